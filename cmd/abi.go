@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/abi"
+	"github.com/spf13/cobra"
+)
+
+var abiCmd = &cobra.Command{
+	Use:   "abi [input] [output]",
+	Short: "Generate an ABI-style summary of the callable surface",
+	Long: `Generate an ABI-style summary from Cadence files or JSON.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+The summary lists, per contract, its addresses, events and the interactions importing it
+and, per script and transaction, its arguments, return type, signers and contracts, plus
+the struct types they use, in a stable layout for third-party integrators.
+The output will be a JSON file (defaults to cadence.abi.json if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputPath := "cadence.abi.json"
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(abi.Build(*report), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+
+		// Create output directory if it doesn't exist
+		err = os.MkdirAll(filepath.Dir(outputPath), 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		err = os.WriteFile(outputPath, data, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write ABI: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	addWithStandardFlag(abiCmd)
+	addDeploymentsFlag(abiCmd)
+	rootCmd.AddCommand(abiCmd)
+}
@@ -7,21 +7,44 @@ import (
 	"path/filepath"
 
 	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/messages"
 	"github.com/spf13/cobra"
 )
 
 var (
 	includeBase64 bool
+	cleanImports  bool
 	resolveNested bool
 	network       string
+	signKeyPath   string
+	messagesPath  string
+	localesDir    string
 )
 
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze [input] [output]",
 	Short: "Analyze Cadence files and generate JSON report",
 	Long: `Analyze Cadence files and generate a JSON report.
-The input can be either a single .cdc file or a directory containing .cdc files.
-The output will be a JSON file containing the analysis result. If output is not specified, it defaults to 'cadence.json'.`,
+The input can be either a single .cdc file or a directory containing .cdc files,
+a .zip/.tar.gz archive (local path or http(s) URL) of such a directory, or a git
+repository URL with an optional ref (e.g. https://github.com/org/repo.git#v1.0.0).
+FLIX interaction templates (.json) are analyzed like .cdc files of the same name, adding the
+addresses of their dependencies to the report.
+The output will be a JSON file containing the analysis result. If output is not specified, it defaults to 'cadence.json'.
+With --with-standard ft,nft, the built-in FT and NFT standard interactions are merged into the report,
+with the mainnet and testnet addresses of the standard contracts they import.
+With --deployments, contract files get deploy_<contract> and update_<contract> transactions embedding their code.
+With --sign-key, the report gets a manifest of the code hashes signed with the Ed25519 key, and
+generated TypeScript and Swift clients can verify at runtime that their embedded code is unchanged.
+Contracts vendored with the vendor command are read from the cadence-codegen.lock lockfile of
+the working directory instead of being fetched from chain.
+Tags are derived with the tagStrategy of cadence-codegen.json: directory (the full directory
+path, default), first-dir (the first directory below the input), pragma (// codegen:tag <name>)
+or none.
+With --messages messages.json, the messages of the // codegen:title and // codegen:description
+pragmas, or of the messages of FLIX templates, are extracted into a catalog per interaction for translation.
+With --locales locales, the catalogs translated into other locales in the directory, e.g. locales/fr-FR.json,
+are merged into the translations of the report, next to those of FLIX templates.`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		inputPath := args[0]
@@ -33,9 +56,20 @@ The output will be a JSON file containing the analysis result. If output is not
 		// Create analyzer
 		a := analyzer.New()
 		a.SetIncludeBase64(includeBase64)
+		a.SetCleanImports(cleanImports)
+		a.SetDeployments(deployments)
+		if err := configureAnalyzer(a); err != nil {
+			return err
+		}
+
+		inputPath, cleanup, err := fetchInput(a, inputPath)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
 
 		// Analyze directory
-		err := a.AnalyzeDirectory(inputPath)
+		err = a.AnalyzeDirectory(inputPath)
 		if err != nil {
 			return fmt.Errorf("failed to analyze directory: %w", err)
 		}
@@ -58,6 +92,17 @@ The output will be a JSON file containing the analysis result. If output is not
 
 		// Get the report
 		report := a.GetReport()
+		if err := mergeStandard(report); err != nil {
+			return err
+		}
+		if err := mergeLocales(report, localesDir); err != nil {
+			return err
+		}
+		if signKeyPath != "" {
+			if err := signReport(report, signKeyPath); err != nil {
+				return err
+			}
+		}
 
 		// Marshal to JSON with indentation
 		jsonData, err := json.MarshalIndent(report, "", "  ")
@@ -71,13 +116,58 @@ The output will be a JSON file containing the analysis result. If output is not
 			return fmt.Errorf("failed to write JSON file: %w", err)
 		}
 
+		if messagesPath != "" {
+			if err := messages.Extract(*report).Save(messagesPath); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	},
 }
 
+// mergeLocales merges the message bundles of dir into report, if dir is set
+func mergeLocales(report *analyzer.Report, dir string) error {
+	if dir == "" {
+		return nil
+	}
+	bundles, err := messages.LoadBundles(dir)
+	if err != nil {
+		return err
+	}
+	for _, bundle := range bundles {
+		for _, fileName := range bundle.Merge(report) {
+			fmt.Fprintf(os.Stderr, "Warning: %s messages of %s match no script or transaction\n", bundle.Locale, fileName)
+		}
+	}
+	return nil
+}
+
+// signReport signs the code of report with the Ed25519 key at keyPath
+func signReport(report *analyzer.Report, keyPath string) error {
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read signing key: %w", err)
+	}
+	key, err := analyzer.ParseSigningKey(data)
+	if err != nil {
+		return fmt.Errorf("failed to load signing key: %w", err)
+	}
+	if err := report.Sign(key); err != nil {
+		return fmt.Errorf("failed to sign report: %w", err)
+	}
+	return nil
+}
+
 func init() {
 	analyzeCmd.Flags().BoolVar(&includeBase64, "base64", true, "Include base64-encoded Cadence files in the output")
+	analyzeCmd.Flags().BoolVar(&cleanImports, "clean-imports", false, "Remove unused imports from the embedded Cadence code")
 	analyzeCmd.Flags().BoolVar(&resolveNested, "resolve-nested", true, "Resolve nested types by fetching contracts from chain")
 	analyzeCmd.Flags().StringVar(&network, "network", "mainnet", "Network to use for resolving nested types (mainnet/testnet)")
+	analyzeCmd.Flags().StringVar(&signKeyPath, "sign-key", "", "PEM encoded Ed25519 private key signing a manifest of the code hashes")
+	analyzeCmd.Flags().StringVar(&messagesPath, "messages", "", "Write the messages catalog of the interactions (e.g. messages.json)")
+	analyzeCmd.Flags().StringVar(&localesDir, "locales", "", "Directory of message catalogs translated into other locales (e.g. locales/fr-FR.json)")
+	addWithStandardFlag(analyzeCmd)
+	addDeploymentsFlag(analyzeCmd)
 	rootCmd.AddCommand(analyzeCmd)
 }
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/outblock/cadence-codegen/internal/diff"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changelogVersion string
+	changelogCurrent string
+	changelogDate    string
+	changelogOutput  string
+)
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog [old] [new]",
+	Short: "Render a changelog section from two reports",
+	Long: `Render a Markdown CHANGELOG section listing the added, changed and removed
+scripts, transactions, structs and events between two reports, ready to paste
+into the release notes of the generated SDK.
+Each input can be a .cdc file, a directory containing .cdc files or a JSON file
+previously generated by the analyze command.
+The section is titled with --version, or with the version following --current
+according to the suggested semantic version bump, and defaults to Unreleased.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldReport, err := loadReport(args[0])
+		if err != nil {
+			return err
+		}
+		newReport, err := loadReport(args[1])
+		if err != nil {
+			return err
+		}
+
+		result := diff.Compare(*oldReport, *newReport)
+
+		version := changelogVersion
+		if version == "" && changelogCurrent != "" {
+			version, err = diff.NextVersion(changelogCurrent, result.Bump)
+			if err != nil {
+				return err
+			}
+		}
+		date := changelogDate
+		if version == "" {
+			version = "Unreleased"
+		} else if date == "" {
+			date = time.Now().Format("2006-01-02")
+		}
+
+		changelog := result.Changelog(version, date)
+		if changelogOutput == "" {
+			fmt.Fprint(cmd.OutOrStdout(), changelog)
+			return nil
+		}
+		if err := os.WriteFile(changelogOutput, []byte(changelog), 0644); err != nil {
+			return fmt.Errorf("failed to write changelog: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	changelogCmd.Flags().StringVar(&changelogVersion, "version", "", "Version of the changelog section")
+	changelogCmd.Flags().StringVar(&changelogCurrent, "current", "", "Current SDK version to apply the suggested bump to")
+	changelogCmd.Flags().StringVar(&changelogDate, "date", "", "Release date of the section (defaults to today for versioned sections)")
+	changelogCmd.Flags().StringVar(&changelogOutput, "output", "", "Write the section to a file instead of stdout")
+	rootCmd.AddCommand(changelogCmd)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/outblock/cadence-codegen/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+var checkFormat string
+
+var checkCmd = &cobra.Command{
+	Use:   "check [input]",
+	Short: "Check that Cadence files parse",
+	Long: `Parse every Cadence file and report its syntax errors.
+The input can be either a single .cdc file or a directory containing .cdc files.
+Errors are printed as file:line:column: error [syntax] message, or as a JSON array of
+diagnostics with --format json. Unlike lint, no conventions are checked and no report
+is generated, so the command suits pre-commit hooks. It exits with an error if any
+file fails to parse.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		diagnostics, files, err := lint.CheckDirectory(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to check input: %w", err)
+		}
+
+		switch checkFormat {
+		case "json":
+			if diagnostics == nil {
+				diagnostics = []lint.Diagnostic{}
+			}
+			jsonData, err := json.MarshalIndent(diagnostics, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+		case "text":
+			for _, d := range diagnostics {
+				fmt.Fprintln(cmd.OutOrStdout(), d.String())
+			}
+		default:
+			return fmt.Errorf("unsupported format: %s", checkFormat)
+		}
+
+		if len(diagnostics) > 0 {
+			failed := make(map[string]bool)
+			for _, d := range diagnostics {
+				failed[d.File] = true
+			}
+			cmd.SilenceUsage = true
+			return fmt.Errorf("check found syntax errors in %d of %d files", len(failed), files)
+		}
+		return nil
+	},
+}
+
+func init() {
+	checkCmd.Flags().StringVar(&checkFormat, "format", "text", "Output format (text/json)")
+	rootCmd.AddCommand(checkCmd)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/outblock/cadence-codegen/internal/coverage"
+	"github.com/spf13/cobra"
+)
+
+var coverageFormat string
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage [input]",
+	Short: "Report which Cadence files produced bindings in each target",
+	Long: `Report which Cadence files produced bindings in each target.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+The report maps every analyzed .cdc file to whether it produced Swift, TypeScript
+and Go bindings, lists which referenced struct types were resolved and which
+contracts could not be fetched from chain.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := loadReport(args[0])
+		if err != nil {
+			return err
+		}
+
+		result, err := coverage.Build(*report)
+		if err != nil {
+			return fmt.Errorf("failed to build coverage report: %w", err)
+		}
+
+		switch coverageFormat {
+		case "json":
+			jsonData, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+		case "text":
+			fmt.Fprint(cmd.OutOrStdout(), result.Table())
+		default:
+			return fmt.Errorf("unsupported format: %s", coverageFormat)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	coverageCmd.Flags().StringVar(&coverageFormat, "format", "text", "Output format (text/json)")
+	rootCmd.AddCommand(coverageCmd)
+}
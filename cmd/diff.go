@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/outblock/cadence-codegen/internal/diff"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat         string
+	diffCurrentVersion string
+	diffFailOnBreaking bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [old] [new]",
+	Short: "Compare two reports and suggest a semantic version bump",
+	Long: `Compare two reports and suggest a semantic version bump for the generated SDK.
+Each input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+Added scripts, transactions, structs, events and struct fields are minor changes.
+Removed ones, and added, removed, renamed or retyped parameters are major changes.
+Code changes that keep all signatures are patch changes.
+Major changes are breaking for code using the generated SDK, all others are not. With
+--fail-on-breaking, the command exits with an error if any breaking change is found, to gate releases.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldReport, err := loadReport(args[0])
+		if err != nil {
+			return err
+		}
+		newReport, err := loadReport(args[1])
+		if err != nil {
+			return err
+		}
+
+		result := diff.Compare(*oldReport, *newReport)
+
+		var nextVersion string
+		if diffCurrentVersion != "" {
+			nextVersion, err = diff.NextVersion(diffCurrentVersion, result.Bump)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch diffFormat {
+		case "json":
+			jsonData, err := json.MarshalIndent(struct {
+				*diff.Result
+				NextVersion string `json:"nextVersion,omitempty"`
+			}{result, nextVersion}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+		case "text":
+			for _, change := range result.Changes {
+				fmt.Fprintln(cmd.OutOrStdout(), change.String())
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Breaking changes: %d\n", result.Breaking)
+			fmt.Fprintf(cmd.OutOrStdout(), "Suggested bump: %s\n", result.Bump)
+			if nextVersion != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Next version: %s\n", nextVersion)
+			}
+		default:
+			return fmt.Errorf("unsupported format: %s", diffFormat)
+		}
+
+		if diffFailOnBreaking && result.Breaking > 0 {
+			return fmt.Errorf("found %d breaking change(s)", result.Breaking)
+		}
+		return nil
+	},
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffFormat, "format", "text", "Output format (text/json)")
+	diffCmd.Flags().StringVar(&diffCurrentVersion, "current", "", "Current SDK version to apply the suggested bump to")
+	diffCmd.Flags().BoolVar(&diffFailOnBreaking, "fail-on-breaking", false, "Exit with an error if a breaking change is found")
+	rootCmd.AddCommand(diffCmd)
+}
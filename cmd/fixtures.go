@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/fixtures"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+	"github.com/spf13/cobra"
+)
+
+var fixturesVariant string
+
+var fixturesCmd = &cobra.Command{
+	Use:   "fixtures [input] [output]",
+	Short: "Generate JSON fixtures of structs and script results",
+	Long: `Generate JSON fixtures of structs and script results.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+Every struct and the result of every script gets a sample payload in the JSON
+shape returned by fcl, for mocks, tests and design tools. Scripts are keyed by
+the name of their generated TypeScript function.
+With --variant min, optionals are null, collections empty and numbers at the
+minimum of their type; with --variant max, collections hold three elements and
+numbers are at their maximum. Integers decoded into JavaScript numbers stay
+within the safe integer range.
+The output will be a JSON file (defaults to cadence.fixtures.json if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputPath := "cadence.fixtures.json"
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+
+		gen := fixtures.New(*report)
+		if err := gen.SetVariant(fixtures.Variant(fixturesVariant)); err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(gen.Catalog(typescript.FunctionName), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+
+		// Create output directory if it doesn't exist
+		err = os.MkdirAll(filepath.Dir(outputPath), 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		err = os.WriteFile(outputPath, append(data, '\n'), 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write fixtures: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	fixturesCmd.Flags().StringVar(&fixturesVariant, "variant", "sample", "Values of the fixtures (sample/min/max)")
+	rootCmd.AddCommand(fixturesCmd)
+}
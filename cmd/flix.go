@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/flix"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flixVerifyFormat string
+	flixLocalesDir   string
+)
+
+var flixCmd = &cobra.Command{
+	Use:   "flix",
+	Short: "Work with FLIX interaction templates",
+}
+
+var flixGenerateCmd = &cobra.Command{
+	Use:   "generate [input] [output]",
+	Short: "Generate FLIX interaction templates from Cadence files or JSON",
+	Long: `Generate a FLIX 1.1.0 interaction template per script and transaction.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+Templates are written below the output directory (defaults to flix) at the path of their
+source with a .json extension, e.g. scripts/get_balance.json, so that flix verify matches them
+with their sources. Imports are published by contract name and the dependencies list the
+addresses of the report per network. The messages of the // codegen:title and
+// codegen:description pragmas become the en-US messages of the templates, and with --locales
+locales the catalogs translated into other locales, e.g. locales/fr-FR.json, are added to their
+i18n sections. Scripts get the JSON
+schema of their decoded result under data.result_schema, so wallets can render it. The
+template ID is computed from the FLIX 1.1.0 fields, without the result schema.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputDir := "flix"
+		if len(args) > 1 {
+			outputDir = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := mergeLocales(report, flixLocalesDir); err != nil {
+			return err
+		}
+
+		templates, err := flix.Generate(*report)
+		if err != nil {
+			return err
+		}
+		for _, generated := range templates {
+			data, err := json.MarshalIndent(generated.Template, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			outputPath := filepath.Join(outputDir, filepath.FromSlash(generated.Path))
+			if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			if err := os.WriteFile(outputPath, append(data, '\n'), 0644); err != nil {
+				return fmt.Errorf("failed to write template: %w", err)
+			}
+		}
+		return nil
+	},
+}
+
+var flixVerifyCmd = &cobra.Command{
+	Use:   "verify [templates] [sources]",
+	Short: "Check that local Cadence files still match published FLIX templates",
+	Long: `Check that local .cdc files still match the code of published FLIX interaction templates.
+The templates can be a single FLIX .json file or a directory of them, and the sources a .cdc
+file or a directory of .cdc files. A template is matched with the source of the same relative
+path, e.g. transfer_tokens.json with transfer_tokens.cdc, or else with the only source of the
+same file name. The imports of a source are written the way the template publishes them and
+the SHA3-256 of both codes, without surrounding whitespace, is compared. The ID of every 1.0.0
+and 1.1.0 template is recomputed from its fields and InteractionTemplateAudit files among the
+templates must audit one of the IDs; audit signatures are not verified. The command exits with
+an error if a source changed or is missing, an ID does not match or an audit is stale.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		results, err := flix.Verify(args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		switch flixVerifyFormat {
+		case "json":
+			jsonData, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+		case "text":
+			for _, result := range results {
+				fmt.Fprintln(cmd.OutOrStdout(), result.String())
+			}
+		default:
+			return fmt.Errorf("unsupported format: %s", flixVerifyFormat)
+		}
+
+		if flix.Failed(results) {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("the published templates do not match their sources, IDs or audits")
+		}
+		return nil
+	},
+}
+
+func init() {
+	flixVerifyCmd.Flags().StringVar(&flixVerifyFormat, "format", "text", "Output format (text/json)")
+	flixGenerateCmd.Flags().StringVar(&flixLocalesDir, "locales", "", "Directory of message catalogs translated into other locales (e.g. locales/fr-FR.json)")
+	addFilterFlags(flixGenerateCmd)
+	addWithStandardFlag(flixGenerateCmd)
+	addDeploymentsFlag(flixGenerateCmd)
+	flixCmd.AddCommand(flixGenerateCmd)
+	flixCmd.AddCommand(flixVerifyCmd)
+	rootCmd.AddCommand(flixCmd)
+}
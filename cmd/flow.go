@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/flowcli"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+	"github.com/spf13/cobra"
+)
+
+var flowWriteAddresses bool
+
+var flowCmd = &cobra.Command{
+	Use:   "flow [project] [output]",
+	Short: "Generate Flow CLI aliases from a flow.json project",
+	Long: `Generate Flow CLI aliases from a flow.json project.
+Scripts and transactions are read from cadence/scripts and cadence/transactions
+(or scripts and transactions) in the project directory. Every generated function
+is mapped to its file and the matching "flow scripts execute" or
+"flow transactions send" command. Contract addresses are collected from the
+contract and dependency aliases and the deployments of flow.json.
+With --write-addresses, the addresses are also written to addresses.json in the
+project directory so that the other commands resolve imports the same way as the Flow CLI.
+The output will be a JSON file (defaults to flow.aliases.json if not specified).`,
+	Args: cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir := "."
+		if len(args) > 0 {
+			projectDir = args[0]
+		}
+		outputPath := "flow.aliases.json"
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		project, err := flowcli.LoadProject(projectDir)
+		if err != nil {
+			return err
+		}
+
+		catalog, err := project.Catalog(typescript.FunctionName)
+		if err != nil {
+			return err
+		}
+
+		// Keep the <name:Type> placeholders of the commands readable
+		var jsonData bytes.Buffer
+		encoder := json.NewEncoder(&jsonData)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(catalog); err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		err = os.MkdirAll(filepath.Dir(outputPath), 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		err = os.WriteFile(outputPath, jsonData.Bytes(), 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write JSON file: %w", err)
+		}
+
+		if flowWriteAddresses {
+			addressesData, err := json.MarshalIndent(catalog.Addresses, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal addresses: %w", err)
+			}
+			err = os.WriteFile(filepath.Join(projectDir, "addresses.json"), addressesData, 0644)
+			if err != nil {
+				return fmt.Errorf("failed to write addresses.json: %w", err)
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	flowCmd.Flags().BoolVar(&flowWriteAddresses, "write-addresses", false, "Write contract addresses from flow.json to addresses.json in the project directory")
+	rootCmd.AddCommand(flowCmd)
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/plugin"
+	"github.com/outblock/cadence-codegen/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateTargetSpecs []string
+	generateOutputDir   string
+	generatePlugins     []string
+)
+
+// generator writes the code of a target from a filtered report
+type generator struct {
+	names    string // Target of the names file it reads, empty if none
+	generate func(report *analyzer.Report, outputPath string) error
+}
+
+// generators are the targets of the generate command, by name
+var generators = map[string]generator{
+	"typescript": {names: "typescript", generate: func(report *analyzer.Report, outputPath string) error {
+		return generateTypeScript(report, outputPath, false)
+	}},
+	"swift":  {names: "swift", generate: generateSwift},
+	"golang": {names: "golang", generate: generateGo},
+	"kotlin": {names: "kotlin", generate: generateKotlin},
+	"trpc":   {names: "typescript", generate: generateTRPC},
+	"nuxt":   {names: "typescript", generate: generateNuxt},
+	"solid":  {names: "typescript", generate: generateSolid},
+	"rest":   {names: "typescript", generate: generateREST},
+	"grpc":   {generate: generateGRPC},
+}
+
+// generateTarget is a target or plugin of the generate command and its
+// output path
+type generateTarget struct {
+	name   string
+	output string
+	plugin bool
+}
+
+var generateCmd = &cobra.Command{
+	Use:   "generate [input]",
+	Short: "Generate several targets from a single analysis",
+	Long: `Generate several targets from a single analysis of the input.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+--targets lists the targets (` + strings.Join(scaffold.TargetNames(), ", ") + `, or all), each
+optionally followed by its output path, e.g. typescript,swift=ios/CadenceGen.swift. Other targets
+are written to their default path below --output-dir, as set up by the init command.
+Every target is generated with the default options of its command. The tRPC, Nuxt, SolidJS and
+REST targets import the TypeScript output if it is generated too.
+With --plugin ./my-gen=lib/cadence.dart, the executable ./my-gen is run with the report JSON on stdin and
+what it writes to stdout is written to lib/cadence.dart. The output path is also set in CADENCE_CODEGEN_OUTPUT.
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are generated.
+With --baseline old.json, generation fails if a function of the previous report disappears or changes signature,
+or else with --names if a function recorded in the names file disappears, unless --allow-breaking is set.
+With --names names.json, the recorded function names of each target are kept and names of new files are recorded.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targets, err := parseGenerateTargets(generateTargetSpecs, generateOutputDir)
+		if err != nil {
+			return err
+		}
+		plugins, err := parsePlugins(generatePlugins)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, plugins...)
+		if len(targets) == 0 {
+			return fmt.Errorf("no targets given, use --targets or --plugin")
+		}
+
+		report, err := loadReport(args[0])
+		if err != nil {
+			return err
+		}
+		// Breaking changes are checked once per names file target, or once
+		// against the baseline. Plugins have no names file.
+		checked := make(map[string]bool)
+		for _, target := range targets {
+			names := generators[target.name].names
+			if baselinePath != "" || target.plugin {
+				names = ""
+			}
+			if checked[names] {
+				continue
+			}
+			checked[names] = true
+			if err := guardBreaking(names, report); err != nil {
+				return err
+			}
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := warnReport(report); err != nil {
+			return err
+		}
+
+		for _, target := range targets {
+			if target.name != "typescript" {
+				continue
+			}
+			for _, servicePath := range []*string{&trpcServicePath, &nuxtServicePath, &solidServicePath, &restServicePath} {
+				if *servicePath == "" {
+					*servicePath = target.output
+				}
+			}
+		}
+
+		// Every target gets its own copy of the report, like a report file
+		jsonData, err := json.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		for _, target := range targets {
+			targetReport := &analyzer.Report{}
+			if err := json.Unmarshal(jsonData, targetReport); err != nil {
+				return fmt.Errorf("failed to parse JSON: %w", err)
+			}
+			if target.plugin {
+				err = generatePlugin(cmd, target, targetReport)
+			} else {
+				err = generators[target.name].generate(targetReport, target.output)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to generate %s: %w", target.name, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Generated %s: %s\n", target.name, target.output)
+		}
+		return nil
+	},
+}
+
+// parseGenerateTargets parses target specs such as typescript or
+// swift=ios/CadenceGen.swift, expanding all to every target, into targets in
+// the order given
+func parseGenerateTargets(specs []string, outputDir string) ([]generateTarget, error) {
+	var targets []generateTarget
+	seen := make(map[string]bool)
+	add := func(name string, output string) error {
+		target, ok := scaffold.Targets[name]
+		if !ok {
+			return fmt.Errorf("unsupported target: %s", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("target %s is listed twice", name)
+		}
+		seen[name] = true
+		if output == "" {
+			output = filepath.Join(outputDir, filepath.FromSlash(target.Output))
+		}
+		targets = append(targets, generateTarget{name: name, output: output})
+		return nil
+	}
+	for _, spec := range specs {
+		name, output, _ := strings.Cut(spec, "=")
+		if name == "all" {
+			for _, name := range scaffold.TargetNames() {
+				if !seen[name] {
+					if err := add(name, ""); err != nil {
+						return nil, err
+					}
+				}
+			}
+			continue
+		}
+		if err := add(name, output); err != nil {
+			return nil, err
+		}
+	}
+	return targets, nil
+}
+
+// parsePlugins parses plugin specs such as ./my-gen=lib/cadence.dart into
+// targets named after the executable
+func parsePlugins(specs []string) ([]generateTarget, error) {
+	var targets []generateTarget
+	for _, spec := range specs {
+		path, output, _ := strings.Cut(spec, "=")
+		if path == "" {
+			return nil, fmt.Errorf("invalid plugin %q, expected executable=output", spec)
+		}
+		if output == "" {
+			return nil, fmt.Errorf("plugin %s has no output path, use --plugin %s=path", path, path)
+		}
+		targets = append(targets, generateTarget{name: path, output: output, plugin: true})
+	}
+	return targets, nil
+}
+
+// generatePlugin runs the plugin of target with report and writes its output,
+// only if the plugin succeeds
+func generatePlugin(cmd *cobra.Command, target generateTarget, report *analyzer.Report) error {
+	code, err := plugin.Run(target.name, report, target.output, cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target.output), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(target.output, code, 0644); err != nil {
+		return fmt.Errorf("failed to write plugin output: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	generateCmd.Flags().StringSliceVar(&generateTargetSpecs, "targets", nil, "Comma separated targets with optional output paths (e.g. typescript,swift=ios/CadenceGen.swift or all)")
+	generateCmd.Flags().StringArrayVar(&generatePlugins, "plugin", nil, "Plugin executable and the path its output is written to (e.g. ./my-gen=lib/cadence.dart), repeatable")
+	generateCmd.Flags().StringVar(&generateOutputDir, "output-dir", "generated", "Directory of the outputs of targets without an output path")
+	addWithStandardFlag(generateCmd)
+	addDeploymentsFlag(generateCmd)
+	addFilterFlags(generateCmd)
+	addBreakingFlags(generateCmd)
+	addNamesFlag(generateCmd)
+	rootCmd.AddCommand(generateCmd)
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/golang"
+	"github.com/spf13/cobra"
+)
+
+var (
+	goPackageName string
+	goMock        bool
+	goFlowSDK     bool
+)
+
+var golangCmd = &cobra.Command{
+	Use:   "golang [input] [output]",
+	Short: "Generate Go code from Cadence files or JSON",
+	Long: `Generate Go code from Cadence files or JSON.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+The generated code contains Go structs for Cadence structs and functions that
+encode script/transaction arguments and decode script results in the JSON-Cadence
+interchange format, without depending on the Cadence runtime.
+With --mock, a Transport interface, a Client running the scripts and transactions over
+it and an in-memory FakeTransport returning fixtures are generated as well, so services
+using the Client can be unit tested without an emulator.
+With --flow-sdk, the Client and a FlowTransport running it with a flow-go-sdk access client
+and signer are generated, so Go services call scripts and transactions without encoding
+arguments by hand. The imports of the code are resolved with the contract addresses of the
+network the FlowTransport is created for.
+With --with-standard ft,nft, the built-in FT and NFT standard interactions are merged into the report.
+With --deployments, contract files get deploy_<contract> and update_<contract> transactions embedding their code.
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are generated.
+With --names names.json, the function names recorded for golang in the names file are kept even if the naming
+algorithm changes, and names of new files are recorded.
+With --baseline old.json, generation fails if a function of the previous report disappears or changes signature,
+or else with --names if a function recorded in the names file disappears, unless --allow-breaking is set.
+The output will be a Go file (defaults to cadence_gen.go if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputPath := "cadence_gen.go"
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if err := guardBreaking("golang", report); err != nil {
+			return err
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := warnReport(report); err != nil {
+			return err
+		}
+
+		return generateGo(report, outputPath)
+	},
+}
+
+// generateGo writes the Go code of report to outputPath
+func generateGo(report *analyzer.Report, outputPath string) error {
+	// Create output directory if it doesn't exist
+	err := os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Generate Go code
+	gen := golang.New(*report)
+	gen.SetPackageName(goPackageName)
+	gen.SetMock(goMock)
+	gen.SetFlowSDK(goFlowSDK)
+	if err := loadNames("golang", gen); err != nil {
+		return err
+	}
+	code, err := gen.Generate()
+	if err != nil {
+		return fmt.Errorf("failed to generate Go code: %w", err)
+	}
+
+	// Write the generated code to file
+	err = os.WriteFile(outputPath, []byte(code), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write Go code: %w", err)
+	}
+
+	return saveNames("golang", gen)
+}
+
+func init() {
+	golangCmd.Flags().StringVar(&goPackageName, "package", "cadencegen", "Package name of the generated Go code")
+	golangCmd.Flags().BoolVar(&goMock, "mock", false, "Generate a Transport interface, a Client using it and an in-memory FakeTransport for unit tests")
+	golangCmd.Flags().BoolVar(&goFlowSDK, "flow-sdk", false, "Generate a Client with a FlowTransport using a flow-go-sdk access client")
+	addWithStandardFlag(golangCmd)
+	addDeploymentsFlag(golangCmd)
+	addFilterFlags(golangCmd)
+	addBreakingFlags(golangCmd)
+	addNamesFlag(golangCmd)
+	rootCmd.AddCommand(golangCmd)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/graph"
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+
+var graphCmd = &cobra.Command{
+	Use:   "graph [input] [output]",
+	Short: "Generate a dependency graph from Cadence files or JSON",
+	Long: `Generate a dependency graph from Cadence files or JSON.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+The graph links scripts and transactions to the contracts they import and the
+struct types they reference, and contracts to their nested struct types.
+Unresolved struct types are drawn dashed in DOT output.
+The output will be a DOT or JSON file (defaults to cadence.graph.dot or cadence.graph.json if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputPath := "cadence.graph." + graphFormat
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+
+		g := graph.Build(*report)
+
+		var data []byte
+		switch graphFormat {
+		case "dot":
+			data = []byte(g.DOT())
+		case "json":
+			data, err = json.MarshalIndent(g, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+		default:
+			return fmt.Errorf("unsupported format: %s", graphFormat)
+		}
+
+		// Create output directory if it doesn't exist
+		err = os.MkdirAll(filepath.Dir(outputPath), 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		err = os.WriteFile(outputPath, data, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write graph: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot", "Output format (dot/json)")
+	rootCmd.AddCommand(graphCmd)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/grpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	grpcProtoPackage string
+	grpcGoPackage    string
+	grpcServerName   string
+)
+
+var grpcCmd = &cobra.Command{
+	Use:   "grpc [input] [output-dir]",
+	Short: "Generate a gRPC service definition from Cadence files or JSON",
+	Long: `Generate a gRPC service definition and Go server skeleton from Cadence files or JSON.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+Each script and transaction becomes an RPC of the CadenceService with request and
+response messages derived from its parameters and return type, and each Cadence
+struct becomes a message. Values protobuf cannot represent, such as nested arrays,
+are passed as JSON-Cadence strings.
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are generated.
+With --baseline old.json, generation fails if a function of the previous report disappears or changes
+signature, unless --allow-breaking is set.
+The output directory (defaults to grpc if not specified) receives cadence.proto and
+server.go, a skeleton implementing the service with the code generated by protoc.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputDir := "grpc"
+		if len(args) > 1 {
+			outputDir = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if err := guardBreaking("", report); err != nil {
+			return err
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := warnReport(report); err != nil {
+			return err
+		}
+
+		return generateGRPC(report, outputDir)
+	},
+}
+
+// generateGRPC writes the proto file and Go server of report to outputDir
+func generateGRPC(report *analyzer.Report, outputDir string) error {
+	gen := grpc.New(*report)
+	gen.SetProtoPackage(grpcProtoPackage)
+	gen.SetGoPackage(grpcGoPackage)
+	gen.SetServerName(grpcServerName)
+
+	proto, err := gen.GenerateProto()
+	if err != nil {
+		return fmt.Errorf("failed to generate proto file: %w", err)
+	}
+	server, err := gen.GenerateServer()
+	if err != nil {
+		return fmt.Errorf("failed to generate Go server: %w", err)
+	}
+
+	// Create output directory if it doesn't exist
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "cadence.proto"), []byte(proto), 0644); err != nil {
+		return fmt.Errorf("failed to write proto file: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "server.go"), []byte(server), 0644); err != nil {
+		return fmt.Errorf("failed to write Go server: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	grpcCmd.Flags().StringVar(&grpcProtoPackage, "proto-package", "cadencegen", "Package of the generated .proto file")
+	grpcCmd.Flags().StringVar(&grpcGoPackage, "go-package", "cadencegen/pb", "Import path of the Go code generated by protoc (go_package option)")
+	grpcCmd.Flags().StringVar(&grpcServerName, "package", "server", "Package name of the generated Go server skeleton")
+	addFilterFlags(grpcCmd)
+	addBreakingFlags(grpcCmd)
+	rootCmd.AddCommand(grpcCmd)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+	"github.com/spf13/cobra"
+)
+
+var indexerCmd = &cobra.Command{
+	Use:   "indexer [input] [output]",
+	Short: "Generate a TypeScript event indexer client from Cadence files or JSON",
+	Long: `Generate a TypeScript event indexer client from Cadence files or JSON.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+Events declared in analyzed contracts become typed interfaces with an
+EventIndexer class that polls the Access API, decodes event payloads and
+calls a handler per event type.
+The output will be a TypeScript file (defaults to cadence.indexer.ts if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputPath := "cadence.indexer.ts"
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if len(report.Events) == 0 {
+			fmt.Fprintln(os.Stderr, "Warning: no events found in input")
+		}
+
+		// Create output directory if it doesn't exist
+		err = os.MkdirAll(filepath.Dir(outputPath), 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		// Generate indexer code
+		gen := typescript.New(*report)
+		code, err := gen.GenerateIndexer()
+		if err != nil {
+			return fmt.Errorf("failed to generate indexer code: %w", err)
+		}
+
+		// Write the generated code to file
+		err = os.WriteFile(outputPath, []byte(code), 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write indexer code: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(indexerCmd)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/config"
+	"github.com/outblock/cadence-codegen/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+var (
+	initTargets   []string
+	initOutputDir string
+	initForce     bool
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Scaffold a cadence-codegen config in the current directory",
+	Long: `Inspect the current directory and scaffold a project.
+The folders holding .cdc files, flow.json and addresses.json are detected, a starter
+cadence-codegen.json is written and an output directory is created for each of the
+targets chosen with --targets (typescript by default, one of ` + strings.Join(scaffold.TargetNames(), ", ") + `)
+below --output-dir. The commands generating the targets are printed.
+An existing cadence-codegen.json is only overwritten with --force.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, name := range initTargets {
+			if _, ok := scaffold.Targets[name]; !ok {
+				return fmt.Errorf("unsupported target: %s", name)
+			}
+		}
+		if _, err := os.Stat(config.DefaultFile); err == nil && !initForce {
+			return fmt.Errorf("%s already exists, use --force to overwrite it", config.DefaultFile)
+		}
+
+		project, err := scaffold.Inspect(".")
+		if err != nil {
+			return err
+		}
+		if len(project.CadenceDirs) == 0 {
+			return fmt.Errorf("no .cdc files found in the current directory")
+		}
+
+		cfg := config.Default()
+		if wd, err := os.Getwd(); err == nil {
+			cfg.App.Title = filepath.Base(wd)
+		}
+		jsonData, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		if err := os.WriteFile(config.DefaultFile, append(jsonData, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write config file: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Wrote %s\n", config.DefaultFile)
+		fmt.Fprintf(out, "Cadence folders: %s\n", strings.Join(project.CadenceDirs, ", "))
+		switch {
+		case project.AddressesJSON != "":
+			fmt.Fprintf(out, "Contract addresses: %s\n", project.AddressesJSON)
+		case project.FlowJSON:
+			fmt.Fprintln(out, "No addresses.json found, write one from flow.json with: cadence-codegen flow --write-addresses")
+		default:
+			fmt.Fprintln(out, "No addresses.json or flow.json found, nested contract types will not be resolved")
+		}
+
+		fmt.Fprintln(out, "Generate with:")
+		fmt.Fprintf(out, "  cadence-codegen analyze %s cadence.json\n", project.Input)
+		for _, name := range initTargets {
+			target := scaffold.Targets[name]
+			output := path.Join(filepath.ToSlash(initOutputDir), target.Output)
+			dir := path.Dir(output)
+			if path.Ext(output) == "" {
+				dir = output
+			}
+			if err := os.MkdirAll(filepath.FromSlash(dir), 0755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			fmt.Fprintf(out, "  cadence-codegen %s cadence.json %s\n", target.Command, output)
+		}
+		return nil
+	},
+}
+
+func init() {
+	initCmd.Flags().StringSliceVar(&initTargets, "targets", []string{"typescript"}, "Comma separated targets to set up (e.g. typescript,swift)")
+	initCmd.Flags().StringVar(&initOutputDir, "output-dir", "generated", "Directory of the generated code")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite an existing cadence-codegen.json")
+	rootCmd.AddCommand(initCmd)
+}
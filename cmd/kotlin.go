@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/config"
+	"github.com/outblock/cadence-codegen/internal/generator/kotlin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	kotlinPackageName   string
+	kotlinSerialization string
+	kotlinCoroutines    string
+	kotlinCompose       bool
+)
+
+var kotlinCmd = &cobra.Command{
+	Use:   "kotlin [input] [output]",
+	Short: "Generate Kotlin code from Cadence files or JSON",
+	Long: `Generate Kotlin code from Cadence files or JSON.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+The generated code contains Kotlin data classes for Cadence structs and a CadenceGen class
+using flow-jvm-sdk, with a suspend function per script and transaction. The imports of the
+code are resolved with the contract addresses of the network CadenceGen is created for.
+With --with-standard ft,nft, the built-in FT and NFT standard interactions are merged into the report.
+With --deployments, contract files get deploy_<contract> and update_<contract> transactions embedding their code.
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are generated.
+With --names names.json, the function names recorded for kotlin in the names file are kept even if the naming
+algorithm changes, and names of new files are recorded.
+With --baseline old.json, generation fails if a function of the previous report disappears or changes signature,
+or else with --names if a function recorded in the names file disappears, unless --allow-breaking is set.
+With --serialization kotlinx or moshi, the data classes are annotated for kotlinx.serialization or Moshi.
+With --coroutines flow, scripts and transactions return a cold Flow instead of being suspend functions.
+With --compose, a composable function per script produces the loading, success or error state of its result.
+They default to the kotlin section of cadence-codegen.json in the working directory, or none and suspend.
+The output will be a Kotlin file (defaults to CadenceGen.kt if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputPath := "CadenceGen.kt"
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if err := guardBreaking("kotlin", report); err != nil {
+			return err
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := warnReport(report); err != nil {
+			return err
+		}
+
+		return generateKotlin(report, outputPath)
+	},
+}
+
+// generateKotlin writes the Kotlin code of report to outputPath
+func generateKotlin(report *analyzer.Report, outputPath string) error {
+	// Create output directory if it doesn't exist
+	err := os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	cfg, err := config.Load(config.DefaultFile, false)
+	if err != nil {
+		return err
+	}
+
+	// Generate Kotlin code
+	gen := kotlin.New(*report)
+	gen.SetPackageName(kotlinPackageName)
+	if cfg.Kotlin != nil {
+		gen.SetSerialization(cfg.Kotlin.Serialization)
+		gen.SetCoroutines(cfg.Kotlin.Coroutines)
+		gen.SetCompose(cfg.Kotlin.Compose)
+	}
+	if kotlinSerialization != "" {
+		gen.SetSerialization(kotlinSerialization)
+	}
+	if kotlinCoroutines != "" {
+		gen.SetCoroutines(kotlinCoroutines)
+	}
+	if kotlinCompose {
+		gen.SetCompose(true)
+	}
+	if err := loadNames("kotlin", gen); err != nil {
+		return err
+	}
+	code, err := gen.Generate()
+	if err != nil {
+		return fmt.Errorf("failed to generate Kotlin code: %w", err)
+	}
+
+	// Write the generated code to file
+	err = os.WriteFile(outputPath, []byte(code), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write Kotlin code: %w", err)
+	}
+
+	return saveNames("kotlin", gen)
+}
+
+func init() {
+	kotlinCmd.Flags().StringVar(&kotlinPackageName, "package", "cadencegen", "Package name of the generated Kotlin code")
+	kotlinCmd.Flags().StringVar(&kotlinSerialization, "serialization", "", "Serialization library of the data classes (none/kotlinx/moshi), defaults to the config file")
+	kotlinCmd.Flags().StringVar(&kotlinCoroutines, "coroutines", "", "Coroutine API of the functions (suspend/flow), defaults to the config file")
+	kotlinCmd.Flags().BoolVar(&kotlinCompose, "compose", false, "Generate a Jetpack Compose state function per script, also set in the config file")
+	addWithStandardFlag(kotlinCmd)
+	addDeploymentsFlag(kotlinCmd)
+	addFilterFlags(kotlinCmd)
+	addBreakingFlags(kotlinCmd)
+	addNamesFlag(kotlinCmd)
+	rootCmd.AddCommand(kotlinCmd)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/outblock/cadence-codegen/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintFormat        string
+	lintMaxCodeSize   int
+	lintMaxParameters int
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [input]",
+	Short: "Check Cadence files against codegen conventions",
+	Long: `Check Cadence files against conventions that matter for generated code.
+The input can be either a single .cdc file or a directory containing .cdc files.
+Rules cover parameter naming, missing script return types, unused and missing
+imports, scripts without a main function, files placed in the wrong folder, overly
+long embedded code and long parameter lists. Entry points are resolved as analyze resolves
+them, and files skipped with // codegen:skip or excluded by .codegenignore are not linted.
+The command exits with an error if any diagnostic has error severity.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+
+		l := lint.New()
+		l.SetMaxCodeSize(lintMaxCodeSize)
+		l.SetMaxParameters(lintMaxParameters)
+
+		diagnostics, err := l.LintDirectory(inputPath)
+		if err != nil {
+			return fmt.Errorf("failed to lint input: %w", err)
+		}
+
+		switch lintFormat {
+		case "json":
+			if diagnostics == nil {
+				diagnostics = []lint.Diagnostic{}
+			}
+			jsonData, err := json.MarshalIndent(diagnostics, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+		case "text":
+			for _, d := range diagnostics {
+				fmt.Fprintln(cmd.OutOrStdout(), d.String())
+			}
+		default:
+			return fmt.Errorf("unsupported format: %s", lintFormat)
+		}
+
+		if lint.HasErrors(diagnostics) {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("lint found errors")
+		}
+		return nil
+	},
+}
+
+func init() {
+	lintCmd.Flags().StringVar(&lintFormat, "format", "text", "Output format (text/json)")
+	lintCmd.Flags().IntVar(&lintMaxCodeSize, "max-code-size", lint.DefaultMaxCodeSize, "Maximum size in bytes of embedded Cadence code (0 disables the check)")
+	lintCmd.Flags().IntVar(&lintMaxParameters, "max-parameters", lint.DefaultMaxParameters, "Maximum number of parameters of a script or transaction (0 disables the check)")
+	rootCmd.AddCommand(lintCmd)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/outblock/cadence-codegen/internal/listing"
+	"github.com/spf13/cobra"
+)
+
+var listFormat string
+
+var listCmd = &cobra.Command{
+	Use:   "list [input]",
+	Short: "List the scripts, transactions, structs and tags that would be generated",
+	Long: `List the scripts, transactions, structs and tags that would be generated, with the
+parameter and return types of every script and transaction, without writing a report.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are listed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := loadReport(args[0])
+		if err != nil {
+			return err
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+
+		result := listing.Build(*report)
+		switch listFormat {
+		case "json":
+			jsonData, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+		case "table":
+			fmt.Fprint(cmd.OutOrStdout(), result.Table())
+		default:
+			return fmt.Errorf("unsupported format: %s", listFormat)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format (table/json)")
+	addFilterFlags(listCmd)
+	rootCmd.AddCommand(listCmd)
+}
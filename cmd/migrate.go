@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/outblock/cadence-codegen/internal/lint"
+	"github.com/outblock/cadence-codegen/internal/migrate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateFormat string
+	migrateWrite  bool
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [input]",
+	Short: "Detect and rewrite pre-Cadence 1.0 syntax",
+	Long: `Detect pre-Cadence 1.0 syntax in Cadence files and optionally rewrite it.
+The input can be either a single .cdc file or a directory containing .cdc files.
+Detected constructs include pub/priv access modifiers, AuthAccount and PublicAccount
+types, account storage functions, linking capability APIs, custom destructors and
+restricted types. With --write, fixable findings are rewritten in place; the remaining
+findings need manual changes and make the command exit with an error.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+
+		diagnostics, err := migrate.MigrateDirectory(inputPath, migrateWrite)
+		if err != nil {
+			return fmt.Errorf("failed to migrate input: %w", err)
+		}
+
+		switch migrateFormat {
+		case "json":
+			if diagnostics == nil {
+				diagnostics = []lint.Diagnostic{}
+			}
+			jsonData, err := json.MarshalIndent(diagnostics, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+		case "text":
+			for _, d := range diagnostics {
+				line := d.String()
+				if d.Fixable {
+					if migrateWrite {
+						line += " (fixed)"
+					} else {
+						line += " (fixable)"
+					}
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), line)
+			}
+		default:
+			return fmt.Errorf("unsupported format: %s", migrateFormat)
+		}
+
+		if lint.HasErrors(diagnostics) {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("migration requires manual changes")
+		}
+		return nil
+	},
+}
+
+func init() {
+	migrateCmd.Flags().StringVar(&migrateFormat, "format", "text", "Output format (text/json)")
+	migrateCmd.Flags().BoolVar(&migrateWrite, "write", false, "Rewrite fixable findings in place")
+	rootCmd.AddCommand(migrateCmd)
+}
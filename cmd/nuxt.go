@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+	"github.com/spf13/cobra"
+)
+
+var nuxtServicePath string
+
+var nuxtCmd = &cobra.Command{
+	Use:   "nuxt [input] [output]",
+	Short: "Generate Nuxt 3 composables from Cadence files or JSON",
+	Long: `Generate Nuxt 3 composables from Cadence files or JSON.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+Each script becomes a useAsyncData based composable (e.g. useFlowGetBalance)
+refetching when its arguments change, and each transaction a composable
+returning execute with txId, pending and error refs. fcl is configured lazily
+from runtimeConfig.public.flow, so the composables are safe to use during SSR.
+The composables call the CadenceService generated by the typescript command at --service.
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are generated.
+With --names names.json, the TypeScript function names recorded in the names file are called.
+With --baseline old.json, generation fails if a function of the previous report disappears or changes signature,
+or else with --names if a function recorded in the names file disappears, unless --allow-breaking is set.
+The output will be a TypeScript file (defaults to composables/cadence.ts if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputPath := filepath.Join("composables", "cadence.ts")
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if err := guardBreaking("typescript", report); err != nil {
+			return err
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := warnReport(report); err != nil {
+			return err
+		}
+
+		return generateNuxt(report, outputPath)
+	},
+}
+
+// generateNuxt writes the Nuxt composables of report to outputPath
+func generateNuxt(report *analyzer.Report, outputPath string) error {
+	// Create output directory if it doesn't exist
+	err := os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	servicePath := nuxtServicePath
+	if servicePath == "" {
+		servicePath = filepath.Join(filepath.Dir(outputPath), "cadence.generated.ts")
+	}
+	importPath, err := relativeImportPath(filepath.Dir(outputPath), servicePath)
+	if err != nil {
+		return err
+	}
+
+	// Generate Nuxt composables
+	gen := typescript.New(*report)
+	if err := loadNames("typescript", gen); err != nil {
+		return err
+	}
+	code, err := gen.GenerateNuxt(importPath)
+	if err != nil {
+		return fmt.Errorf("failed to generate Nuxt composables: %w", err)
+	}
+
+	// Write the generated code to file
+	err = os.WriteFile(outputPath, []byte(code), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write Nuxt composables: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	nuxtCmd.Flags().StringVar(&nuxtServicePath, "service", "", "Path of the generated TypeScript service (defaults to cadence.generated.ts next to the output)")
+	addFilterFlags(nuxtCmd)
+	addBreakingFlags(nuxtCmd)
+	addNamesFlag(nuxtCmd)
+	rootCmd.AddCommand(nuxtCmd)
+}
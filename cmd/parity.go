@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/parity"
+	"github.com/spf13/cobra"
+)
+
+var parityFormat string
+
+var parityCmd = &cobra.Command{
+	Use:   "parity [input]",
+	Short: "Compare the imported contracts between mainnet and testnet",
+	Long: `Fetch the contracts imported by the scripts and transactions from mainnet and testnet
+and compare the fields, functions, events and nested types the interactions use.
+The input can be either a single .cdc file, a directory containing .cdc files or a
+JSON file previously generated by the analyze command. Contract addresses come from
+the report. A member that is missing on one network or has a different signature is
+reported as a divergence, since the generated bindings would behave differently per
+network. The command exits with an error if any divergence is found.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := loadReport(args[0])
+		if err != nil {
+			return err
+		}
+
+		result := parity.Check(*report, analyzer.FetchContractCode)
+
+		switch parityFormat {
+		case "json":
+			jsonData, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+		case "text":
+			var contracts []string
+			for contract := range result.Skipped {
+				contracts = append(contracts, contract)
+			}
+			sort.Strings(contracts)
+			for _, contract := range contracts {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipped %s: %s\n", contract, result.Skipped[contract])
+			}
+			for _, d := range result.Divergences {
+				fmt.Fprintln(cmd.OutOrStdout(), d.String())
+			}
+		default:
+			return fmt.Errorf("unsupported format: %s", parityFormat)
+		}
+
+		if len(result.Divergences) > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("parity found %d divergences", len(result.Divergences))
+		}
+		return nil
+	},
+}
+
+func init() {
+	parityCmd.Flags().StringVar(&parityFormat, "format", "text", "Output format (text/json)")
+	rootCmd.AddCommand(parityCmd)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/outblock/cadence-codegen/internal/config"
+	"github.com/outblock/cadence-codegen/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	registryURL        string
+	registryToken      string
+	registryConfigPath string
+	registryVersion    string
+	publishName        string
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish [input]",
+	Short: "Publish a report to an interaction registry",
+	Long: `Publish a report to an interaction registry, so other teams can pull it and
+generate their own SDKs from it.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+The report is uploaded with an HTTP PUT to <registry>/<name>/<version>. The registry URL
+is read from --registry or the registry field of the config file, and the auth token from
+--token or the CADENCE_CODEGEN_REGISTRY_TOKEN environment variable.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := registryClient()
+		if err != nil {
+			return err
+		}
+
+		report, err := loadReport(args[0])
+		if err != nil {
+			return err
+		}
+
+		endpoint, err := client.Publish(publishName, registryVersion, *report)
+		if err != nil {
+			return fmt.Errorf("failed to publish report: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Published %d scripts and %d transactions to %s\n", len(report.Scripts), len(report.Transactions), endpoint)
+		return nil
+	},
+}
+
+// addRegistryFlags registers the flags configuring the registry of cmd
+func addRegistryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&registryURL, "registry", "", "Base URL of the interaction registry (defaults to the registry of the config file)")
+	cmd.Flags().StringVar(&registryToken, "token", "", "Auth token of the registry (defaults to $"+registry.TokenEnv+")")
+	cmd.Flags().StringVar(&registryConfigPath, "config", config.DefaultFile, "Config file with the registry URL")
+}
+
+// registryClient creates the registry client configured by the flags, the
+// config file and the environment
+func registryClient() (*registry.Client, error) {
+	url := registryURL
+	if url == "" {
+		cfg, err := config.Load(registryConfigPath, false)
+		if err != nil {
+			return nil, err
+		}
+		url = cfg.Registry
+	}
+	token := registryToken
+	if token == "" {
+		token = os.Getenv(registry.TokenEnv)
+	}
+	return registry.New(url, token)
+}
+
+func init() {
+	publishCmd.Flags().StringVar(&publishName, "name", "", "Name of the interaction library (required)")
+	publishCmd.Flags().StringVar(&registryVersion, "version", registry.LatestVersion, "Version to publish the report as")
+	publishCmd.MarkFlagRequired("name")
+	addRegistryFlags(publishCmd)
+	addWithStandardFlag(publishCmd)
+	addDeploymentsFlag(publishCmd)
+	rootCmd.AddCommand(publishCmd)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var pullVersion string
+
+var pullCmd = &cobra.Command{
+	Use:   "pull [name] [output]",
+	Short: "Pull a published report from an interaction registry",
+	Long: `Pull a report published with the publish command from an interaction registry.
+The report is downloaded from <registry>/<name>/<version> (the latest version by default)
+and written as JSON, ready to be passed to the swift, typescript, golang or kotlin commands.
+The registry URL is read from --registry or the registry field of the config file, and the
+auth token from --token or the CADENCE_CODEGEN_REGISTRY_TOKEN environment variable.
+The output will be a JSON file (defaults to <name>.json if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		outputPath := name + ".json"
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		client, err := registryClient()
+		if err != nil {
+			return err
+		}
+		report, err := client.Pull(name, pullVersion)
+		if err != nil {
+			return fmt.Errorf("failed to pull report: %w", err)
+		}
+
+		jsonData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+
+		// Create output directory if it doesn't exist
+		err = os.MkdirAll(filepath.Dir(outputPath), 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := os.WriteFile(outputPath, jsonData, 0644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Pulled %d scripts and %d transactions to %s\n", len(report.Scripts), len(report.Transactions), outputPath)
+		return nil
+	},
+}
+
+func init() {
+	pullCmd.Flags().StringVar(&pullVersion, "version", "", "Version to pull (defaults to the latest)")
+	addRegistryFlags(pullCmd)
+	rootCmd.AddCommand(pullCmd)
+}
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/config"
+	"github.com/outblock/cadence-codegen/internal/diff"
+	"github.com/outblock/cadence-codegen/internal/lint"
+	"github.com/outblock/cadence-codegen/internal/names"
+	"github.com/outblock/cadence-codegen/internal/source"
+	"github.com/outblock/cadence-codegen/internal/standard"
+	"github.com/spf13/cobra"
+)
+
+// withStandard is the comma separated list of standard presets merged into
+// the report, e.g. ft,nft
+var withStandard string
+
+// deployments enables the transactions deploying and updating the contracts
+// of the input
+var deployments bool
+
+// onlyPatterns and skipTags filter the scripts and transactions generated,
+// e.g. staking/* and nft
+var onlyPatterns, skipTags []string
+
+// namesPath is the names file pinning generated names, empty if disabled
+var namesPath string
+
+// baselinePath is the previous report generation is checked against for
+// breaking changes, empty if disabled
+var baselinePath string
+
+// allowBreaking lets generation proceed despite breaking changes
+var allowBreaking bool
+
+// namedGenerator is a generator whose function names can be pinned
+type namedGenerator interface {
+	SetNames(names map[string]string)
+	FunctionNames() map[string]string
+}
+
+// addWithStandardFlag registers the --with-standard flag of cmd
+func addWithStandardFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&withStandard, "with-standard", "", "Comma separated standard presets to merge into the report (ft, nft)")
+}
+
+// addDeploymentsFlag registers the --deployments flag of cmd
+func addDeploymentsFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&deployments, "deployments", false, "Generate transactions deploying and updating the contracts of the input")
+}
+
+// addFilterFlags registers the --only and --skip-tags flags of cmd
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&onlyPatterns, "only", nil, "Generate only the scripts and transactions whose path matches one of these globs (e.g. staking/*)")
+	cmd.Flags().StringSliceVar(&skipTags, "skip-tags", nil, "Skip the scripts and transactions with one of these tags (e.g. nft)")
+}
+
+// addNamesFlag registers the --names flag of cmd
+func addNamesFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&namesPath, "names", "", "Names file (e.g. names.json) whose recorded function names are kept and which is updated with new ones")
+}
+
+// addBreakingFlags registers the --baseline and --allow-breaking flags of cmd
+func addBreakingFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&baselinePath, "baseline", "", "Previous report (or Cadence files) to check the generated functions against for breaking changes")
+	cmd.Flags().BoolVar(&allowBreaking, "allow-breaking", false, "Generate even if a function disappears or changes signature")
+}
+
+// guardBreaking fails if a function generated before disappears or changes
+// signature, unless --allow-breaking is set. The previous functions are those
+// of the --baseline report, or else the names recorded for target in the names
+// file. It is called before the report is filtered, since filtered functions
+// are left out on purpose.
+func guardBreaking(target string, report *analyzer.Report) error {
+	var breaking []diff.Change
+	switch {
+	case baselinePath != "":
+		baseline, err := loadReport(baselinePath)
+		if err != nil {
+			return fmt.Errorf("failed to load baseline: %w", err)
+		}
+		breaking = diff.Compare(*baseline, *report).BreakingChanges()
+	case namesPath != "" && target != "":
+		recorded, err := names.Load(namesPath)
+		if err != nil {
+			return err
+		}
+		breaking = diff.CompareNames(recorded[target], *report).BreakingChanges()
+	}
+	if len(breaking) == 0 {
+		return nil
+	}
+
+	for _, change := range breaking {
+		if allowBreaking {
+			fmt.Fprintf(os.Stderr, "Warning: breaking change: %s %s: %s\n", change.Kind, change.Name, change.Message)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", change.Kind, change.Name, change.Message)
+		}
+	}
+	if !allowBreaking {
+		return fmt.Errorf("found %d breaking change(s), use --allow-breaking to generate anyway", len(breaking))
+	}
+	return nil
+}
+
+// loadNames makes gen use the names of target recorded in the names file,
+// failing if a recorded name is also recorded or derived for another file
+func loadNames(target string, gen namedGenerator) error {
+	if namesPath == "" {
+		return nil
+	}
+	recorded, err := names.Load(namesPath)
+	if err != nil {
+		return err
+	}
+	gen.SetNames(recorded[target])
+	return names.CheckUnique(target, gen.FunctionNames())
+}
+
+// saveNames records the names generated by gen for target in the names file
+func saveNames(target string, gen namedGenerator) error {
+	if namesPath == "" {
+		return nil
+	}
+	recorded, err := names.Load(namesPath)
+	if err != nil {
+		return err
+	}
+	recorded[target] = gen.FunctionNames()
+	return recorded.Save(namesPath)
+}
+
+// filterReport applies the --only and --skip-tags filters to report
+func filterReport(report *analyzer.Report) error {
+	signed := report.Manifest != nil
+	if err := report.Filter(onlyPatterns, skipTags); err != nil {
+		return fmt.Errorf("failed to filter report: %w", err)
+	}
+	if signed && report.Manifest == nil {
+		fmt.Fprintln(os.Stderr, "Warning: the signed manifest is dropped since it does not cover a filtered report")
+	}
+	return nil
+}
+
+// mergeStandard merges the standard presets listed with --with-standard into report
+func mergeStandard(report *analyzer.Report) error {
+	if withStandard == "" {
+		return nil
+	}
+	presets, err := standard.Parse(withStandard)
+	if err != nil {
+		return err
+	}
+	if err := standard.Merge(report, presets); err != nil {
+		return fmt.Errorf("failed to merge standard presets: %w", err)
+	}
+	return nil
+}
+
+// warnReport prints a warning for every unused transaction parameter of
+// report, every near-identical script and transaction and every one exceeding
+// the code size and parameter count budget of the config file in the working
+// directory, or the default budget
+func warnReport(report *analyzer.Report) error {
+	cfg, err := config.Load(config.DefaultFile, false)
+	if err != nil {
+		return err
+	}
+	l := lint.New()
+	if cfg.Budget != nil {
+		l.SetMaxCodeSize(cfg.Budget.MaxCodeSize)
+		l.SetMaxParameters(cfg.Budget.MaxParameters)
+	}
+	diagnostics := append(lint.UnusedParameters(*report), lint.Duplicates(*report)...)
+	for _, d := range append(diagnostics, l.CheckBudget(*report)...) {
+		fmt.Fprintln(os.Stderr, d.String())
+	}
+	return nil
+}
+
+// configureAnalyzer makes a read the contracts vendored in the lockfile of the
+// working directory, if any, instead of fetching them from chain, and derive
+// tags with the tag strategy and report the access nodes of the config file
+func configureAnalyzer(a *analyzer.Analyzer) error {
+	lock, err := analyzer.LoadLockfile(analyzer.LockfileName)
+	if err != nil {
+		return err
+	}
+	a.SetLockfile(lock)
+	cfg, err := config.Load(config.DefaultFile, false)
+	if err != nil {
+		return err
+	}
+	a.SetTagStrategy(cfg.TagStrategy)
+	a.SetAccessNodes(cfg.AccessNodes)
+	return nil
+}
+
+// fetchInput extracts an archive or clones a git repository input into a
+// temporary directory and sets it as base directory of the analyzer, so that
+// tags are derived as for a local checkout. Other inputs are returned as is.
+func fetchInput(a *analyzer.Analyzer, inputPath string) (string, func(), error) {
+	if !source.IsRemote(inputPath) {
+		return inputPath, func() {}, nil
+	}
+	dir, cleanup, err := source.Fetch(inputPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch input: %w", err)
+	}
+	a.SetBaseDir(dir)
+	return dir, cleanup, nil
+}
+
+// isReportJSON reports whether inputPath is a JSON report previously
+// generated by the analyze command rather than a FLIX template, which is
+// analyzed like a .cdc file
+func isReportJSON(inputPath string) bool {
+	if !strings.HasSuffix(inputPath, ".json") {
+		return false
+	}
+	data, err := os.ReadFile(inputPath)
+	return err != nil || !analyzer.IsFLIX(data)
+}
+
+// loadReport loads a report from a JSON file previously generated by the
+// analyze command, or analyzes the given .cdc file or directory
+func loadReport(inputPath string) (*analyzer.Report, error) {
+	// Check if input is a JSON report
+	if isReportJSON(inputPath) {
+		// Read JSON file
+		jsonData, err := os.ReadFile(inputPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JSON file: %w", err)
+		}
+
+		// Parse JSON
+		report := &analyzer.Report{}
+		if err := json.Unmarshal(jsonData, report); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON file: %w", err)
+		}
+		return report, mergeStandard(report)
+	}
+
+	// Create analyzer for Cadence files
+	a := analyzer.New()
+	a.SetIncludeBase64(true)
+	a.SetDeployments(deployments)
+	if err := configureAnalyzer(a); err != nil {
+		return nil, err
+	}
+
+	inputPath, cleanup, err := fetchInput(a, inputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	// Analyze directory or file
+	if err := a.AnalyzeDirectory(inputPath); err != nil {
+		return nil, fmt.Errorf("failed to analyze input: %w", err)
+	}
+
+	// Resolve nested types if addresses are available
+	if a.GetReport().Addresses != nil {
+		// Try to resolve nested types for both mainnet and testnet
+		if err := a.ResolveNestedTypes("mainnet"); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to resolve nested types for mainnet: %v\n", err)
+		}
+		if err := a.ResolveNestedTypes("testnet"); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to resolve nested types for testnet: %v\n", err)
+		}
+	}
+
+	report := a.GetReport()
+	return report, mergeStandard(report)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restServicePath string
+	restFramework   string
+	restValidators  string
+)
+
+var restCmd = &cobra.Command{
+	Use:   "rest [input] [output]",
+	Short: "Generate a REST API server from Cadence files or JSON",
+	Long: `Generate an Express or Fastify REST API server from Cadence files or JSON.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+Each script is exposed as a GET /scripts/<name> endpoint taking its arguments in
+the query string and each transaction as a POST /transactions/<name> endpoint taking
+a JSON body. Requests are validated with schemas of the --validators library (zod,
+valibot or io-ts) derived from the parameters before the CadenceService generated by
+the typescript command at --service executes them.
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are generated.
+With --names names.json, the TypeScript function names recorded in the names file are called.
+With --baseline old.json, generation fails if a function of the previous report disappears or changes signature,
+or else with --names if a function recorded in the names file disappears, unless --allow-breaking is set.
+The output will be a TypeScript file (defaults to cadence.server.ts if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputPath := "cadence.server.ts"
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if err := guardBreaking("typescript", report); err != nil {
+			return err
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := warnReport(report); err != nil {
+			return err
+		}
+
+		return generateREST(report, outputPath)
+	},
+}
+
+// generateREST writes the REST server of report to outputPath
+func generateREST(report *analyzer.Report, outputPath string) error {
+	// Create output directory if it doesn't exist
+	err := os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	servicePath := restServicePath
+	if servicePath == "" {
+		servicePath = filepath.Join(filepath.Dir(outputPath), "cadence.generated.ts")
+	}
+	importPath, err := relativeImportPath(filepath.Dir(outputPath), servicePath)
+	if err != nil {
+		return err
+	}
+
+	// Generate REST server
+	gen := typescript.New(*report)
+	if err := loadNames("typescript", gen); err != nil {
+		return err
+	}
+	gen.SetValidators(restValidators)
+	code, err := gen.GenerateREST(restFramework, importPath)
+	if err != nil {
+		return fmt.Errorf("failed to generate REST server: %w", err)
+	}
+
+	// Write the generated code to file
+	err = os.WriteFile(outputPath, []byte(code), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write REST server: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	restCmd.Flags().StringVar(&restServicePath, "service", "", "Path of the generated TypeScript service (defaults to cadence.generated.ts next to the output)")
+	restCmd.Flags().StringVar(&restFramework, "framework", typescript.RESTFrameworkExpress, "Server framework (express/fastify)")
+	restCmd.Flags().StringVar(&restValidators, "validators", typescript.ValidatorsZod, "Validation library of the request schemas (zod/valibot/io-ts)")
+	addFilterFlags(restCmd)
+	addBreakingFlags(restCmd)
+	addNamesFlag(restCmd)
+	rootCmd.AddCommand(restCmd)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/outblock/cadence-codegen/internal/analyzer"
 	"github.com/spf13/cobra"
 )
 
@@ -30,6 +31,7 @@ func Execute() {
 }
 
 func init() {
+	analyzer.Version = version
 	// No need to register commands here as they register themselves in their own files
 	rootCmd.SetVersionTemplate(`Version: {{.Version}}
 Commit: ` + commit + `
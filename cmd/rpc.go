@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/outblock/cadence-codegen/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+var rpcCmd = &cobra.Command{
+	Use:   "rpc",
+	Short: "Run a JSON-RPC server for editor integrations",
+	Long: `Run a long-running JSON-RPC 2.0 server for editor integrations.
+Requests and responses are newline delimited JSON objects on stdin and stdout.
+Available methods:
+  initialize      {root}                    set the workspace root used for tags
+  analyzeFile     {path, content?}          analyze a file or unsaved buffer
+  getDiagnostics  {path, content?}          lint and Cadence 1.0 migration diagnostics
+  getSnippet      {path, content?, target}  generated typescript/swift/golang code for a file
+  shutdown                                  stop the server`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rpc.New().Serve(os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rpcCmd)
+}
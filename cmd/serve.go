@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/outblock/cadence-codegen/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr       string
+	serveSwift      string
+	serveTypeScript string
+	serveGolang     string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve [dir]",
+	Short: "Serve a web UI for browsing the analyzed interactions",
+	Long: `Serve a web UI for browsing the analyzed interactions of a directory of Cadence files.
+The UI lists scripts and transactions by tag with search, shows their parameters,
+return types and Cadence code, and provides usage snippets to copy for each target.
+The Regenerate button analyzes the directory again and rewrites the outputs
+configured with --swift, --typescript and --golang.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s := server.New(args[0])
+		s.SetOutput("swift", serveSwift)
+		s.SetOutput("typescript", serveTypeScript)
+		s.SetOutput("golang", serveGolang)
+		return s.ListenAndServe(serveAddr)
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8080", "Address to listen on")
+	serveCmd.Flags().StringVar(&serveSwift, "swift", "", "Swift output regenerated on request (disabled if empty)")
+	serveCmd.Flags().StringVar(&serveTypeScript, "typescript", "", "TypeScript output regenerated on request (disabled if empty)")
+	serveCmd.Flags().StringVar(&serveGolang, "golang", "", "Go output regenerated on request (disabled if empty)")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+	"github.com/spf13/cobra"
+)
+
+var solidServicePath string
+
+var solidCmd = &cobra.Command{
+	Use:   "solid [input] [output]",
+	Short: "Generate SolidJS primitives from Cadence files or JSON",
+	Long: `Generate SolidJS primitives from Cadence files or JSON.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+Each script becomes a createResource based primitive (e.g. createGetBalanceResource)
+refetching when its arguments change, and each transaction an action wrapper
+(e.g. createTransferAction) with txId, pending and error signals. The primitives
+call the CadenceService of CadenceContext, or one generated by the typescript
+command at --service.
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are generated.
+With --names names.json, the TypeScript function names recorded in the names file are called.
+With --baseline old.json, generation fails if a function of the previous report disappears or changes signature,
+or else with --names if a function recorded in the names file disappears, unless --allow-breaking is set.
+The output will be a TypeScript file (defaults to cadence.solid.ts if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputPath := "cadence.solid.ts"
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if err := guardBreaking("typescript", report); err != nil {
+			return err
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := warnReport(report); err != nil {
+			return err
+		}
+
+		return generateSolid(report, outputPath)
+	},
+}
+
+// generateSolid writes the SolidJS primitives of report to outputPath
+func generateSolid(report *analyzer.Report, outputPath string) error {
+	// Create output directory if it doesn't exist
+	err := os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	servicePath := solidServicePath
+	if servicePath == "" {
+		servicePath = filepath.Join(filepath.Dir(outputPath), "cadence.generated.ts")
+	}
+	importPath, err := relativeImportPath(filepath.Dir(outputPath), servicePath)
+	if err != nil {
+		return err
+	}
+
+	// Generate SolidJS primitives
+	gen := typescript.New(*report)
+	if err := loadNames("typescript", gen); err != nil {
+		return err
+	}
+	code, err := gen.GenerateSolid(importPath)
+	if err != nil {
+		return fmt.Errorf("failed to generate SolidJS primitives: %w", err)
+	}
+
+	// Write the generated code to file
+	err = os.WriteFile(outputPath, []byte(code), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write SolidJS primitives: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	solidCmd.Flags().StringVar(&solidServicePath, "service", "", "Path of the generated TypeScript service (defaults to cadence.generated.ts next to the output)")
+	addFilterFlags(solidCmd)
+	addBreakingFlags(solidCmd)
+	addNamesFlag(solidCmd)
+	rootCmd.AddCommand(solidCmd)
+}
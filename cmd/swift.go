@@ -5,13 +5,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/outblock/cadence-codegen/internal/analyzer"
 	"github.com/outblock/cadence-codegen/internal/generator/swift"
 	"github.com/spf13/cobra"
 )
 
+var (
+	swiftWalletKit bool
+	swiftTelemetry bool
+	swiftPackage   bool
+	swiftFixtures  bool
+	swiftObjC      bool
+	swiftServer    bool
+	swiftRetry     bool
+	swiftLogging   bool
+	swiftIntegrity bool
+)
+
 var swiftCmd = &cobra.Command{
 	Use:   "swift [input] [output]",
 	Short: "Generate Swift code from Cadence files or JSON",
@@ -20,19 +31,43 @@ The input can be either:
 1. A single .cdc file
 2. A directory containing .cdc files
 3. A JSON file previously generated by the analyze command
-The output will be a Swift file (defaults to CadenceGen.swift if not specified).`,
+The output will be a Swift file (defaults to CadenceGen.swift if not specified).
+With --wallet-kit, transactions get Flow Wallet Kit signing adapters listing their required signers.
+With --telemetry, instrumentedQuery and instrumentedSend report the name, duration and outcome of
+every interaction to CadenceTelemetry.hook.
+With --preview-fixtures, PreviewFixtures holds a sample instance of every generated struct for
+SwiftUI previews and snapshot tests.
+With --objc, @objc wrapper classes such as CadenceGenObjC expose the enums to Objective-C.
+With --server, the output builds on macOS and Linux for server-side Swift such as Vapor.
+With --retry, failed calls are retried according to CadenceRetryPolicy.shared.
+With --logging, every call is reported to the CadenceLogger set as CadenceLogging.logger.
+With --integrity, each case embeds the SHA-256 of its Cadence code, checked before the code is executed,
+and calls throw CadenceGenError.integrityMismatch if the embedded code was corrupted. The hashes ship
+next to the code, so this does not guard against deliberate edits of the binary.
+With --with-standard ft,nft, the built-in FT and NFT standard interactions are merged into the report.
+With --deployments, contract files get deploy_<contract> and update_<contract> transactions embedding their code.
+With --package, the output is a Swift package directory (defaults to CadenceGen) with one SwiftPM
+target per tag holding only the structs it needs, plus a CadenceGenCore target for shared code.
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are generated.
+With --names names.json, the enum case names recorded for swift in the names file are kept even if the naming
+algorithm changes, and names of new files are recorded.
+With --baseline old.json, generation fails if a function of the previous report disappears or changes signature,
+or else with --names if a function recorded in the names file disappears, unless --allow-breaking is set.`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		inputPath := args[0]
 		outputPath := "CadenceGen.swift"
+		if swiftPackage {
+			outputPath = "CadenceGen"
+		}
 		if len(args) > 1 {
 			outputPath = args[1]
 		}
 
 		var report *analyzer.Report
 
-		// Check if input is JSON
-		if strings.HasSuffix(inputPath, ".json") {
+		// Check if input is a JSON report
+		if isReportJSON(inputPath) {
 			// Read JSON file
 			jsonData, err := os.ReadFile(inputPath)
 			if err != nil {
@@ -47,39 +82,118 @@ The output will be a Swift file (defaults to CadenceGen.swift if not specified).
 		} else {
 			// Create analyzer for Cadence files
 			a := analyzer.New()
+			a.SetDeployments(deployments)
+			if err := configureAnalyzer(a); err != nil {
+				return err
+			}
+
+			inputPath, cleanup, err := fetchInput(a, inputPath)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
 
 			// Analyze directory or file
-			err := a.AnalyzeDirectory(inputPath)
+			err = a.AnalyzeDirectory(inputPath)
 			if err != nil {
 				return fmt.Errorf("failed to analyze input: %w", err)
 			}
 
 			report = a.GetReport()
 		}
-
-		// Create output directory if it doesn't exist
-		err := os.MkdirAll(filepath.Dir(outputPath), 0755)
-		if err != nil {
-			return fmt.Errorf("failed to create output directory: %w", err)
+		if err := mergeStandard(report); err != nil {
+			return err
 		}
-
-		// Generate Swift code
-		gen := swift.New(*report)
-		code, err := gen.Generate()
-		if err != nil {
-			return fmt.Errorf("failed to generate Swift code: %w", err)
+		if err := guardBreaking("swift", report); err != nil {
+			return err
 		}
-
-		// Write the generated code to file
-		err = os.WriteFile(outputPath, []byte(code), 0644)
-		if err != nil {
-			return fmt.Errorf("failed to write Swift code: %w", err)
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := warnReport(report); err != nil {
+			return err
 		}
 
-		return nil
+		return generateSwift(report, outputPath)
 	},
 }
 
+// generateSwift writes the Swift code of report to outputPath
+func generateSwift(report *analyzer.Report, outputPath string) error {
+	// Create output directory if it doesn't exist
+	err := os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Generate Swift code
+	gen := swift.New(*report)
+	gen.SetWalletKit(swiftWalletKit)
+	gen.SetTelemetry(swiftTelemetry)
+	gen.SetPreviewFixtures(swiftFixtures)
+	gen.SetObjC(swiftObjC)
+	gen.SetServer(swiftServer)
+	gen.SetRetry(swiftRetry)
+	gen.SetLogging(swiftLogging)
+	gen.SetIntegrity(swiftIntegrity)
+	if err := loadNames("swift", gen); err != nil {
+		return err
+	}
+
+	// Generate a Swift package with per-tag targets if requested
+	if swiftPackage {
+		if err := writeSwiftPackage(gen, outputPath); err != nil {
+			return err
+		}
+		return saveNames("swift", gen)
+	}
+
+	code, err := gen.Generate()
+	if err != nil {
+		return fmt.Errorf("failed to generate Swift code: %w", err)
+	}
+
+	// Write the generated code to file
+	err = os.WriteFile(outputPath, []byte(code), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write Swift code: %w", err)
+	}
+
+	return saveNames("swift", gen)
+}
+
+// writeSwiftPackage writes the Swift package with per-tag targets into dir
+func writeSwiftPackage(gen *swift.Generator, dir string) error {
+	files, err := gen.GeneratePackage(filepath.Base(dir))
+	if err != nil {
+		return fmt.Errorf("failed to generate Swift package: %w", err)
+	}
+	for name, code := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create package directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+			return fmt.Errorf("failed to write Swift package: %w", err)
+		}
+	}
+	return nil
+}
+
 func init() {
+	addWithStandardFlag(swiftCmd)
+	addDeploymentsFlag(swiftCmd)
+	addFilterFlags(swiftCmd)
+	addBreakingFlags(swiftCmd)
+	addNamesFlag(swiftCmd)
 	rootCmd.AddCommand(swiftCmd)
+	swiftCmd.Flags().BoolVar(&swiftTelemetry, "telemetry", false, "Generate telemetry hooks reporting the duration and outcome of every interaction")
+	swiftCmd.Flags().BoolVar(&swiftObjC, "objc", false, "Generate @objc wrapper classes for Objective-C codebases")
+	swiftCmd.Flags().BoolVar(&swiftRetry, "retry", false, "Generate a configurable retry policy applied to queries and transactions")
+	swiftCmd.Flags().BoolVar(&swiftLogging, "logging", false, "Generate logger hooks reporting the requests, responses and errors of every call")
+	swiftCmd.Flags().BoolVar(&swiftIntegrity, "integrity", false, "Check the SHA-256 of the embedded Cadence code of a case before executing it")
+	swiftCmd.Flags().BoolVar(&swiftServer, "server", false, "Generate the server-side flavor for macOS and Linux, e.g. Vapor services")
+	swiftCmd.Flags().BoolVar(&swiftPackage, "package", false, "Generate a Swift package with one target per tag instead of a single file")
+	swiftCmd.Flags().BoolVar(&swiftFixtures, "preview-fixtures", false, "Generate PreviewFixtures with sample instances of every struct for SwiftUI previews")
+	swiftCmd.Flags().BoolVar(&swiftWalletKit, "wallet-kit", false, "Generate Flow Wallet Kit signing adapters with typed signer requirements")
 }
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trpcServicePath string
+	trpcValidators  string
+)
+
+var trpcCmd = &cobra.Command{
+	Use:   "trpc [input] [output]",
+	Short: "Generate a tRPC router from Cadence files or JSON",
+	Long: `Generate a tRPC router from Cadence files or JSON.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+Each script becomes a query procedure and each transaction a mutation procedure,
+with input schemas derived from the parameters using the --validators library
+(zod, valibot or io-ts). The router calls the
+CadenceService generated by the typescript command at --service.
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are generated.
+With --names names.json, the TypeScript function names recorded in the names file are called.
+With --baseline old.json, generation fails if a function of the previous report disappears or changes signature,
+or else with --names if a function recorded in the names file disappears, unless --allow-breaking is set.
+The output will be a TypeScript file (defaults to cadence.router.ts if not specified).`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath := args[0]
+		outputPath := "cadence.router.ts"
+		if len(args) > 1 {
+			outputPath = args[1]
+		}
+
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if err := guardBreaking("typescript", report); err != nil {
+			return err
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := warnReport(report); err != nil {
+			return err
+		}
+
+		return generateTRPC(report, outputPath)
+	},
+}
+
+// generateTRPC writes the tRPC router of report to outputPath
+func generateTRPC(report *analyzer.Report, outputPath string) error {
+	// Create output directory if it doesn't exist
+	err := os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	servicePath := trpcServicePath
+	if servicePath == "" {
+		servicePath = filepath.Join(filepath.Dir(outputPath), "cadence.generated.ts")
+	}
+	importPath, err := relativeImportPath(filepath.Dir(outputPath), servicePath)
+	if err != nil {
+		return err
+	}
+
+	// Generate tRPC router
+	gen := typescript.New(*report)
+	if err := loadNames("typescript", gen); err != nil {
+		return err
+	}
+	gen.SetValidators(trpcValidators)
+	code, err := gen.GenerateTRPC(importPath)
+	if err != nil {
+		return fmt.Errorf("failed to generate tRPC router: %w", err)
+	}
+
+	// Write the generated code to file
+	err = os.WriteFile(outputPath, []byte(code), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write tRPC router: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	trpcCmd.Flags().StringVar(&trpcServicePath, "service", "", "Path of the generated TypeScript service (defaults to cadence.generated.ts next to the output)")
+	trpcCmd.Flags().StringVar(&trpcValidators, "validators", typescript.ValidatorsZod, "Validation library of the input schemas (zod/valibot/io-ts)")
+	addFilterFlags(trpcCmd)
+	addBreakingFlags(trpcCmd)
+	addNamesFlag(trpcCmd)
+	rootCmd.AddCommand(trpcCmd)
+}
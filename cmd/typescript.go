@@ -1,17 +1,41 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/config"
+	"github.com/outblock/cadence-codegen/internal/flowcli"
 	"github.com/outblock/cadence-codegen/internal/generator/typescript"
 	"github.com/spf13/cobra"
 )
 
+var (
+	tsTestsDir      string
+	tsIntegration   string
+	tsTestFramework string
+	tsMockDir       string
+	tsValidate      bool
+	tsTelemetry     bool
+	tsAuth          bool
+	tsConfigPath    string
+	tsDeclarations  bool
+	tsEstimates     bool
+	tsIdempotency   bool
+	tsOffline       bool
+	tsCache         bool
+	tsRetry         bool
+	tsLogging       bool
+	tsSlim          bool
+	tsBarrel        bool
+	tsWorker        bool
+	tsIntegrity     bool
+	tsNode          bool
+)
+
 var typescriptCmd = &cobra.Command{
 	Use:   "typescript [input] [output]",
 	Short: "Generate TypeScript code from Cadence files or JSON",
@@ -20,78 +44,374 @@ The input can be either:
 1. A single .cdc file
 2. A directory containing .cdc files
 3. A JSON file previously generated by the analyze command
-The output will be a TypeScript file (defaults to cadence.generated.ts if not specified).`,
+The output will be a TypeScript file (defaults to cadence.generated.ts if not specified).
+With --tests-dir, a Jest or Vitest test scaffold is also generated per tag with a mocked fcl.
+With --integration-tests, the tests directory also gets integration.test.ts, which starts the Flow
+emulator in the given Flow CLI project, deploys its contracts and executes every script.
+With --mock-dir, a MockCadenceService returning canned responses from a fixtures folder is generated.
+With --validate, arguments are validated against their Cadence types before they are encoded by fcl.
+With --telemetry, hooks registered with useTelemetry receive the name, duration and outcome of every
+query and mutation.
+With --estimates, an estimate<Name> helper per transaction dry-runs it with the estimator registered
+with useEstimator and returns its computation usage, fee and whether it exceeds the compute limit.
+No estimator is generated: the helpers throw until the app registers one, e.g. backed by an emulator fork.
+With --idempotency, transactions accept an idempotencyKey option and useIdempotency rejects keys
+that were already submitted with a DuplicateTransactionError carrying the first transaction ID.
+With --offline-signing, a build<Name>Payload function per transaction exports it unsigned as a JSON
+voucher with its RLP encoded payload message for hardware wallets and multi-party signing, with
+the import aliases of its code resolved.
+With --cache, script results are cached by function name, network and arguments for the time to
+live configured per function with useCache.
+With --retry, failed fcl calls are retried according to the policy registered with useRetryPolicy.
+With --logging, loggers registered with useLogger receive the requests, responses and errors of fcl calls.
+With --with-standard ft,nft, the built-in FT and NFT standard interactions are merged into the report.
+With --deployments, contract files get deploy_<contract> and update_<contract> transactions embedding their code.
+With --auth, a cadence.auth.ts module configuring fcl discovery and WalletConnect is generated next
+to the output, using the network and app metadata of the config file.
+With --slim, the bundle size is minimized for browser dapps: Cadence code shared between functions
+is embedded once and base64 encodings are computed with btoa on access instead of embedded.
+With --integrity, each function embeds the SHA-256 of its Cadence code in a frozen constant apart from
+the exported cadence map and checks it before the code is executed, throwing a CadenceIntegrityError
+if the code was corrupted or changed at runtime. The hashes ship in the same bundle, so this does not
+guard against deliberate edits of the bundle.
+With --barrel, an index.ts barrel is generated next to the output, re-exporting the service and
+adding a CadenceClient with the functions of each tag grouped into a sub-service, e.g. client.staking.
+With --worker, a cadence.worker.ts Web Worker and a cadence.proxy.ts main-thread proxy are generated
+next to the output, running scripts and the decoding of their responses off the UI thread.
+With --node, the output targets Node.js backends: transactions are proposed, paid for and authorized
+by the ServerSigner registered with useSigner, e.g. a privateKeySigner or a KMS, instead of fcl.currentUser.
+With --declarations, only type declarations (defaults to cadence.generated.d.ts) are generated: the
+interfaces, parameter and response types and function signatures, for custom execution layers.
+With --only staking/* and --skip-tags nft, only the matching scripts and transactions and the structs they use are generated.
+With --names names.json, the function names recorded for typescript in the names file are kept even if the naming
+algorithm changes, and names of new files are recorded.
+With --baseline old.json, generation fails if a function of the previous report disappears or changes signature,
+or else with --names if a function recorded in the names file disappears, unless --allow-breaking is set.`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		inputPath := args[0]
 		outputPath := "cadence.generated.ts"
+		if tsDeclarations {
+			outputPath = "cadence.generated.d.ts"
+		}
 		if len(args) > 1 {
 			outputPath = args[1]
 		}
 
-		var report *analyzer.Report
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if err := guardBreaking("typescript", report); err != nil {
+			return err
+		}
+		if err := filterReport(report); err != nil {
+			return err
+		}
+		if err := warnReport(report); err != nil {
+			return err
+		}
 
-		// Check if input is JSON
-		if strings.HasSuffix(inputPath, ".json") {
-			// Read JSON file
-			jsonData, err := os.ReadFile(inputPath)
-			if err != nil {
-				return fmt.Errorf("failed to read JSON file: %w", err)
-			}
+		return generateTypeScript(report, outputPath, cmd.Flags().Changed("config"))
+	},
+}
 
-			// Parse JSON
-			report = &analyzer.Report{}
-			if err := json.Unmarshal(jsonData, report); err != nil {
-				return fmt.Errorf("failed to parse JSON file: %w", err)
-			}
-		} else {
-			// Create analyzer for Cadence files
-			a := analyzer.New()
-			a.SetIncludeBase64(true) // Always include base64 for TypeScript generation
-
-			// Analyze directory or file
-			err := a.AnalyzeDirectory(inputPath)
-			if err != nil {
-				return fmt.Errorf("failed to analyze input: %w", err)
-			}
+// generateTypeScript writes the TypeScript code of report to outputPath, with the
+// companion files enabled by the flags of the typescript command. explicitConfig
+// tells whether the config file was set with --config.
+func generateTypeScript(report *analyzer.Report, outputPath string, explicitConfig bool) error {
+	// Create output directory if it doesn't exist
+	err := os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
 
-			// Resolve nested types if addresses are available
-			if a.GetReport().Addresses != nil {
-				// Try to resolve nested types for both mainnet and testnet
-				if err := a.ResolveNestedTypes("mainnet"); err != nil {
-					fmt.Printf("Warning: failed to resolve nested types for mainnet: %v\n", err)
-				}
-				if err := a.ResolveNestedTypes("testnet"); err != nil {
-					fmt.Printf("Warning: failed to resolve nested types for testnet: %v\n", err)
-				}
-			}
+	if tsIntegration != "" && tsTestsDir == "" {
+		return fmt.Errorf("--integration-tests requires --tests-dir")
+	}
 
-			report = a.GetReport()
-		}
+	if tsNode && (tsAuth || tsWorker) {
+		return fmt.Errorf("--node cannot be combined with --auth or --worker")
+	}
 
-		// Create output directory if it doesn't exist
-		err := os.MkdirAll(filepath.Dir(outputPath), 0755)
+	gen := typescript.New(*report)
+	if err := loadNames("typescript", gen); err != nil {
+		return err
+	}
+
+	// Generate type declarations only if requested
+	if tsDeclarations {
+		if tsTestsDir != "" || tsMockDir != "" || tsAuth || tsBarrel || tsWorker {
+			return fmt.Errorf("--declarations cannot be combined with --tests-dir, --mock-dir, --auth, --barrel or --worker")
+		}
+		code, err := gen.GenerateDeclarations()
 		if err != nil {
-			return fmt.Errorf("failed to create output directory: %w", err)
+			return fmt.Errorf("failed to generate TypeScript declarations: %w", err)
 		}
+		if err := os.WriteFile(outputPath, []byte(code), 0644); err != nil {
+			return fmt.Errorf("failed to write TypeScript declarations: %w", err)
+		}
+		return saveNames("typescript", gen)
+	}
 
-		// Generate TypeScript code
-		gen := typescript.New(*report)
-		code, err := gen.Generate()
-		if err != nil {
-			return fmt.Errorf("failed to generate TypeScript code: %w", err)
+	// Generate TypeScript code
+	gen.SetValidate(tsValidate)
+	gen.SetTelemetry(tsTelemetry)
+	gen.SetEstimates(tsEstimates)
+	gen.SetIdempotency(tsIdempotency)
+	gen.SetOfflineSigning(tsOffline)
+	gen.SetCache(tsCache)
+	gen.SetRetry(tsRetry)
+	gen.SetLogging(tsLogging)
+	gen.SetSlim(tsSlim)
+	gen.SetIntegrity(tsIntegrity)
+	gen.SetNode(tsNode)
+	code, err := gen.Generate()
+	if err != nil {
+		return fmt.Errorf("failed to generate TypeScript code: %w", err)
+	}
+
+	// Write the generated code to file
+	err = os.WriteFile(outputPath, []byte(code), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write TypeScript code: %w", err)
+	}
+
+	// Generate test scaffolds if requested
+	if tsTestsDir != "" {
+		if err := writeTypeScriptTests(gen, tsTestsDir, outputPath); err != nil {
+			return err
 		}
+	}
 
-		// Write the generated code to file
-		err = os.WriteFile(outputPath, []byte(code), 0644)
-		if err != nil {
-			return fmt.Errorf("failed to write TypeScript code: %w", err)
+	// Generate emulator integration tests if requested
+	if tsIntegration != "" {
+		if err := writeTypeScriptIntegrationTests(gen, tsTestsDir, outputPath, tsIntegration); err != nil {
+			return err
 		}
+	}
 
-		return nil
-	},
+	// Generate mock service if requested
+	if tsMockDir != "" {
+		if err := writeTypeScriptMock(gen, tsMockDir, outputPath); err != nil {
+			return err
+		}
+	}
+
+	// Generate barrel if requested
+	if tsBarrel {
+		if err := writeTypeScriptBarrel(gen, outputPath); err != nil {
+			return err
+		}
+	}
+
+	// Generate worker and proxy if requested
+	if tsWorker {
+		if err := writeTypeScriptWorker(gen, outputPath); err != nil {
+			return err
+		}
+	}
+
+	// Generate auth module if requested
+	if tsAuth {
+		if err := writeTypeScriptAuth(gen, outputPath, explicitConfig); err != nil {
+			return err
+		}
+	}
+
+	return saveNames("typescript", gen)
+}
+
+// relativeImportPath returns the TypeScript module path of the file at
+// outputPath when imported from a module in dir
+func relativeImportPath(dir string, outputPath string) (string, error) {
+	importPath, err := filepath.Rel(dir, strings.TrimSuffix(outputPath, ".ts"))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve import path: %w", err)
+	}
+	importPath = filepath.ToSlash(importPath)
+	if !strings.HasPrefix(importPath, ".") {
+		importPath = "./" + importPath
+	}
+	return importPath, nil
+}
+
+// writeTypeScriptTests writes the generated test scaffolds into testsDir,
+// importing the service generated at outputPath
+func writeTypeScriptTests(gen *typescript.Generator, testsDir string, outputPath string) error {
+	importPath, err := relativeImportPath(testsDir, outputPath)
+	if err != nil {
+		return err
+	}
+
+	files, err := gen.GenerateTests(tsTestFramework, importPath)
+	if err != nil {
+		return fmt.Errorf("failed to generate TypeScript tests: %w", err)
+	}
+
+	if err := os.MkdirAll(testsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create tests directory: %w", err)
+	}
+	for name, code := range files {
+		if err := os.WriteFile(filepath.Join(testsDir, name), []byte(code), 0644); err != nil {
+			return fmt.Errorf("failed to write TypeScript test: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeTypeScriptIntegrationTests writes the emulator integration tests of
+// the Flow CLI project in projectDir into testsDir
+func writeTypeScriptIntegrationTests(gen *typescript.Generator, testsDir string, outputPath string, projectDir string) error {
+	project, err := flowcli.LoadProject(projectDir)
+	if err != nil {
+		return err
+	}
+	importPath, err := relativeImportPath(testsDir, outputPath)
+	if err != nil {
+		return err
+	}
+	projectPath, err := filepath.Rel(testsDir, projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project path: %w", err)
+	}
+
+	var addresses map[string]interface{}
+	if emulator, ok := project.Addresses()["emulator"].(map[string]interface{}); ok {
+		addresses = emulator
+	}
+	code, err := gen.GenerateIntegrationTests(tsTestFramework, importPath, filepath.ToSlash(projectPath)+"/", addresses)
+	if err != nil {
+		return fmt.Errorf("failed to generate TypeScript integration tests: %w", err)
+	}
+	if err := os.MkdirAll(testsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create tests directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(testsDir, "integration.test.ts"), []byte(code), 0644); err != nil {
+		return fmt.Errorf("failed to write TypeScript integration tests: %w", err)
+	}
+	return nil
+}
+
+// writeTypeScriptMock writes the mock service and its fixtures folder into
+// mockDir. Existing fixture JSON files are kept so that edits survive regeneration.
+func writeTypeScriptMock(gen *typescript.Generator, mockDir string, outputPath string) error {
+	importPath, err := relativeImportPath(mockDir, outputPath)
+	if err != nil {
+		return err
+	}
+
+	code, files, err := gen.GenerateMock(importPath)
+	if err != nil {
+		return fmt.Errorf("failed to generate TypeScript mock: %w", err)
+	}
+
+	fixturesDir := filepath.Join(mockDir, "fixtures")
+	if err := os.MkdirAll(fixturesDir, 0755); err != nil {
+		return fmt.Errorf("failed to create fixtures directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(mockDir, "cadence.mock.ts"), []byte(code), 0644); err != nil {
+		return fmt.Errorf("failed to write TypeScript mock: %w", err)
+	}
+	for name, content := range files {
+		path := filepath.Join(fixturesDir, name)
+		if filepath.Ext(name) == ".json" {
+			if _, err := os.Stat(path); err == nil {
+				continue
+			}
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write fixture: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeTypeScriptBarrel writes the barrel with tag-scoped sub-services next to
+// the service generated at outputPath
+func writeTypeScriptBarrel(gen *typescript.Generator, outputPath string) error {
+	dir := filepath.Dir(outputPath)
+	importPath, err := relativeImportPath(dir, outputPath)
+	if err != nil {
+		return err
+	}
+
+	code, err := gen.GenerateBarrel(importPath)
+	if err != nil {
+		return fmt.Errorf("failed to generate TypeScript barrel: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.ts"), []byte(code), 0644); err != nil {
+		return fmt.Errorf("failed to write TypeScript barrel: %w", err)
+	}
+	return nil
+}
+
+// writeTypeScriptWorker writes the worker module and its main-thread proxy
+// next to the service generated at outputPath
+func writeTypeScriptWorker(gen *typescript.Generator, outputPath string) error {
+	dir := filepath.Dir(outputPath)
+	importPath, err := relativeImportPath(dir, outputPath)
+	if err != nil {
+		return err
+	}
+
+	worker, proxy, err := gen.GenerateWorker(importPath)
+	if err != nil {
+		return fmt.Errorf("failed to generate TypeScript worker: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cadence.worker.ts"), []byte(worker), 0644); err != nil {
+		return fmt.Errorf("failed to write TypeScript worker: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cadence.proxy.ts"), []byte(proxy), 0644); err != nil {
+		return fmt.Errorf("failed to write TypeScript worker proxy: %w", err)
+	}
+	return nil
+}
+
+// writeTypeScriptAuth writes the auth module next to the service generated at
+// outputPath. The config file is optional unless it was set explicitly.
+func writeTypeScriptAuth(gen *typescript.Generator, outputPath string, required bool) error {
+	cfg, err := config.Load(tsConfigPath, required)
+	if err != nil {
+		return err
+	}
+
+	code, err := gen.GenerateAuth(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to generate TypeScript auth module: %w", err)
+	}
+	authPath := filepath.Join(filepath.Dir(outputPath), "cadence.auth.ts")
+	if err := os.WriteFile(authPath, []byte(code), 0644); err != nil {
+		return fmt.Errorf("failed to write TypeScript auth module: %w", err)
+	}
+	return nil
 }
 
 func init() {
+	typescriptCmd.Flags().StringVar(&tsTestsDir, "tests-dir", "", "Directory to write generated test scaffolds to (disabled if empty)")
+	typescriptCmd.Flags().StringVar(&tsIntegration, "integration-tests", "", "Flow CLI project directory to generate emulator integration tests for (requires --tests-dir)")
+	typescriptCmd.Flags().StringVar(&tsTestFramework, "test-framework", typescript.TestFrameworkVitest, "Test framework for generated test scaffolds (vitest/jest)")
+	typescriptCmd.Flags().StringVar(&tsMockDir, "mock-dir", "", "Directory to write a mock service and fixtures folder to (disabled if empty)")
+	typescriptCmd.Flags().BoolVar(&tsTelemetry, "telemetry", false, "Report the duration and outcome of every query and mutation to telemetry hooks")
+	typescriptCmd.Flags().BoolVar(&tsLogging, "logging", false, "Generate logger hooks reporting the requests, responses and errors of fcl calls")
+	typescriptCmd.Flags().BoolVar(&tsRetry, "retry", false, "Generate a configurable retry policy applied to queries and mutations")
+	typescriptCmd.Flags().BoolVar(&tsCache, "cache", false, "Generate a TTL cache of script results configured per function")
+	typescriptCmd.Flags().BoolVar(&tsOffline, "offline-signing", false, "Generate functions exporting unsigned transactions for offline and multi-party signing")
+	typescriptCmd.Flags().BoolVar(&tsIdempotency, "idempotency", false, "Generate idempotency keys detecting duplicate submissions of transactions")
+	typescriptCmd.Flags().BoolVar(&tsEstimates, "estimates", false, "Generate estimate helpers calling an estimator registered with useEstimator, none is built in")
+	typescriptCmd.Flags().BoolVar(&tsValidate, "validate", false, "Validate arguments against their Cadence types before fcl encoding")
+	typescriptCmd.Flags().BoolVar(&tsBarrel, "barrel", false, "Generate an index.ts barrel grouping tagged functions into sub-services")
+	typescriptCmd.Flags().BoolVar(&tsWorker, "worker", false, "Generate a Web Worker and main-thread proxy running scripts off the UI thread")
+	typescriptCmd.Flags().BoolVar(&tsAuth, "auth", false, "Generate an auth module wiring fcl discovery and WalletConnect")
+	typescriptCmd.Flags().BoolVar(&tsSlim, "slim", false, "Minimize the bundle size by deduplicating embedded Cadence code")
+	typescriptCmd.Flags().BoolVar(&tsIntegrity, "integrity", false, "Check the SHA-256 of the embedded Cadence code of a function before executing it")
+	typescriptCmd.Flags().BoolVar(&tsNode, "node", false, "Generate the Node.js flavor signing transactions with a server-side signer")
+	typescriptCmd.Flags().BoolVar(&tsDeclarations, "declarations", false, "Generate only type declarations (.d.ts) without an implementation")
+	typescriptCmd.Flags().StringVar(&tsConfigPath, "config", config.DefaultFile, "Config file with the network and app metadata of the auth module")
+	addWithStandardFlag(typescriptCmd)
+	addDeploymentsFlag(typescriptCmd)
+	addFilterFlags(typescriptCmd)
+	addBreakingFlags(typescriptCmd)
+	addNamesFlag(typescriptCmd)
 	rootCmd.AddCommand(typescriptCmd)
 }
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/outblock/cadence-codegen/internal/names"
+	"github.com/outblock/cadence-codegen/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	unusedFormat    string
+	unusedPrunePath string
+)
+
+var unusedCmd = &cobra.Command{
+	Use:   "unused [input] [usage]",
+	Short: "Report the interactions never called according to usage data",
+	Long: `Report the scripts and transactions never called according to a usage export of app
+analytics, a JSON object mapping function names to call counts, e.g. {"getBalance": 120}.
+The input can be either:
+1. A single .cdc file
+2. A directory containing .cdc files
+3. A JSON file previously generated by the analyze command
+An interaction is called by its file name, with or without .cdc, its TypeScript, Swift or Go
+function name, or with --names a name recorded for it in the names file. Names of the export
+matching no interaction are listed as unknown.
+With --prune pruned.json, a report without the unused interactions and the structs only they use
+is written, to generate smaller bundles from.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := loadReport(args[0])
+		if err != nil {
+			return err
+		}
+		counts, err := usage.Load(args[1])
+		if err != nil {
+			return err
+		}
+		recorded := make(names.Names)
+		if namesPath != "" {
+			if recorded, err = names.Load(namesPath); err != nil {
+				return err
+			}
+		}
+
+		result := usage.Analyze(*report, counts, recorded)
+		switch unusedFormat {
+		case "json":
+			jsonData, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+		case "text":
+			out := cmd.OutOrStdout()
+			for _, interaction := range result.Unused {
+				fmt.Fprintf(out, "%s %s: never called\n", interaction.Type, interaction.FileName)
+			}
+			for _, name := range result.Unknown {
+				fmt.Fprintf(out, "unknown %s: matches no interaction\n", name)
+			}
+			fmt.Fprintf(out, "%d of %d interactions unused\n", len(result.Unused), len(result.Used)+len(result.Unused))
+		default:
+			return fmt.Errorf("unsupported format: %s", unusedFormat)
+		}
+
+		if unusedPrunePath != "" {
+			result.Prune(report)
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			if err := os.WriteFile(unusedPrunePath, jsonData, 0644); err != nil {
+				return fmt.Errorf("failed to write JSON file: %w", err)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	unusedCmd.Flags().StringVar(&unusedFormat, "format", "text", "Output format (text/json)")
+	unusedCmd.Flags().StringVar(&unusedPrunePath, "prune", "", "Write a report without the unused interactions (e.g. pruned.json)")
+	addNamesFlag(unusedCmd)
+	rootCmd.AddCommand(unusedCmd)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	vendorDir      string
+	vendorLockfile string
+)
+
+var vendorCmd = &cobra.Command{
+	Use:   "vendor [input]",
+	Short: "Download the imported contracts for offline runs",
+	Long: `Download the sources of the contracts imported by the scripts and transactions from
+mainnet and testnet into a local directory (defaults to vendored/) and record their
+network, address, path and SHA-256 in the cadence-codegen.lock lockfile.
+The input can be either a single .cdc file, a directory containing .cdc files or a
+JSON file previously generated by the analyze command. Contract addresses come from
+the report. Later runs in the same working directory read the vendored sources instead
+of fetching them from chain, so that they are fully offline and auditable, and fail if a
+vendored source no longer matches its recorded hash.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := loadReport(args[0])
+		if err != nil {
+			return err
+		}
+
+		lock, failed, err := analyzer.Vendor(*report, vendorDir, vendorLockfile)
+		if err != nil {
+			return err
+		}
+
+		var contracts []string
+		for contract := range failed {
+			contracts = append(contracts, contract)
+		}
+		sort.Strings(contracts)
+		for _, contract := range contracts {
+			fmt.Fprintf(os.Stderr, "Warning: failed to vendor %s: %s\n", contract, failed[contract])
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Vendored %d contracts into %s\n", len(lock.Contracts), vendorDir)
+		return nil
+	},
+}
+
+func init() {
+	vendorCmd.Flags().StringVar(&vendorDir, "dir", analyzer.VendorDir, "Directory of the vendored contract sources")
+	vendorCmd.Flags().StringVar(&vendorLockfile, "lockfile", analyzer.LockfileName, "Path of the lockfile recording the vendored contracts")
+	rootCmd.AddCommand(vendorCmd)
+}
@@ -0,0 +1,126 @@
+//go:build cgo
+
+// Command ffi exposes the analyzer and generators through a C ABI so that
+// non-Go toolchains can embed the codegen engine:
+//
+//	go build -buildmode=c-shared -o libcadencecodegen.so ./ffi
+//
+// All functions return a newly allocated string, or NULL with *err set to an
+// error message. Both must be released with CadenceCodegenFree.
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+	"unsafe"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/golang"
+	"github.com/outblock/cadence-codegen/internal/generator/swift"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+)
+
+// result converts a Go result into the C return convention
+func result(value string, err error, errOut **C.char) *C.char {
+	if err != nil {
+		if errOut != nil {
+			*errOut = C.CString(err.Error())
+		}
+		return nil
+	}
+	return C.CString(value)
+}
+
+// marshalReport returns the JSON report of an analyzer
+func marshalReport(a *analyzer.Analyzer) (string, error) {
+	jsonData, err := json.MarshalIndent(a.GetReport(), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(jsonData), nil
+}
+
+// analyzeDirectory analyzes all Cadence files in a directory
+func analyzeDirectory(path string, includeBase64 bool) (string, error) {
+	a := analyzer.New()
+	a.SetIncludeBase64(includeBase64)
+	if err := a.AnalyzeDirectory(path); err != nil {
+		return "", fmt.Errorf("failed to analyze directory: %w", err)
+	}
+	return marshalReport(a)
+}
+
+// analyzeFile analyzes a single Cadence file
+func analyzeFile(path string, includeBase64 bool) (string, error) {
+	a := analyzer.New()
+	a.SetIncludeBase64(includeBase64)
+	if _, err := a.AnalyzeFile(path); err != nil {
+		return "", fmt.Errorf("failed to analyze file: %w", err)
+	}
+	return marshalReport(a)
+}
+
+// analyzeSource analyzes Cadence source code as if it was read from fileName
+func analyzeSource(code string, fileName string, includeBase64 bool) (string, error) {
+	a := analyzer.New()
+	a.SetIncludeBase64(includeBase64)
+	if _, err := a.AnalyzeSource(fileName, []byte(code)); err != nil {
+		return "", fmt.Errorf("failed to analyze source: %w", err)
+	}
+	return marshalReport(a)
+}
+
+// generate generates code for a target (swift/typescript/golang) from a JSON report
+func generate(reportJSON string, target string) (string, error) {
+	var report analyzer.Report
+	if err := json.Unmarshal([]byte(reportJSON), &report); err != nil {
+		return "", fmt.Errorf("failed to parse report: %w", err)
+	}
+
+	switch target {
+	case "swift":
+		return swift.New(report).Generate()
+	case "typescript":
+		return typescript.New(report).Generate()
+	case "golang":
+		return golang.New(report).Generate()
+	default:
+		return "", fmt.Errorf("unsupported target: %s", target)
+	}
+}
+
+//export CadenceCodegenAnalyzeDirectory
+func CadenceCodegenAnalyzeDirectory(path *C.char, includeBase64 C.int, err **C.char) *C.char {
+	value, e := analyzeDirectory(C.GoString(path), includeBase64 != 0)
+	return result(value, e, err)
+}
+
+//export CadenceCodegenAnalyzeFile
+func CadenceCodegenAnalyzeFile(path *C.char, includeBase64 C.int, err **C.char) *C.char {
+	value, e := analyzeFile(C.GoString(path), includeBase64 != 0)
+	return result(value, e, err)
+}
+
+//export CadenceCodegenAnalyzeSource
+func CadenceCodegenAnalyzeSource(code *C.char, fileName *C.char, includeBase64 C.int, err **C.char) *C.char {
+	value, e := analyzeSource(C.GoString(code), C.GoString(fileName), includeBase64 != 0)
+	return result(value, e, err)
+}
+
+//export CadenceCodegenGenerate
+func CadenceCodegenGenerate(reportJSON *C.char, target *C.char, err **C.char) *C.char {
+	value, e := generate(C.GoString(reportJSON), C.GoString(target))
+	return result(value, e, err)
+}
+
+//export CadenceCodegenFree
+func CadenceCodegenFree(p *C.char) {
+	C.free(unsafe.Pointer(p))
+}
+
+func main() {}
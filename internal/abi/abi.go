@@ -0,0 +1,160 @@
+package abi
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// Version is the version of the ABI layout, increased on breaking changes
+const Version = 1
+
+// Value is a named and typed argument, return field or event field
+type Value struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// Event is an event declared in a contract
+type Event struct {
+	Name   string  `json:"name"`
+	Fields []Value `json:"fields"`
+}
+
+// Contract is a contract imported by the interactions or declaring events
+type Contract struct {
+	Name         string            `json:"name"`
+	Address      string            `json:"address,omitempty"`  // Address or placeholder of its imports
+	Networks     map[string]string `json:"networks,omitempty"` // network -> address
+	Events       []Event           `json:"events"`
+	Interactions []string          `json:"interactions"` // Interactions importing it
+}
+
+// Interaction is the callable surface of a script or transaction
+type Interaction struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind"` // script or transaction
+	Tag        string   `json:"tag,omitempty"`
+	Arguments  []Value  `json:"arguments"`
+	Returns    string   `json:"returns,omitempty"`
+	Signers    int      `json:"signers,omitempty"`
+	Contracts  []string `json:"contracts"`
+	Deprecated string   `json:"deprecated,omitempty"`
+	// Messages are the user-facing messages by key, e.g. title
+	Messages map[string]string `json:"messages,omitempty"`
+	// Translations are the messages in other locales, by locale and key
+	Translations map[string]map[string]string `json:"translations,omitempty"`
+	// Roles names the signer holding the proposer and payer roles by role
+	Roles map[string]string `json:"roles,omitempty"`
+}
+
+// Type is a struct type used by the arguments and return values
+type Type struct {
+	Name   string  `json:"name"`
+	Fields []Value `json:"fields"`
+}
+
+// ABI is the callable surface of a report for third-party integrators, with
+// every list sorted by name
+type ABI struct {
+	Version      int           `json:"version"`
+	Contracts    []Contract    `json:"contracts"`
+	Interactions []Interaction `json:"interactions"`
+	Types        []Type        `json:"types"`
+}
+
+// Build summarizes the contracts, interactions and struct types of report
+func Build(report analyzer.Report) *ABI {
+	contracts := make(map[string]*Contract)
+	contract := func(name string) *Contract {
+		if c, ok := contracts[name]; ok {
+			return c
+		}
+		c := &Contract{Name: name, Events: []Event{}, Interactions: []string{}}
+		for network, addresses := range report.Addresses {
+			if addresses, ok := addresses.(map[string]interface{}); ok {
+				// Addresses are keyed by the import placeholder, e.g. 0xFlowToken
+				address, ok := addresses["0x"+name].(string)
+				if !ok {
+					address, ok = addresses[name].(string)
+				}
+				if ok {
+					if c.Networks == nil {
+						c.Networks = make(map[string]string)
+					}
+					c.Networks[network] = address
+				}
+			}
+		}
+		contracts[name] = c
+		return c
+	}
+
+	summary := &ABI{Version: Version, Interactions: []Interaction{}, Contracts: []Contract{}, Types: []Type{}}
+	for kind, results := range map[string]map[string]analyzer.AnalysisResult{"script": report.Scripts, "transaction": report.Transactions} {
+		for fileName, result := range results {
+			interaction := Interaction{
+				Name:       strings.TrimSuffix(fileName, ".cdc"),
+				Kind:       kind,
+				Tag:        result.Tag,
+				Arguments:  values(result.Parameters),
+				Returns:    result.ReturnType,
+				Signers:    len(result.Signers),
+				Contracts:  []string{},
+				Deprecated: result.Deprecated,
+				Messages:   result.Messages,
+			}
+			interaction.Translations = result.Translations
+			interaction.Roles = result.Roles
+			for _, imp := range result.Imports {
+				c := contract(imp.Contract)
+				if c.Address == "" {
+					c.Address = imp.Address
+				}
+				c.Interactions = append(c.Interactions, interaction.Name)
+				interaction.Contracts = append(interaction.Contracts, imp.Contract)
+			}
+			sort.Strings(interaction.Contracts)
+			summary.Interactions = append(summary.Interactions, interaction)
+		}
+	}
+
+	for _, event := range report.Events {
+		c := contract(event.Contract)
+		c.Events = append(c.Events, Event{Name: event.Name, Fields: fields(event.Fields)})
+	}
+
+	for _, c := range contracts {
+		sort.Strings(c.Interactions)
+		sort.Slice(c.Events, func(i, j int) bool { return c.Events[i].Name < c.Events[j].Name })
+		summary.Contracts = append(summary.Contracts, *c)
+	}
+	sort.Slice(summary.Contracts, func(i, j int) bool { return summary.Contracts[i].Name < summary.Contracts[j].Name })
+	sort.Slice(summary.Interactions, func(i, j int) bool { return summary.Interactions[i].Name < summary.Interactions[j].Name })
+
+	for _, composite := range report.Structs {
+		summary.Types = append(summary.Types, Type{Name: composite.Name, Fields: fields(composite.Fields)})
+	}
+	sort.Slice(summary.Types, func(i, j int) bool { return summary.Types[i].Name < summary.Types[j].Name })
+	return summary
+}
+
+// values converts parameters in declaration order
+func values(parameters []analyzer.Parameter) []Value {
+	result := make([]Value, 0, len(parameters))
+	for _, param := range parameters {
+		result = append(result, Value{Name: param.Name, Type: param.TypeStr, Optional: param.Optional})
+	}
+	return result
+}
+
+// fields converts struct and event fields in declaration order
+func fields(declared []analyzer.Field) []Value {
+	result := make([]Value, 0, len(declared))
+	for _, field := range declared {
+		result = append(result, Value{Name: field.Name, Type: field.TypeStr, Optional: field.Optional})
+	}
+	return result
+}
@@ -3,6 +3,7 @@ package analyzer
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -51,6 +52,14 @@ type Struct struct {
 	FileName string  `json:"fileName"`
 }
 
+// Event represents a Cadence event declared in a contract
+type Event struct {
+	Name     string  `json:"name"`
+	Contract string  `json:"contract"`
+	Fields   []Field `json:"fields"`
+	FileName string  `json:"fileName"`
+}
+
 // AnalysisResult represents the analysis result of a single Cadence file
 type AnalysisResult struct {
 	FileName   string      `json:"fileName"`
@@ -60,15 +69,40 @@ type AnalysisResult struct {
 	Imports    []Import    `json:"imports"`
 	Base64     string      `json:"base64,omitempty"`
 	Tag        string      `json:"tag,omitempty"`
+	Signers    []Parameter `json:"signers,omitempty"`    // Parameters of the transaction prepare block
+	Deprecated string      `json:"deprecated,omitempty"` // Message of the // codegen:deprecated pragma
+	FilePath   string      `json:"filePath,omitempty"`   // Slash separated path of the .cdc file, relative to BaseDir or the analyzed root
+	Pagination *Pagination `json:"pagination,omitempty"` // Offset and limit parameters of a paginated script
+	Paths      []Path      `json:"paths,omitempty"`      // Storage and public path literals of the code
+	Setup      *Setup      `json:"setup,omitempty"`      // Marks a transaction preparing the signer account
+	// UnusedParameters names the transaction parameters never used in its body
+	UnusedParameters []string `json:"unusedParameters,omitempty"`
+	// Messages are the user-facing messages of the // codegen:title and
+	// // codegen:description pragmas by key
+	Messages map[string]string `json:"messages,omitempty"`
+	// Translations are the messages in other locales, by locale and key
+	Translations map[string]map[string]string `json:"translations,omitempty"`
+	// Roles names the signer holding the proposer and payer roles of a
+	// transaction by role, e.g. payer -> admin
+	Roles map[string]string `json:"roles,omitempty"`
+	// ReturnUnion lists the types a script returning AnyStruct returns, from
+	// the // codegen:returns pragma, in the order they are tried
+	ReturnUnion []string `json:"returnUnion,omitempty"`
 }
 
 // Report represents the complete analysis report
 type Report struct {
-	Transactions  map[string]AnalysisResult `json:"transactions"`
-	Scripts       map[string]AnalysisResult `json:"scripts"`
-	Structs       map[string]Struct         `json:"structs"`
-	Addresses     map[string]interface{}    `json:"addresses,omitempty"`
-	IncludeBase64 bool                      `json:"-"`
+	Transactions        map[string]AnalysisResult `json:"transactions"`
+	Scripts             map[string]AnalysisResult `json:"scripts"`
+	Structs             map[string]Struct         `json:"structs"`
+	Events              map[string]Event          `json:"events,omitempty"`
+	Addresses           map[string]interface{}    `json:"addresses,omitempty"`
+	AccessNodes         map[string][]string       `json:"accessNodes,omitempty"`         // network -> access nodes in order of priority
+	Skipped             map[string]string         `json:"skipped,omitempty"`             // .cdc file path -> reason no binding was produced
+	UnresolvedContracts map[string]string         `json:"unresolvedContracts,omitempty"` // contract -> error fetching it from chain
+	CodegenVersion      string                    `json:"codegenVersion,omitempty"`      // Version of cadence-codegen that produced the report
+	Manifest            *Manifest                 `json:"manifest,omitempty"`            // Signed hashes of the code, set with --sign-key
+	IncludeBase64       bool                      `json:"-"`
 }
 
 // Analyzer is responsible for analyzing Cadence files
@@ -76,8 +110,23 @@ type Analyzer struct {
 	Transactions  map[string]AnalysisResult
 	Scripts       map[string]AnalysisResult
 	Structs       map[string]Struct
+	Events        map[string]Event
+	Skipped       map[string]string // .cdc file path -> reason no binding was produced
+	Unresolved    map[string]string // contract -> error fetching it from chain
 	IncludeBase64 bool
+	CleanImports  bool   // Removes unused imports from the embedded code
 	AddressesPath string // New field for storing addresses.json path
+	BaseDir       string // Tags are derived from paths relative to BaseDir if set
+	Deployments   bool   // Generates transactions deploying and updating contracts
+	// FLIXAddresses holds the contract addresses pinned by FLIX templates,
+	// network -> 0x<Contract> -> address
+	FLIXAddresses map[string]map[string]string
+	Lockfile      *Lockfile // Vendored contracts read instead of fetching them from chain
+	TagStrategy   string    // Derivation of the tags, see TagStrategyDirectory
+	// AccessNodes lists the access nodes of generated clients per network in
+	// order of priority
+	AccessNodes map[string][]string
+	root        string // Directory analyzed by AnalyzeDirectory
 }
 
 // New creates a new Analyzer instance
@@ -86,6 +135,9 @@ func New() *Analyzer {
 		Transactions:  make(map[string]AnalysisResult),
 		Scripts:       make(map[string]AnalysisResult),
 		Structs:       make(map[string]Struct),
+		Events:        make(map[string]Event),
+		Skipped:       make(map[string]string),
+		Unresolved:    make(map[string]string),
 		IncludeBase64: false,
 	}
 }
@@ -151,6 +203,23 @@ func (a *Analyzer) GetReport() *Report {
 		}
 	}
 
+	// Addresses pinned by FLIX templates complete those of addresses.json
+	for network, contracts := range a.FLIXAddresses {
+		if addresses == nil {
+			addresses = make(map[string]interface{})
+		}
+		networkAddresses, ok := addresses[network].(map[string]interface{})
+		if !ok {
+			networkAddresses = make(map[string]interface{})
+			addresses[network] = networkAddresses
+		}
+		for contract, address := range contracts {
+			if _, ok := networkAddresses[contract]; !ok {
+				networkAddresses[contract] = address
+			}
+		}
+	}
+
 	// Flatten struct names in the report
 	flattenedStructs := make(map[string]Struct)
 	for key, structDef := range a.Structs {
@@ -161,16 +230,23 @@ func (a *Analyzer) GetReport() *Report {
 	}
 
 	return &Report{
-		Transactions:  a.Transactions,
-		Scripts:       a.Scripts,
-		Structs:       flattenedStructs,
-		Addresses:     addresses,
-		IncludeBase64: a.IncludeBase64,
+		Transactions:        a.Transactions,
+		Scripts:             a.Scripts,
+		Structs:             flattenedStructs,
+		Events:              a.Events,
+		Addresses:           addresses,
+		AccessNodes:         a.AccessNodes,
+		Skipped:             a.Skipped,
+		UnresolvedContracts: a.Unresolved,
+		CodegenVersion:      Version,
+		IncludeBase64:       a.IncludeBase64,
 	}
 }
 
-// extractImports extracts imports from the code and returns the code without imports
-func extractImports(content []byte) ([]Import, []byte) {
+// ExtractImports extracts imports from the code and returns the code without imports.
+// Import lines are blanked rather than removed so that parser positions still
+// match the line numbers of the original file.
+func ExtractImports(content []byte) ([]Import, []byte) {
 	lines := strings.Split(string(content), "\n")
 	var imports []Import
 	var nonImportLines []string
@@ -185,6 +261,7 @@ func extractImports(content []byte) ([]Import, []byte) {
 					Address:  parts[3],
 				})
 			}
+			nonImportLines = append(nonImportLines, "")
 		} else {
 			nonImportLines = append(nonImportLines, line)
 		}
@@ -200,48 +277,28 @@ func (a *Analyzer) AnalyzeFile(filePath string) (*AnalysisResult, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	imports, codeWithoutImports := extractImports(content)
-
-	fileName := filepath.Base(filePath)
+	return a.AnalyzeSource(filePath, content)
+}
 
-	// Get full directory path as tag
-	var tag string
-	dir := filepath.Dir(filePath)
-	if dir != "." {
-		// Split the path and remove any empty parts
-		parts := strings.Split(dir, string(filepath.Separator))
-		var validParts []string
-		for _, part := range parts {
-			if part != "" && part != "." {
-				validParts = append(validParts, part)
-			}
-		}
+// AnalyzeSource analyzes Cadence source code as if it was read from filePath.
+// The file name and tag of the result are derived from filePath.
+func (a *Analyzer) AnalyzeSource(filePath string, content []byte) (*AnalysisResult, error) {
+	content, err := NormalizeSource(content)
+	if err != nil {
+		return nil, err
+	}
+	pragmas := parsePragmas(content)
+	if _, ok := pragmas["skip"]; ok {
+		return nil, ErrSkipped
+	}
+	if a.CleanImports {
+		content = RemoveImports(content, UnusedImports(content))
+	}
+	imports, codeWithoutImports := ExtractImports(content)
 
-		// Convert to camelCase
-		for i, part := range validParts {
-			// Split by underscores or hyphens
-			subParts := strings.FieldsFunc(part, func(r rune) bool {
-				return r == '_' || r == '-'
-			})
-			// Capitalize each part
-			for j, subPart := range subParts {
-				if i == 0 && j == 0 {
-					// First word starts with lowercase
-					subParts[j] = strings.ToLower(subPart)
-				} else {
-					subParts[j] = strings.Title(strings.ToLower(subPart))
-				}
-			}
-			validParts[i] = strings.Join(subParts, "")
-		}
+	fileName := filepath.Base(filePath)
 
-		// Join all parts
-		tag = strings.Join(validParts, "")
-		if tag != "" {
-			// Ensure first character is uppercase for Swift enum
-			tag = strings.Title(tag)
-		}
-	}
+	tag := a.deriveTag(filePath, pragmas)
 
 	memoryGauge := &SimpleMemoryGauge{}
 	program, err := parser.ParseProgram(memoryGauge, codeWithoutImports, parser.Config{})
@@ -253,49 +310,79 @@ func (a *Analyzer) AnalyzeFile(filePath string) (*AnalysisResult, error) {
 	result := &AnalysisResult{
 		FileName: fileName,
 		Imports:  imports,
+		FilePath: a.relativePath(filePath),
+		Paths:    DetectPaths(codeWithoutImports),
 	}
 
 	// Only set tag if it's not empty
 	if tag != "" {
 		result.Tag = tag
 	}
+	if message, ok := pragmas["deprecated"]; ok {
+		if message == "" {
+			message = defaultDeprecationMessage
+		}
+		result.Deprecated = message
+	}
+	result.Messages = messagePragmas(pragmas)
 
 	// Add base64 content if enabled
 	if a.IncludeBase64 {
 		result.Base64 = base64.StdEncoding.EncodeToString(content)
 	}
 
-	// Check for struct declarations
+	// Check for struct declarations, including helper structs declared in contracts
 	for _, declaration := range program.Declarations() {
-		if structDecl, ok := declaration.(*ast.CompositeDeclaration); ok {
-			// Check if it's a struct by checking the composite kind
-			if structDecl.CompositeKind == common.CompositeKindStructure {
-				fields := make([]Field, 0)
-
-				for _, member := range structDecl.Members.Declarations() {
-					if field, ok := member.(*ast.FieldDeclaration); ok {
-						var optional bool
-						if _, isOptional := field.TypeAnnotation.Type.(*ast.OptionalType); isOptional {
-							optional = true
-						}
-
-						fields = append(fields, Field{
-							Name:     field.Identifier.String(),
-							TypeStr:  field.TypeAnnotation.String(),
-							Optional: optional,
-							Access:   field.Access.String(),
-						})
-					}
+		composite, ok := declaration.(*ast.CompositeDeclaration)
+		if !ok {
+			continue
+		}
+		switch composite.CompositeKind {
+		case common.CompositeKindStructure:
+			a.addStruct(composite.Identifier.String(), composite, fileName)
+		case common.CompositeKindContract:
+			for _, member := range composite.Members.Composites() {
+				if member.CompositeKind == common.CompositeKindStructure {
+					a.addStruct(composite.Identifier.String()+"."+member.Identifier.String(), member, fileName)
 				}
+			}
+		}
+	}
 
-				structName := structDecl.Identifier.String()
-				a.Structs[structName] = Struct{
-					Name:     structName,
-					Fields:   fields,
-					Access:   structDecl.Access.String(),
-					FileName: fileName,
+	// Check for event declarations in contracts
+	for _, declaration := range program.Declarations() {
+		contractDecl, ok := declaration.(*ast.CompositeDeclaration)
+		if !ok || contractDecl.CompositeKind != common.CompositeKindContract {
+			continue
+		}
+		contractName := contractDecl.Identifier.String()
+		for _, member := range contractDecl.Members.Composites() {
+			if member.CompositeKind != common.CompositeKindEvent {
+				continue
+			}
+
+			fields := make([]Field, 0)
+			for _, initializer := range member.Members.Initializers() {
+				if initializer.FunctionDeclaration.ParameterList == nil {
+					continue
+				}
+				for _, param := range initializer.FunctionDeclaration.ParameterList.Parameters {
+					_, optional := param.TypeAnnotation.Type.(*ast.OptionalType)
+					fields = append(fields, Field{
+						Name:     param.Identifier.String(),
+						TypeStr:  param.TypeAnnotation.String(),
+						Optional: optional,
+					})
 				}
 			}
+
+			eventName := member.Identifier.String()
+			a.Events[contractName+"."+eventName] = Event{
+				Name:     eventName,
+				Contract: contractName,
+				Fields:   fields,
+				FileName: fileName,
+			}
 		}
 	}
 
@@ -312,79 +399,157 @@ func (a *Analyzer) AnalyzeFile(filePath string) (*AnalysisResult, error) {
 					})
 				}
 			}
+			if transaction.Prepare != nil && transaction.Prepare.FunctionDeclaration.ParameterList != nil {
+				for _, param := range transaction.Prepare.FunctionDeclaration.ParameterList.Parameters {
+					result.Signers = append(result.Signers, Parameter{
+						Name:    param.Identifier.String(),
+						TypeStr: param.TypeAnnotation.String(),
+					})
+				}
+			}
 			result.Type = "transaction"
 			result.Parameters = params
+			result.UnusedParameters = UnusedParameters(transaction)
+			roles, annotated := pragmas["roles"]
+			if result.Roles, err = detectRoles(result.Signers, roles, annotated); err != nil {
+				return nil, err
+			}
+			setup, annotated := pragmas["setup"]
+			if result.Setup, err = detectSetup(*result, codeWithoutImports, setup, annotated); err != nil {
+				return nil, err
+			}
 			a.Transactions[fileName] = *result
 			return result, nil
 		}
 	}
 
-	// Check for script (main function)
-	for _, declaration := range program.Declarations() {
-		if function, ok := declaration.(*ast.FunctionDeclaration); ok {
-			if function.Identifier.String() == "main" {
-				params := make([]Parameter, 0)
-				if function.ParameterList != nil {
-					for _, param := range function.ParameterList.Parameters {
-						params = append(params, Parameter{
-							Name:     param.Identifier.String(),
-							TypeStr:  param.TypeAnnotation.String(),
-							Optional: false,
-						})
-					}
-				}
-				result.Type = "script"
-				result.Parameters = params
-				if function.ReturnTypeAnnotation != nil {
-					result.ReturnType = function.ReturnTypeAnnotation.Type.String()
-				}
-				a.Scripts[fileName] = *result
-				return result, nil
-			}
+	// Check for scripts. Without a main function, every function with an
+	// access modifier is an entry point: the first keeps the file name and the
+	// others are named after the file and the function. A main function
+	// forwarding to the entry point is appended to their code.
+	// // codegen:entry <function> selects the only entry point
+	functions, err := ScriptEntryPoints(content, program)
+	if err != nil {
+		return nil, err
+	}
+	for _, function := range functions {
+		if err := CheckReturnType(function); err != nil {
+			return nil, err
 		}
 	}
+	for i, function := range functions {
+		script := *result
+		script.Type = "script"
+		script.Parameters = functionParameters(function)
+		if function.ReturnTypeAnnotation != nil {
+			script.ReturnType = function.ReturnTypeAnnotation.Type.String()
+		}
+		if i > 0 {
+			script.FileName = entryPointFileName(fileName, function.Identifier.String())
+		}
+		paginate, annotated := pragmas["paginate"]
+		pagination, err := detectPagination(script, paginate, annotated)
+		if err != nil {
+			return nil, err
+		}
+		script.Pagination = pagination
+		returns, annotated := pragmas["returns"]
+		if script.ReturnUnion, err = detectReturnUnion(script, returns, annotated, a.Structs); err != nil {
+			return nil, err
+		}
+		if a.IncludeBase64 && function.Identifier.String() != "main" {
+			script.Base64 = base64.StdEncoding.EncodeToString(entryPointCode(content, program, function))
+		}
+		a.Scripts[script.FileName] = script
+	}
+	if len(functions) > 0 {
+		primary := a.Scripts[fileName]
+		return &primary, nil
+	}
 
-	// Check for public function that could serve as the main entry point
-	for _, declaration := range program.Declarations() {
-		if function, ok := declaration.(*ast.FunctionDeclaration); ok {
-			if function.Access != ast.AccessNotSpecified {
-				params := make([]Parameter, 0)
-				if function.ParameterList != nil {
-					for _, param := range function.ParameterList.Parameters {
-						params = append(params, Parameter{
-							Name:     param.Identifier.String(),
-							TypeStr:  param.TypeAnnotation.String(),
-							Optional: false,
-						})
-					}
-				}
-				result.Type = "script"
-				result.Parameters = params
-				if function.ReturnTypeAnnotation != nil {
-					result.ReturnType = function.ReturnTypeAnnotation.Type.String()
-				}
-				a.Scripts[fileName] = *result
-				return result, nil
-			}
+	// Contract files get transactions deploying and updating them if enabled
+	if a.Deployments {
+		if deploy, ok := a.addDeployments(program, content, *result); ok {
+			return deploy, nil
 		}
 	}
 
 	return nil, fmt.Errorf("no transaction or script found in file")
 }
 
-// AnalyzeDirectory analyzes all Cadence files in a directory and its subdirectories
+// addStruct records the fields of a struct declaration under structName
+func (a *Analyzer) addStruct(structName string, structDecl *ast.CompositeDeclaration, fileName string) {
+	fields := make([]Field, 0)
+	for _, member := range structDecl.Members.Declarations() {
+		if field, ok := member.(*ast.FieldDeclaration); ok {
+			var optional bool
+			if _, isOptional := field.TypeAnnotation.Type.(*ast.OptionalType); isOptional {
+				optional = true
+			}
+
+			fields = append(fields, Field{
+				Name:     field.Identifier.String(),
+				TypeStr:  field.TypeAnnotation.String(),
+				Optional: optional,
+				Access:   field.Access.String(),
+			})
+		}
+	}
+
+	a.Structs[structName] = Struct{
+		Name:     structName,
+		Fields:   fields,
+		Access:   structDecl.Access.String(),
+		FileName: fileName,
+	}
+}
+
+// AnalyzeDirectory analyzes all Cadence files and FLIX templates in a
+// directory and its subdirectories
 func (a *Analyzer) AnalyzeDirectory(dirPath string) error {
 	if path, err := findAddressesJSONRecursive(dirPath); err == nil {
 		a.AddressesPath = path
 	}
+	// Paths listed in .codegenignore at the root are excluded
+	ignore := &IgnoreFile{}
+	a.root = filepath.Dir(dirPath)
+	if info, err := os.Stat(dirPath); err == nil && info.IsDir() {
+		a.root = dirPath
+		if ignore, err = LoadIgnoreFile(filepath.Join(dirPath, IgnoreFileName)); err != nil {
+			return err
+		}
+	}
+
 	return filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
+		if rel, err := filepath.Rel(dirPath, path); err == nil && rel != "." && ignore.Match(filepath.ToSlash(rel), info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		if !info.IsDir() && filepath.Ext(path) == ".cdc" {
 			if _, err := a.AnalyzeFile(path); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to analyze %s: %v\n", path, err)
+				if !errors.Is(err, ErrSkipped) {
+					fmt.Fprintf(os.Stderr, "Warning: failed to analyze %s: %v\n", path, err)
+				}
+				a.Skipped[path] = err.Error()
+			}
+		}
+
+		// FLIX interaction templates are analyzed alongside .cdc files
+		if !info.IsDir() && filepath.Ext(path) == ".json" {
+			if data, err := os.ReadFile(path); err == nil && IsFLIX(data) {
+				if _, err := a.AnalyzeFLIXFile(path); err != nil {
+					if !errors.Is(err, ErrSkipped) {
+						fmt.Fprintf(os.Stderr, "Warning: failed to analyze %s: %v\n", path, err)
+					}
+					a.Skipped[path] = err.Error()
+				}
 			}
 		}
 
@@ -392,6 +557,42 @@ func (a *Analyzer) AnalyzeDirectory(dirPath string) error {
 	})
 }
 
+// relativePath returns the slash separated path of filePath relative to
+// BaseDir if set, or else to the analyzed root, so that reports do not leak
+// the absolute location of the checkout. A file analyzed on its own is
+// relative to its directory.
+func (a *Analyzer) relativePath(filePath string) string {
+	base := a.BaseDir
+	if base == "" {
+		base = a.root
+	}
+	if base == "" && filepath.IsAbs(filePath) {
+		base = filepath.Dir(filePath)
+	}
+	if base != "" {
+		if rel, err := filepath.Rel(base, filePath); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(filePath)
+}
+
+// SetBaseDir sets the directory that tags are derived relative to
+func (a *Analyzer) SetBaseDir(dir string) {
+	a.BaseDir = dir
+}
+
+// SetCleanImports sets whether unused imports are removed from the embedded code
+func (a *Analyzer) SetCleanImports(clean bool) {
+	a.CleanImports = clean
+}
+
+// SetAccessNodes sets the access nodes of generated clients per network in
+// order of priority
+func (a *Analyzer) SetAccessNodes(accessNodes map[string][]string) {
+	a.AccessNodes = accessNodes
+}
+
 // SetIncludeBase64 sets whether to include base64-encoded content in the analysis results
 func (a *Analyzer) SetIncludeBase64(include bool) {
 	a.IncludeBase64 = include
@@ -463,8 +664,22 @@ func (a *Analyzer) FetchContractFromChain(contractName string, network string) e
 		return fmt.Errorf("contract %s not found in network %s", contractName, network)
 	}
 
+	// Try to find the contract with the original name (without 0x prefix)
+	originalContractName := strings.TrimPrefix(contractName, "0x")
+	decodedCode, err := a.contractCode(network, contractAddress, originalContractName)
+	if err != nil {
+		return err
+	}
+
+	// Analyze the decoded contract code
+	return a.analyzeContractCode(string(decodedCode), originalContractName)
+}
+
+// FetchContractCode fetches the code of the contract deployed to address on
+// mainnet or testnet through the access node REST API
+func FetchContractCode(network string, address string, contractName string) ([]byte, error) {
 	// Remove 0x prefix if present
-	contractAddress = strings.TrimPrefix(contractAddress, "0x")
+	address = strings.TrimPrefix(address, "0x")
 
 	// Determine API endpoint based on network
 	var apiEndpoint string
@@ -474,47 +689,43 @@ func (a *Analyzer) FetchContractFromChain(contractName string, network string) e
 	case "testnet":
 		apiEndpoint = "https://rest-testnet.onflow.org"
 	default:
-		return fmt.Errorf("unsupported network: %s", network)
+		return nil, fmt.Errorf("unsupported network: %s", network)
 	}
 
 	// Fetch contract from chain
-	url := fmt.Sprintf("%s/v1/accounts/%s?expand=contracts", apiEndpoint, contractAddress)
+	url := fmt.Sprintf("%s/v1/accounts/%s?expand=contracts", apiEndpoint, address)
 	resp, err := http.Get(url)
 	if err != nil {
-		return fmt.Errorf("failed to fetch contract: %w", err)
+		return nil, fmt.Errorf("failed to fetch contract: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	var accountData map[string]interface{}
 	if err := json.Unmarshal(body, &accountData); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	contracts, ok := accountData["contracts"].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("no contracts found in response")
+		return nil, fmt.Errorf("no contracts found in response")
 	}
 
-	// Try to find the contract with the original name (without 0x prefix)
-	originalContractName := strings.TrimPrefix(contractName, "0x")
-	contractCode, ok := contracts[originalContractName].(string)
+	contractCode, ok := contracts[contractName].(string)
 	if !ok {
-		return fmt.Errorf("contract %s not found in response", originalContractName)
+		return nil, fmt.Errorf("contract %s not found in response", contractName)
 	}
 
 	// Decode base64 contract code
 	decodedCode, err := base64.StdEncoding.DecodeString(contractCode)
 	if err != nil {
-		return fmt.Errorf("failed to decode contract code: %w", err)
+		return nil, fmt.Errorf("failed to decode contract code: %w", err)
 	}
-
-	// Analyze the decoded contract code
-	return a.analyzeContractCode(string(decodedCode), originalContractName)
+	return decodedCode, nil
 }
 
 // analyzeContractCode analyzes Cadence contract code and extracts structure definitions
@@ -624,6 +835,9 @@ func (a *Analyzer) ResolveNestedTypes(network string) error {
 	// Check in scripts for nested references
 	for _, script := range a.Scripts {
 		extractNestedTypes(script.ReturnType)
+		for _, member := range script.ReturnUnion {
+			extractNestedTypes(member)
+		}
 	}
 
 	// Check in transactions for nested references
@@ -638,13 +852,20 @@ func (a *Analyzer) ResolveNestedTypes(network string) error {
 		}
 	}
 
-	fmt.Printf("Found nested types to resolve: %v\n", nestedTypes)
+	fmt.Fprintf(os.Stderr, "Found nested types to resolve: %v\n", nestedTypes)
 
 	// Fetch each contract and analyze only the used structures
 	for contractName, structNames := range nestedTypes {
 		if err := a.FetchContractFromChainSelective(contractName, network, structNames); err != nil {
-			fmt.Printf("Warning: failed to fetch contract %s: %v\n", contractName, err)
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch contract %s: %v\n", contractName, err)
+			reason := fmt.Sprintf("%s: %v", network, err)
+			if previous, ok := a.Unresolved[contractName]; ok {
+				reason = previous + "; " + reason
+			}
+			a.Unresolved[contractName] = reason
 			// Continue with other contracts even if one fails
+		} else {
+			delete(a.Unresolved, contractName)
 		}
 	}
 
@@ -678,54 +899,11 @@ func (a *Analyzer) FetchContractFromChainSelective(contractName string, network
 		return fmt.Errorf("contract %s not found in network %s", contractName, network)
 	}
 
-	// Remove 0x prefix if present
-	contractAddress = strings.TrimPrefix(contractAddress, "0x")
-
-	// Determine API endpoint based on network
-	var apiEndpoint string
-	switch network {
-	case "mainnet":
-		apiEndpoint = "https://rest-mainnet.onflow.org"
-	case "testnet":
-		apiEndpoint = "https://rest-testnet.onflow.org"
-	default:
-		return fmt.Errorf("unsupported network: %s", network)
-	}
-
-	// Fetch contract from chain
-	url := fmt.Sprintf("%s/v1/accounts/%s?expand=contracts", apiEndpoint, contractAddress)
-	resp, err := http.Get(url)
-	if err != nil {
-		return fmt.Errorf("failed to fetch contract: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	var accountData map[string]interface{}
-	if err := json.Unmarshal(body, &accountData); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	contracts, ok := accountData["contracts"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("no contracts found in response")
-	}
-
 	// Try to find the contract with the original name (without 0x prefix)
 	originalContractName := strings.TrimPrefix(contractName, "0x")
-	contractCode, ok := contracts[originalContractName].(string)
-	if !ok {
-		return fmt.Errorf("contract %s not found in response", originalContractName)
-	}
-
-	// Decode base64 contract code
-	decodedCode, err := base64.StdEncoding.DecodeString(contractCode)
+	decodedCode, err := a.contractCode(network, contractAddress, originalContractName)
 	if err != nil {
-		return fmt.Errorf("failed to decode contract code: %w", err)
+		return err
 	}
 
 	// Analyze the decoded contract code, but only for the specified structures
@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+)
+
+// cadenceStringEscaper escapes text for Cadence string literals
+var cadenceStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "\x00", `\0`)
+
+// deployedContract returns the name and initializer parameters of the
+// contract or contract interface declared by program, if any
+func deployedContract(program *ast.Program) (string, []Parameter, bool) {
+	for _, declaration := range program.Declarations() {
+		switch declaration := declaration.(type) {
+		case *ast.CompositeDeclaration:
+			if declaration.CompositeKind != common.CompositeKindContract {
+				continue
+			}
+			params := make([]Parameter, 0)
+			if initializers := declaration.Members.Initializers(); len(initializers) > 0 {
+				params = functionParameters(initializers[0].FunctionDeclaration)
+			}
+			return declaration.Identifier.String(), params, true
+		case *ast.InterfaceDeclaration:
+			if declaration.CompositeKind == common.CompositeKindContract {
+				return declaration.Identifier.String(), make([]Parameter, 0), true
+			}
+		}
+	}
+	return "", nil, false
+}
+
+// deploymentCode returns the code of a transaction adding (or updating) the
+// contract name with the given code to the signer account. The code is
+// embedded as a string literal, so that import aliases in it are replaced by
+// the client like in the rest of the transaction. The initializer arguments
+// of added contracts are transaction parameters.
+func deploymentCode(name string, code []byte, params []Parameter, update bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Generated by cadence-codegen from the %s contract\n", name)
+	if update {
+		b.WriteString("transaction {\n")
+		b.WriteString("    prepare(signer: auth(UpdateContract) &Account) {\n")
+		fmt.Fprintf(&b, "        signer.contracts.update(name: %q, code: \"%s\".utf8)\n", name, cadenceStringEscaper.Replace(string(code)))
+	} else {
+		var declared, passed []string
+		for _, param := range params {
+			declared = append(declared, param.Name+": "+param.TypeStr)
+			passed = append(passed, ", "+param.Name)
+		}
+		if len(declared) > 0 {
+			fmt.Fprintf(&b, "transaction(%s) {\n", strings.Join(declared, ", "))
+		} else {
+			b.WriteString("transaction {\n")
+		}
+		b.WriteString("    prepare(signer: auth(AddContract) &Account) {\n")
+		fmt.Fprintf(&b, "        signer.contracts.add(name: %q, code: \"%s\".utf8%s)\n", name, cadenceStringEscaper.Replace(string(code)), strings.Join(passed, ""))
+	}
+	b.WriteString("    }\n")
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// addDeployments adds the transactions deploying and updating the contract
+// declared in content, e.g. deploy_my_token.cdc and update_my_token.cdc for
+// MyToken, based on the result of the contract file. It returns the deploy
+// transaction, or false if content declares no contract.
+func (a *Analyzer) addDeployments(program *ast.Program, content []byte, base AnalysisResult) (*AnalysisResult, bool) {
+	name, params, ok := deployedContract(program)
+	if !ok {
+		return nil, false
+	}
+
+	var deploy AnalysisResult
+	for _, update := range []bool{false, true} {
+		result := base
+		result.Type = "transaction"
+		result.Imports = make([]Import, 0)
+		result.Paths = nil
+		result.Parameters = make([]Parameter, 0)
+		result.FileName = entryPointFileName("deploy.cdc", name)
+		result.Signers = []Parameter{{Name: "signer", TypeStr: "auth(AddContract) &Account"}}
+		if update {
+			result.FileName = entryPointFileName("update.cdc", name)
+			result.Signers = []Parameter{{Name: "signer", TypeStr: "auth(UpdateContract) &Account"}}
+		} else {
+			result.Parameters = params
+		}
+		if a.IncludeBase64 {
+			result.Base64 = base64.StdEncoding.EncodeToString([]byte(deploymentCode(name, content, params, update)))
+		}
+		a.Transactions[result.FileName] = result
+		if !update {
+			deploy = result
+		}
+	}
+	return &deploy, true
+}
+
+// SetDeployments sets whether transactions deploying and updating contracts
+// are generated for contract files
+func (a *Analyzer) SetDeployments(deployments bool) {
+	a.Deployments = deployments
+}
@@ -0,0 +1,20 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// addressLiteralPattern matches hexadecimal address literals
+var addressLiteralPattern = regexp.MustCompile(`\b0x[0-9a-fA-F]+\b`)
+
+// NormalizedHash returns the hex SHA-256 of code without comments, string
+// literals and address values and with whitespace collapsed, so that copies
+// differing only in formatting or hard-coded values hash the same
+func NormalizedHash(code []byte) string {
+	normalized := addressLiteralPattern.ReplaceAll(StripCommentsAndStrings(code), []byte("0x"))
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(string(normalized)), " ")))
+	return hex.EncodeToString(sum[:])
+}
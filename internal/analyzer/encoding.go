@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Byte order marks of the encodings editors commonly save Cadence files in
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// NormalizeSource converts Cadence source code into UTF-8 with LF line endings,
+// so that files authored on Windows analyze identically. UTF-16 files are
+// recognized by their byte order mark, a UTF-8 byte order mark is removed and
+// CRLF and CR line endings become LF.
+func NormalizeSource(content []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(content, bomUTF8):
+		content = content[len(bomUTF8):]
+	case bytes.HasPrefix(content, bomUTF16LE):
+		content = decodeUTF16(content[len(bomUTF16LE):], binary.LittleEndian)
+	case bytes.HasPrefix(content, bomUTF16BE):
+		content = decodeUTF16(content[len(bomUTF16BE):], binary.BigEndian)
+	}
+
+	if !utf8.Valid(content) {
+		return nil, fmt.Errorf("file is not valid UTF-8 or UTF-16")
+	}
+
+	if bytes.IndexByte(content, '\r') >= 0 {
+		content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+		content = bytes.ReplaceAll(content, []byte("\r"), []byte("\n"))
+	}
+	return content, nil
+}
+
+// decodeUTF16 decodes UTF-16 text into UTF-8
+func decodeUTF16(content []byte, order binary.ByteOrder) []byte {
+	units := make([]uint16, len(content)/2)
+	for i := range units {
+		units[i] = order.Uint16(content[2*i:])
+	}
+	return []byte(string(utf16.Decode(units)))
+}
@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+// encodeUTF16 encodes text as UTF-16 with a byte order mark
+func encodeUTF16(text string, bigEndian bool) []byte {
+	var out []byte
+	if bigEndian {
+		out = append(out, bomUTF16BE...)
+	} else {
+		out = append(out, bomUTF16LE...)
+	}
+	for _, unit := range utf16.Encode([]rune(text)) {
+		if bigEndian {
+			out = append(out, byte(unit>>8), byte(unit))
+		} else {
+			out = append(out, byte(unit), byte(unit>>8))
+		}
+	}
+	return out
+}
+
+func TestNormalizeSource(t *testing.T) {
+	const want = "access(all) fun main(): String {\n    return \"héllo ✓\"\n}\n"
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{"LF", []byte(want)},
+		{"UTF-8 BOM", append(append([]byte{}, bomUTF8...), want...)},
+		{"CRLF", bytes.ReplaceAll([]byte(want), []byte("\n"), []byte("\r\n"))},
+		{"lone CR", bytes.ReplaceAll([]byte(want), []byte("\n"), []byte("\r"))},
+		{"UTF-8 BOM and CRLF", append(append([]byte{}, bomUTF8...), bytes.ReplaceAll([]byte(want), []byte("\n"), []byte("\r\n"))...)},
+		{"UTF-16 LE", encodeUTF16(want, false)},
+		{"UTF-16 BE", encodeUTF16(want, true)},
+		{"UTF-16 LE and CRLF", encodeUTF16(string(bytes.ReplaceAll([]byte(want), []byte("\n"), []byte("\r\n"))), false)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NormalizeSource(test.content)
+			if err != nil {
+				t.Fatalf("NormalizeSource() error = %v", err)
+			}
+			if string(got) != want {
+				t.Errorf("NormalizeSource() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestNormalizeSourceInvalid(t *testing.T) {
+	if _, err := NormalizeSource([]byte{'a', 0xFF, 'b'}); err == nil {
+		t.Error("NormalizeSource() of invalid UTF-8 succeeded")
+	}
+}
+
+func TestAnalyzeSourceCRLFWithBOM(t *testing.T) {
+	const code = `import FungibleToken from 0xFungibleToken
+
+// codegen:title Send tokens
+transaction(amount: UFix64, to: Address) {
+    prepare(signer: &Account) {
+        log(amount)
+    }
+    execute {
+        log(to)
+    }
+}
+`
+	lf, err := New().AnalyzeSource("transactions/send.cdc", []byte(code))
+	if err != nil {
+		t.Fatalf("AnalyzeSource() of LF code error = %v", err)
+	}
+	crlf := append(append([]byte{}, bomUTF8...), bytes.ReplaceAll([]byte(code), []byte("\n"), []byte("\r\n"))...)
+	windows, err := New().AnalyzeSource("transactions/send.cdc", crlf)
+	if err != nil {
+		t.Fatalf("AnalyzeSource() of CRLF code with BOM error = %v", err)
+	}
+
+	// Base64 embeds the original bytes
+	lf.Base64 = ""
+	windows.Base64 = ""
+	if !reflect.DeepEqual(lf, windows) {
+		t.Errorf("AnalyzeSource() of CRLF code with BOM = %+v, want %+v", windows, lf)
+	}
+}
@@ -0,0 +1,189 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence/ast"
+)
+
+// renamedMainFunction is the name main is renamed to when // codegen:entry
+// selects another function
+const renamedMainFunction = "codegenOriginalMain"
+
+// argumentLabelNotRequired is the label of parameters called without a label
+const argumentLabelNotRequired = "_"
+
+// scriptEntryPoints returns the entry points of a script: main if the program
+// declares it, as other functions are its helpers, and otherwise every
+// function with an access modifier
+func scriptEntryPoints(program *ast.Program) []*ast.FunctionDeclaration {
+	var functions []*ast.FunctionDeclaration
+	for _, declaration := range program.Declarations() {
+		if function, ok := declaration.(*ast.FunctionDeclaration); ok {
+			if function.Identifier.String() == "main" {
+				return []*ast.FunctionDeclaration{function}
+			}
+			if function.Access != ast.AccessNotSpecified {
+				functions = append(functions, function)
+			}
+		}
+	}
+	return functions
+}
+
+// ScriptEntryPoints returns the entry points of a script with content parsed
+// as program, as they are generated: the function selected with
+// // codegen:entry, or else those of scriptEntryPoints
+func ScriptEntryPoints(content []byte, program *ast.Program) ([]*ast.FunctionDeclaration, error) {
+	name, ok := Pragma(content, "entry")
+	if !ok {
+		return scriptEntryPoints(program), nil
+	}
+	function := findFunction(program, name)
+	if function == nil {
+		return nil, fmt.Errorf("entry point %q selected by // codegen:entry not found", name)
+	}
+	return []*ast.FunctionDeclaration{function}, nil
+}
+
+// functionParameters returns the parameters of a function
+func functionParameters(function *ast.FunctionDeclaration) []Parameter {
+	params := make([]Parameter, 0)
+	if function.ParameterList != nil {
+		for _, param := range function.ParameterList.Parameters {
+			params = append(params, Parameter{
+				Name:     param.Identifier.String(),
+				TypeStr:  param.TypeAnnotation.String(),
+				Optional: false,
+			})
+		}
+	}
+	return params
+}
+
+// entryPointFileName derives the file name of an additional entry point from
+// the file name and the function name, e.g. balances.cdc and fetchAll become
+// balances_fetch_all.cdc
+func entryPointFileName(fileName string, functionName string) string {
+	var builder strings.Builder
+	for i, r := range functionName {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				builder.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		builder.WriteRune(r)
+	}
+	return strings.TrimSuffix(fileName, ".cdc") + "_" + builder.String() + ".cdc"
+}
+
+// findFunction returns the top-level function of a program with the given name
+func findFunction(program *ast.Program, name string) *ast.FunctionDeclaration {
+	for _, declaration := range program.Declarations() {
+		if function, ok := declaration.(*ast.FunctionDeclaration); ok && function.Identifier.String() == name {
+			return function
+		}
+	}
+	return nil
+}
+
+// lineOffset converts a parser position into a byte offset of content. Import
+// lines are blanked before parsing, so lines and columns match the original
+// content while offsets do not.
+func lineOffset(content []byte, pos ast.Position) int {
+	offset := 0
+	for line := 1; line < pos.Line; line++ {
+		next := bytes.IndexByte(content[offset:], '\n')
+		if next < 0 {
+			return len(content)
+		}
+		offset += next + 1
+	}
+	return offset + pos.Column
+}
+
+// entryPointCode returns the source code of a script executing function, by
+// appending a main function forwarding its arguments. An existing main
+// function is renamed to codegenOriginalMain.
+func entryPointCode(content []byte, program *ast.Program, function *ast.FunctionDeclaration) []byte {
+	if main := findFunction(program, "main"); main != nil {
+		offset := lineOffset(content, main.Identifier.Pos)
+		if bytes.HasPrefix(content[offset:], []byte("main")) {
+			renamed := append([]byte(nil), content[:offset]...)
+			renamed = append(renamed, renamedMainFunction...)
+			content = append(renamed, content[offset+len("main"):]...)
+		}
+	}
+	code := []byte(strings.TrimRight(string(content), "\n"))
+
+	var params, args []string
+	if function.ParameterList != nil {
+		for _, param := range function.ParameterList.Parameters {
+			name := param.Identifier.String()
+			params = append(params, fmt.Sprintf("%s: %s", name, param.TypeAnnotation.String()))
+			if label := param.EffectiveArgumentLabel(); label != argumentLabelNotRequired {
+				args = append(args, fmt.Sprintf("%s: %s", label, name))
+			} else {
+				args = append(args, name)
+			}
+		}
+	}
+
+	call := fmt.Sprintf("%s(%s)", function.Identifier.String(), strings.Join(args, ", "))
+	signature := fmt.Sprintf("access(all) fun main(%s)", strings.Join(params, ", "))
+	if function.ReturnTypeAnnotation != nil {
+		signature += ": " + function.ReturnTypeAnnotation.String()
+		call = "return " + call
+	}
+	return append(code, []byte(fmt.Sprintf("\n\n%s {\n    %s\n}\n", signature, call))...)
+}
+
+// CheckReturnType returns an error if a script returns a value that cannot be
+// returned over the Access API: resources, references and functions
+func CheckReturnType(function *ast.FunctionDeclaration) error {
+	annotation := function.ReturnTypeAnnotation
+	if annotation == nil {
+		return nil
+	}
+	if annotation.IsResource {
+		return fmt.Errorf("script function %s returns the resource type %s, which cannot be returned over the Access API",
+			function.Identifier.String(), annotation.String())
+	}
+	if kind := nonReturnableType(annotation.Type); kind != "" {
+		return fmt.Errorf("script function %s returns %s (%s), which cannot be returned over the Access API",
+			function.Identifier.String(), kind, annotation.String())
+	}
+	return nil
+}
+
+// nonReturnableType describes the first part of a type that cannot be
+// returned from a script, or returns an empty string if there is none.
+// Type arguments are not checked, as capabilities of references are returnable.
+func nonReturnableType(typ ast.Type) string {
+	switch t := typ.(type) {
+	case *ast.ReferenceType:
+		return "a reference"
+	case *ast.FunctionType:
+		return "a function"
+	case *ast.NominalType:
+		if t.Identifier.String() == "AnyResource" {
+			return "a resource"
+		}
+	case *ast.OptionalType:
+		return nonReturnableType(t.Type)
+	case *ast.VariableSizedType:
+		return nonReturnableType(t.Type)
+	case *ast.ConstantSizedType:
+		return nonReturnableType(t.Type)
+	case *ast.DictionaryType:
+		if kind := nonReturnableType(t.KeyType); kind != "" {
+			return kind
+		}
+		return nonReturnableType(t.ValueType)
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// typeNamePattern matches the possibly qualified type names of a type string
+var typeNamePattern = regexp.MustCompile(`[A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*`)
+
+// MatchPath reports whether the glob pattern matches the slash separated
+// filePath or one of its trailing parts, so that staking/* matches
+// cadence/scripts/staking/get_info.cdc
+func MatchPath(pattern string, filePath string) bool {
+	parts := strings.Split(filePath, "/")
+	for i := range parts {
+		if ok, _ := path.Match(pattern, strings.Join(parts[i:], "/")); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter keeps the scripts and transactions whose file path matches one of
+// the only patterns, if any, and that are tagged with none of skipTags. A
+// result is tagged with its tag and with every folder of its path relative to
+// the analyzed root, e.g. nft, compared case insensitively. The other results are removed with Retain.
+func (r *Report) Filter(only []string, skipTags []string) error {
+	if len(only) == 0 && len(skipTags) == 0 {
+		return nil
+	}
+	for _, pattern := range only {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	keep := func(name string, result AnalysisResult) bool {
+		folders := sourceFolders(result.SourcePath())
+		for _, tag := range skipTags {
+			if strings.EqualFold(result.Tag, tag) {
+				return false
+			}
+			for _, folder := range folders {
+				if strings.EqualFold(folder, tag) {
+					return false
+				}
+			}
+		}
+		if len(only) == 0 {
+			return true
+		}
+		for _, pattern := range only {
+			if MatchPath(pattern, result.SourcePath()) {
+				return true
+			}
+		}
+		return false
+	}
+	r.Retain(keep)
+	return nil
+}
+
+// sourceFolders returns the folders of the slash separated sourcePath,
+// relative to the analyzed root. Absolute paths of reports from older
+// versions have no folders, so that the folders of the checkout location never
+// match a tag.
+func sourceFolders(sourcePath string) []string {
+	dir := path.Dir(sourcePath)
+	if dir == "." || path.IsAbs(sourcePath) {
+		return nil
+	}
+	return strings.Split(dir, "/")
+}
+
+// Retain keeps the scripts and transactions for which keep returns true, by
+// file name. Structs no remaining script or transaction uses are pruned and
+// the manifest is dropped, since it no longer covers the code.
+func (r *Report) Retain(keep func(name string, result AnalysisResult) bool) {
+	for _, results := range []map[string]AnalysisResult{r.Scripts, r.Transactions} {
+		for name, result := range results {
+			if !keep(name, result) {
+				delete(results, name)
+			}
+		}
+	}
+
+	r.pruneStructs()
+	r.Manifest = nil
+}
+
+// pruneStructs removes the structs not reachable from the parameters, return
+// types and return unions of the scripts and transactions
+func (r *Report) pruneStructs() {
+	used := make(map[string]bool)
+	var visit func(typeStr string)
+	visit = func(typeStr string) {
+		for _, name := range typeNamePattern.FindAllString(typeStr, -1) {
+			name = strings.ReplaceAll(name, ".", "")
+			composite, ok := r.Structs[name]
+			if !ok || used[name] {
+				continue
+			}
+			used[name] = true
+			for _, field := range composite.Fields {
+				visit(field.TypeStr)
+			}
+		}
+	}
+	for _, results := range []map[string]AnalysisResult{r.Scripts, r.Transactions} {
+		for _, result := range results {
+			for _, param := range result.Parameters {
+				visit(param.TypeStr)
+			}
+			visit(result.ReturnType)
+			for _, member := range result.ReturnUnion {
+				visit(member)
+			}
+		}
+	}
+	for name := range r.Structs {
+		if !used[name] {
+			delete(r.Structs, name)
+		}
+	}
+}
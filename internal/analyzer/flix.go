@@ -0,0 +1,248 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FLIXType is the f_type of FLIX interaction templates
+const FLIXType = "InteractionTemplate"
+
+// flixTemplate is the part of a FLIX interaction template, in the 1.0.0 or
+// 1.1.0 format, that is mapped into the report
+type flixTemplate struct {
+	FType    string `json:"f_type"`
+	FVersion string `json:"f_version"`
+	Data     struct {
+		Type         string          `json:"type"`
+		Cadence      json.RawMessage `json:"cadence"`
+		Dependencies json.RawMessage `json:"dependencies"`
+		Messages     json.RawMessage `json:"messages"`
+	} `json:"data"`
+}
+
+// FLIXDefaultLocale is the locale of the messages of the report
+const FLIXDefaultLocale = "en-US"
+
+// flixDependency is a contract dependency of a FLIX 1.1.0 template
+type flixDependency struct {
+	Contracts []struct {
+		Contract string `json:"contract"`
+		Networks []struct {
+			Network string `json:"network"`
+			Address string `json:"address"`
+		} `json:"networks"`
+	} `json:"contracts"`
+}
+
+// flixStringImportPattern matches the string imports of FLIX 1.1.0 code,
+// e.g. import "FlowToken"
+var flixStringImportPattern = regexp.MustCompile(`(?m)^(\s*)import\s+"([A-Za-z_][A-Za-z0-9_]*)"\s*$`)
+
+// IsFLIX reports whether data is a FLIX interaction template
+func IsFLIX(data []byte) bool {
+	var template struct {
+		FType string `json:"f_type"`
+	}
+	return json.Unmarshal(data, &template) == nil && template.FType == FLIXType
+}
+
+// AnalyzeFLIXFile analyzes the Cadence code of a FLIX interaction template as
+// if it was read from a .cdc file of the same name. Imports are rewritten to
+// the 0x<Contract> placeholders of the report and the dependency addresses
+// are added to the addresses of the report.
+func (a *Analyzer) AnalyzeFLIXFile(filePath string) (*AnalysisResult, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	var template flixTemplate
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse FLIX template: %w", err)
+	}
+	if template.FType != FLIXType {
+		return nil, fmt.Errorf("not a FLIX interaction template")
+	}
+
+	var code string
+	switch template.FVersion {
+	case "1.0.0":
+		if err := json.Unmarshal(template.Data.Cadence, &code); err != nil {
+			return nil, fmt.Errorf("failed to parse FLIX cadence: %w", err)
+		}
+		var dependencies map[string]map[string]map[string]struct {
+			Address string `json:"address"`
+		}
+		if len(template.Data.Dependencies) > 0 {
+			if err := json.Unmarshal(template.Data.Dependencies, &dependencies); err != nil {
+				return nil, fmt.Errorf("failed to parse FLIX dependencies: %w", err)
+			}
+		}
+		for placeholder, contracts := range dependencies {
+			for contract, networks := range contracts {
+				code = regexp.MustCompile(`\bfrom\s+`+regexp.QuoteMeta(placeholder)+`\b`).ReplaceAllString(code, "from 0x"+contract)
+				for network, pin := range networks {
+					a.addFLIXAddress(network, contract, pin.Address)
+				}
+			}
+		}
+	case "1.1.0":
+		var cadence struct {
+			Body string `json:"body"`
+		}
+		if err := json.Unmarshal(template.Data.Cadence, &cadence); err != nil {
+			return nil, fmt.Errorf("failed to parse FLIX cadence: %w", err)
+		}
+		code = flixStringImportPattern.ReplaceAllString(cadence.Body, "${1}import $2 from 0x$2")
+		var dependencies []flixDependency
+		if len(template.Data.Dependencies) > 0 {
+			if err := json.Unmarshal(template.Data.Dependencies, &dependencies); err != nil {
+				return nil, fmt.Errorf("failed to parse FLIX dependencies: %w", err)
+			}
+		}
+		for _, dependency := range dependencies {
+			for _, contract := range dependency.Contracts {
+				for _, network := range contract.Networks {
+					a.addFLIXAddress(network.Network, contract.Contract, network.Address)
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported FLIX version %q", template.FVersion)
+	}
+
+	codePath := strings.TrimSuffix(filePath, filepath.Ext(filePath)) + ".cdc"
+	result, err := a.AnalyzeSource(codePath, []byte(code))
+	if err != nil {
+		return nil, err
+	}
+	if result.Type != template.Data.Type {
+		return nil, fmt.Errorf("FLIX template of type %s contains a %s", template.Data.Type, result.Type)
+	}
+
+	// The results point to the template rather than the .cdc file
+	codeFilePath := result.FilePath
+	templateFilePath := strings.TrimSuffix(codeFilePath, ".cdc") + filepath.Ext(filePath)
+	for _, results := range []map[string]AnalysisResult{a.Transactions, a.Scripts} {
+		for name, r := range results {
+			if r.FilePath == codeFilePath {
+				r.FilePath = templateFilePath
+				results[name] = r
+			}
+		}
+	}
+	result.FilePath = templateFilePath
+
+	// Message pragmas of the code take precedence over template messages in
+	// every locale
+	translations, err := template.messages()
+	if err != nil {
+		return nil, err
+	}
+	// Copied, so that the keys of the pragmas stay apart from the defaults
+	pragmaMessages := result.Messages
+	var messages map[string]string
+	for _, key := range MessageKeys {
+		message := pragmaMessages[key]
+		if message == "" {
+			message = translations[key][FLIXDefaultLocale]
+		}
+		if message != "" {
+			if messages == nil {
+				messages = make(map[string]string)
+			}
+			messages[key] = message
+		}
+	}
+	var other map[string]map[string]string
+	for _, key := range MessageKeys {
+		if pragmaMessages[key] != "" {
+			continue
+		}
+		for locale, message := range translations[key] {
+			if locale == FLIXDefaultLocale || message == "" {
+				continue
+			}
+			if other == nil {
+				other = make(map[string]map[string]string)
+			}
+			if other[locale] == nil {
+				other[locale] = make(map[string]string)
+			}
+			other[locale][key] = message
+		}
+	}
+	for _, results := range []map[string]AnalysisResult{a.Transactions, a.Scripts} {
+		for name, r := range results {
+			if r.FilePath == templateFilePath {
+				r.Messages = messages
+				r.Translations = other
+				results[name] = r
+			}
+		}
+	}
+	result.Messages = messages
+	result.Translations = other
+	return result, nil
+}
+
+// messages returns the translations of the template messages by key and
+// locale, e.g. title -> en-US -> Transfer Tokens
+func (t flixTemplate) messages() (map[string]map[string]string, error) {
+	translations := make(map[string]map[string]string)
+	if len(t.Data.Messages) == 0 {
+		return translations, nil
+	}
+	switch t.FVersion {
+	case "1.0.0":
+		var messages map[string]struct {
+			I18n map[string]string `json:"i18n"`
+		}
+		if err := json.Unmarshal(t.Data.Messages, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse FLIX messages: %w", err)
+		}
+		for key, message := range messages {
+			translations[key] = message.I18n
+		}
+	default:
+		var messages []struct {
+			Key  string `json:"key"`
+			I18n []struct {
+				Tag         string `json:"tag"`
+				Translation string `json:"translation"`
+			} `json:"i18n"`
+		}
+		if err := json.Unmarshal(t.Data.Messages, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse FLIX messages: %w", err)
+		}
+		for _, message := range messages {
+			translations[message.Key] = make(map[string]string)
+			for _, translation := range message.I18n {
+				translations[message.Key][translation.Tag] = translation.Translation
+			}
+		}
+	}
+	return translations, nil
+}
+
+// addFLIXAddress adds the address of a contract on a network pinned by a
+// FLIX template
+func (a *Analyzer) addFLIXAddress(network string, contract string, address string) {
+	if network == "" || address == "" {
+		return
+	}
+	if a.FLIXAddresses == nil {
+		a.FLIXAddresses = make(map[string]map[string]string)
+	}
+	if a.FLIXAddresses[network] == nil {
+		a.FLIXAddresses[network] = make(map[string]string)
+	}
+	if !strings.HasPrefix(address, "0x") {
+		address = "0x" + address
+	}
+	a.FLIXAddresses[network]["0x"+contract] = address
+}
@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// localImportPattern matches the imports of a local .cdc file by address or
+// path, e.g. import FlowToken from 0x1654653399040a61
+var localImportPattern = regexp.MustCompile(`(?m)^([ \t]*)import[ \t]+([A-Za-z_][A-Za-z0-9_]*)[ \t]+from[ \t]+\S+[ \t]*$`)
+
+// localStringImportPattern matches the imports by contract name of a local
+// .cdc file, e.g. import "FlowToken", without the surrounding lines
+var localStringImportPattern = regexp.MustCompile(`(?m)^([ \t]*)import[ \t]+"([A-Za-z_][A-Za-z0-9_]*)"[ \t]*$`)
+
+// FLIXCadence returns the Cadence code of a FLIX interaction template as
+// published, with the imports of its version, e.g. import "FlowToken" in
+// 1.1.0 templates
+func FLIXCadence(data []byte) (string, error) {
+	var template flixTemplate
+	if err := json.Unmarshal(data, &template); err != nil {
+		return "", fmt.Errorf("failed to parse FLIX template: %w", err)
+	}
+	if template.FType != FLIXType {
+		return "", fmt.Errorf("not a FLIX interaction template")
+	}
+	switch template.FVersion {
+	case "1.0.0":
+		var code string
+		if err := json.Unmarshal(template.Data.Cadence, &code); err != nil {
+			return "", fmt.Errorf("failed to parse FLIX cadence: %w", err)
+		}
+		return code, nil
+	case "1.1.0":
+		var cadence struct {
+			Body string `json:"body"`
+		}
+		if err := json.Unmarshal(template.Data.Cadence, &cadence); err != nil {
+			return "", fmt.Errorf("failed to parse FLIX cadence: %w", err)
+		}
+		return cadence.Body, nil
+	default:
+		return "", fmt.Errorf("unsupported FLIX version %q", template.FVersion)
+	}
+}
+
+// FLIXSource rewrites the imports of a local .cdc source the way the FLIX
+// template data publishes them: contract names in 1.1.0 templates and the
+// address placeholders of the dependencies in 1.0.0 templates
+func FLIXSource(data []byte, source []byte) (string, error) {
+	var template flixTemplate
+	if err := json.Unmarshal(data, &template); err != nil {
+		return "", fmt.Errorf("failed to parse FLIX template: %w", err)
+	}
+	source, err := NormalizeSource(source)
+	if err != nil {
+		return "", err
+	}
+	code := localStringImportPattern.ReplaceAllString(string(source), "${1}import $2 from 0x$2")
+
+	switch template.FVersion {
+	case "1.0.0":
+		var dependencies map[string]map[string]json.RawMessage
+		if len(template.Data.Dependencies) > 0 {
+			if err := json.Unmarshal(template.Data.Dependencies, &dependencies); err != nil {
+				return "", fmt.Errorf("failed to parse FLIX dependencies: %w", err)
+			}
+		}
+		placeholders := make(map[string]string)
+		for placeholder, contracts := range dependencies {
+			for contract := range contracts {
+				placeholders[contract] = placeholder
+			}
+		}
+		return localImportPattern.ReplaceAllStringFunc(code, func(line string) string {
+			match := localImportPattern.FindStringSubmatch(line)
+			if placeholder, ok := placeholders[match[2]]; ok {
+				return match[1] + "import " + match[2] + " from " + placeholder
+			}
+			return line
+		}), nil
+	case "1.1.0":
+		return flixBody(code), nil
+	default:
+		return "", fmt.Errorf("unsupported FLIX version %q", template.FVersion)
+	}
+}
+
+// FLIXBody rewrites the imports of a local .cdc source the way FLIX 1.1.0
+// templates publish them, by contract name, e.g. import "FlowToken"
+func FLIXBody(source []byte) (string, error) {
+	source, err := NormalizeSource(source)
+	if err != nil {
+		return "", err
+	}
+	return flixBody(string(source)), nil
+}
+
+// flixBody rewrites the imports by address or path of normalized code to
+// imports by contract name
+func flixBody(code string) string {
+	return localImportPattern.ReplaceAllString(code, `${1}import "$2"`)
+}
+
+// CadenceHash returns the hex encoded SHA3-256 of Cadence code, the hash FLIX
+// uses for code, without surrounding whitespace so that the trailing newline
+// of a local file is no change
+func CadenceHash(code string) string {
+	sum := sha3.Sum256([]byte(strings.TrimSpace(code)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,45 @@
+package analyzer
+
+import "testing"
+
+func TestFLIXSourceHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		source   string
+	}{
+		{
+			"1.1.0",
+			`{"f_type":"InteractionTemplate","f_version":"1.1.0","data":{"type":"script","cadence":{"body":"import \"FungibleToken\"\n\naccess(all) fun main(): Int {\n    return 1\n}"}}}`,
+			"import FungibleToken from 0xf233dcee88fe0abe\r\n\r\naccess(all) fun main(): Int {\r\n    return 1\r\n}\r\n",
+		},
+		{
+			"1.0.0",
+			`{"f_type":"InteractionTemplate","f_version":"1.0.0","data":{"type":"script","cadence":"import FungibleToken from 0xFUNGIBLETOKENADDRESS\n\npub fun main(): Int { return 1 }\n","dependencies":{"0xFUNGIBLETOKENADDRESS":{"FungibleToken":{"mainnet":{"address":"0xf233dcee88fe0abe"}}}}}}`,
+			"import \"FungibleToken\"\n\npub fun main(): Int { return 1 }\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			published, err := FLIXCadence([]byte(test.template))
+			if err != nil {
+				t.Fatalf("FLIXCadence() error = %v", err)
+			}
+			local, err := FLIXSource([]byte(test.template), []byte(test.source))
+			if err != nil {
+				t.Fatalf("FLIXSource() error = %v", err)
+			}
+			if CadenceHash(local) != CadenceHash(published) {
+				t.Errorf("FLIXSource() = %q, want the code of %q", local, published)
+			}
+
+			changed, err := FLIXSource([]byte(test.template), []byte(test.source+"// changed\n"))
+			if err != nil {
+				t.Fatalf("FLIXSource() error = %v", err)
+			}
+			if CadenceHash(changed) == CadenceHash(published) {
+				t.Error("CadenceHash() of changed source matches the published code")
+			}
+		})
+	}
+}
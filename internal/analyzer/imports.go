@@ -0,0 +1,215 @@
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// builtinContainers are built-in types and contracts whose members are
+// accessed without an import, e.g. Account.Storage or HashAlgorithm.SHA3_256
+var builtinContainers = map[string]bool{
+	"Account": true, "Address": true, "AccountCapabilityController": true, "BLS": true,
+	"Block": true, "Capability": true, "CapabilityPath": true, "Character": true,
+	"Crypto": true, "DeploymentResult": true, "HashAlgorithm": true, "InclusiveRange": true,
+	"Path": true, "PrivatePath": true, "PublicKey": true, "PublicPath": true, "RLP": true,
+	"Self": true, "SignatureAlgorithm": true, "StorageCapabilityController": true,
+	"StoragePath": true, "String": true, "Type": true,
+	"Int": true, "Int8": true, "Int16": true, "Int32": true, "Int64": true, "Int128": true, "Int256": true,
+	"UInt": true, "UInt8": true, "UInt16": true, "UInt32": true, "UInt64": true, "UInt128": true, "UInt256": true,
+	"Word8": true, "Word16": true, "Word32": true, "Word64": true, "Word128": true, "Word256": true,
+	"Fix64": true, "UFix64": true, "Fix128": true, "UFix128": true,
+}
+
+// importPattern matches import declarations: import A, import "A" and import A, B from 0x1
+var importPattern = regexp.MustCompile(`^import\s+(.+?)(?:\s+from\s+\S+)?$`)
+
+// containerPattern matches capitalized identifiers whose members are accessed
+var containerPattern = regexp.MustCompile(`(^|[^.\w])([A-Z]\w*)\.`)
+
+// ImportedContracts returns the names of the contracts imported by source
+// code, in all import forms
+func ImportedContracts(content []byte) []string {
+	var contracts []string
+	for _, line := range strings.Split(string(content), "\n") {
+		match := importPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		for _, name := range strings.Split(match[1], ",") {
+			if name = strings.Trim(strings.TrimSpace(name), `"`); name != "" {
+				contracts = append(contracts, name)
+			}
+		}
+	}
+	return contracts
+}
+
+// StripCommentsAndStrings blanks comments and string literals of code with
+// spaces, keeping newlines so that offsets and positions are unchanged
+func StripCommentsAndStrings(code []byte) []byte {
+	stripped := append([]byte(nil), code...)
+	blank := func(i int) {
+		if stripped[i] != '\n' {
+			stripped[i] = ' '
+		}
+	}
+	for i := 0; i < len(stripped); i++ {
+		switch {
+		case stripped[i] == '/' && i+1 < len(stripped) && stripped[i+1] == '/':
+			for ; i < len(stripped) && stripped[i] != '\n'; i++ {
+				blank(i)
+			}
+		case stripped[i] == '/' && i+1 < len(stripped) && stripped[i+1] == '*':
+			// Block comments nest in Cadence
+			depth := 0
+			for ; i < len(stripped); i++ {
+				if stripped[i] == '/' && i+1 < len(stripped) && stripped[i+1] == '*' {
+					depth++
+					blank(i)
+					i++
+				} else if stripped[i] == '*' && i+1 < len(stripped) && stripped[i+1] == '/' {
+					depth--
+					blank(i)
+					i++
+				}
+				blank(i)
+				if depth == 0 {
+					break
+				}
+			}
+		case stripped[i] == '"':
+			blank(i)
+			for i++; i < len(stripped) && stripped[i] != '"' && stripped[i] != '\n'; i++ {
+				if stripped[i] == '\\' && i+1 < len(stripped) {
+					blank(i)
+					i++
+				}
+				blank(i)
+			}
+			if i < len(stripped) {
+				blank(i)
+			}
+		}
+	}
+	return stripped
+}
+
+// References reports whether code, without comments and strings, references
+// an identifier
+func References(code []byte, identifier string) bool {
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(identifier) + `\b`)
+	return pattern.Match(StripCommentsAndStrings(code))
+}
+
+// ContainerReference is a capitalized identifier whose members are accessed,
+// such as the contract of FlowToken.Vault
+type ContainerReference struct {
+	Name   string
+	Offset int // Byte offset of the first reference
+}
+
+// ReferencedContainers returns the capitalized identifiers of code, without
+// comments and strings, whose members are accessed, excluding built-in types.
+// They are contracts if they are neither imported nor declared in the code.
+func ReferencedContainers(code []byte) []ContainerReference {
+	seen := make(map[string]bool)
+	var references []ContainerReference
+	for _, match := range containerPattern.FindAllSubmatchIndex(StripCommentsAndStrings(code), -1) {
+		name := string(code[match[4]:match[5]])
+		if builtinContainers[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+		references = append(references, ContainerReference{Name: name, Offset: match[4]})
+	}
+	return references
+}
+
+// UnusedImports returns the contracts imported by source code but never
+// referenced by it
+func UnusedImports(content []byte) []string {
+	_, code := ExtractImports(content)
+	var unused []string
+	for _, contract := range ImportedContracts(content) {
+		if !References(code, contract) {
+			unused = append(unused, contract)
+		}
+	}
+	return unused
+}
+
+// RemoveImports removes the imports of the given contracts from source code.
+// Import lines naming several contracts keep the remaining ones.
+func RemoveImports(content []byte, contracts []string) []byte {
+	if len(contracts) == 0 {
+		return content
+	}
+	remove := make(map[string]bool)
+	for _, contract := range contracts {
+		remove[contract] = true
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		match := importPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			lines = append(lines, line)
+			continue
+		}
+		var kept []string
+		for _, name := range strings.Split(match[1], ",") {
+			if !remove[strings.Trim(strings.TrimSpace(name), `"`)] {
+				kept = append(kept, strings.TrimSpace(name))
+			}
+		}
+		switch {
+		case len(kept) == 0:
+			continue
+		case len(kept) < len(strings.Split(match[1], ",")):
+			line = strings.Replace(line, match[1], strings.Join(kept, ", "), 1)
+		}
+		lines = append(lines, line)
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// ScriptsImport reports whether a script of the report imports contract
+func (r Report) ScriptsImport(contract string) bool {
+	for _, script := range r.Scripts {
+		for _, imp := range script.Imports {
+			if strings.Trim(imp.Contract, `"`) == contract {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ContractAddresses returns the contract addresses of the report by network
+// and 0x prefixed import alias, e.g. 0xFungibleToken. Aliases listed with the
+// prefix win over bare contract names.
+func (r Report) ContractAddresses() map[string]map[string]string {
+	networks := make(map[string]map[string]string)
+	for network, value := range r.Addresses {
+		entries, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		addresses := make(map[string]string)
+		for alias, entry := range entries {
+			address, ok := entry.(string)
+			if !ok {
+				continue
+			}
+			if !strings.HasPrefix(alias, "0x") {
+				if _, ok := entries["0x"+alias]; ok {
+					continue
+				}
+				alias = "0x" + alias
+			}
+			addresses[alias] = address
+		}
+		networks[network] = addresses
+	}
+	return networks
+}
@@ -0,0 +1,134 @@
+package analyzer
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Manifest is the signed list of the Cadence code hashes of a report, so
+// generated clients can verify that their embedded code is unchanged
+type Manifest struct {
+	Hashes    map[string]string `json:"hashes"`    // Key of each script and transaction -> ManifestHash of its code
+	PublicKey string            `json:"publicKey"` // Base64 raw Ed25519 public key
+	Signature string            `json:"signature"` // Base64 Ed25519 signature of the payload
+}
+
+// ManifestKey returns the manifest key of a script or transaction, e.g.
+// scripts/get_balance.cdc
+func ManifestKey(kind string, fileName string) string {
+	return kind + "/" + fileName
+}
+
+// ManifestHash returns the hex SHA-256 of code without surrounding
+// whitespace, which generated clients hash at runtime
+func ManifestHash(code string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Keys returns the keys of the manifest in the order of the payload
+func (m Manifest) Keys() []string {
+	keys := make([]string, 0, len(m.Hashes))
+	for key := range m.Hashes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Payload returns the signed text, a "<key> <hash>" line per entry sorted by key
+func (m Manifest) Payload() []byte {
+	var b strings.Builder
+	for _, key := range m.Keys() {
+		b.WriteString(key + " " + m.Hashes[key] + "\n")
+	}
+	return []byte(b.String())
+}
+
+// manifestHashes returns the hashes of the code of every script and
+// transaction of the report
+func (r Report) manifestHashes() (map[string]string, error) {
+	hashes := make(map[string]string)
+	for kind, results := range map[string]map[string]AnalysisResult{"scripts": r.Scripts, "transactions": r.Transactions} {
+		for name, result := range results {
+			code, err := base64.StdEncoding.DecodeString(result.Base64)
+			if err != nil || result.Base64 == "" {
+				return nil, fmt.Errorf("%s has no base64 encoded code", name)
+			}
+			hashes[ManifestKey(kind, name)] = ManifestHash(string(code))
+		}
+	}
+	return hashes, nil
+}
+
+// Sign sets the manifest of the report, signed with key
+func (r *Report) Sign(key ed25519.PrivateKey) error {
+	hashes, err := r.manifestHashes()
+	if err != nil {
+		return fmt.Errorf("failed to hash report code: %w", err)
+	}
+	manifest := &Manifest{
+		Hashes:    hashes,
+		PublicKey: base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+	}
+	manifest.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, manifest.Payload()))
+	r.Manifest = manifest
+	return nil
+}
+
+// VerifyManifest checks that the manifest signature is valid and that every
+// entry of the manifest exists in the report with unchanged code. Entries
+// added to the report after signing are not covered by the manifest.
+func (r Report) VerifyManifest() error {
+	if r.Manifest == nil {
+		return fmt.Errorf("report has no manifest")
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(r.Manifest.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid manifest public key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(r.Manifest.Signature)
+	if err != nil || !ed25519.Verify(publicKey, r.Manifest.Payload(), signature) {
+		return fmt.Errorf("invalid manifest signature")
+	}
+
+	hashes, err := r.manifestHashes()
+	if err != nil {
+		return err
+	}
+	for _, key := range r.Manifest.Keys() {
+		hash, ok := hashes[key]
+		if !ok {
+			return fmt.Errorf("%s of the manifest is missing from the report", key)
+		}
+		if hash != r.Manifest.Hashes[key] {
+			return fmt.Errorf("code of %s does not match the manifest", key)
+		}
+	}
+	return nil
+}
+
+// ParseSigningKey parses a PEM encoded PKCS #8 Ed25519 private key, e.g.
+// generated with openssl genpkey -algorithm ed25519
+func ParseSigningKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded key found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key: %w", err)
+	}
+	ed25519Key, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key is not an Ed25519 private key")
+	}
+	return ed25519Key, nil
+}
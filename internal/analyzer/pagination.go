@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pagination names the offset and limit parameters of a script returning one
+// page of an array
+type Pagination struct {
+	Offset string `json:"offset"`
+	Limit  string `json:"limit"`
+}
+
+// paginationTypes are the integer types of offset and limit parameters
+var paginationTypes = map[string]bool{
+	"Int": true, "Int8": true, "Int16": true, "Int32": true, "Int64": true,
+	"UInt": true, "UInt8": true, "UInt16": true, "UInt32": true, "UInt64": true,
+}
+
+// detectPagination returns the pagination of a script returning an array. The
+// // codegen:paginate <offset> <limit> pragma names the parameters, otherwise
+// integer parameters named offset and limit are used. It returns nil for
+// scripts without pagination and an error if the pragma does not match the
+// script.
+func detectPagination(script AnalysisResult, pragma string, annotated bool) (*Pagination, error) {
+	pagination := &Pagination{Offset: "offset", Limit: "limit"}
+	if annotated && pragma != "" {
+		names := strings.Fields(pragma)
+		if len(names) != 2 {
+			return nil, fmt.Errorf("// codegen:paginate expects an offset and a limit parameter, got %q", pragma)
+		}
+		pagination.Offset, pagination.Limit = names[0], names[1]
+	}
+
+	paged := strings.HasPrefix(script.ReturnType, "[") && strings.HasSuffix(script.ReturnType, "]")
+	for _, name := range []string{pagination.Offset, pagination.Limit} {
+		found := false
+		for _, param := range script.Parameters {
+			if param.Name == name && paginationTypes[param.TypeStr] {
+				found = true
+			}
+		}
+		paged = paged && found
+	}
+
+	switch {
+	case paged:
+		return pagination, nil
+	case annotated:
+		return nil, fmt.Errorf("// codegen:paginate needs an array return type and integer %s and %s parameters", pagination.Offset, pagination.Limit)
+	}
+	return nil, nil
+}
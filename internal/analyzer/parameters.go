@@ -0,0 +1,37 @@
+package analyzer
+
+import "github.com/onflow/cadence/ast"
+
+// UnusedParameters returns the names of the parameters of a transaction that
+// are never referenced by its body, i.e. its fields, prepare, pre, execute and
+// post blocks. Only identifier expressions count as references, not argument
+// labels or member names of the same name.
+func UnusedParameters(transaction *ast.TransactionDeclaration) []string {
+	if transaction.ParameterList == nil || len(transaction.ParameterList.Parameters) == 0 {
+		return nil
+	}
+
+	referenced := make(map[string]bool)
+	collect := func(element ast.Element) bool {
+		if identifier, ok := element.(*ast.IdentifierExpression); ok {
+			referenced[identifier.Identifier.Identifier] = true
+		}
+		return true
+	}
+	ast.Inspect(transaction, collect)
+	// The walk of a transaction skips its pre and post conditions
+	for _, conditions := range []*ast.Conditions{transaction.PreConditions, transaction.PostConditions} {
+		conditions.Walk(func(element ast.Element) {
+			ast.Inspect(element, collect)
+		})
+	}
+
+	var unused []string
+	for _, param := range transaction.ParameterList.Parameters {
+		name := param.Identifier.String()
+		if !referenced[name] {
+			unused = append(unused, name)
+		}
+	}
+	return unused
+}
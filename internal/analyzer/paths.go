@@ -0,0 +1,36 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+)
+
+// Path is a storage or public path literal used by Cadence code
+type Path struct {
+	Domain     string `json:"domain"`
+	Identifier string `json:"identifier"`
+}
+
+// String returns the path literal, e.g. /public/flowTokenBalance
+func (p Path) String() string {
+	return "/" + p.Domain + "/" + p.Identifier
+}
+
+// pathPattern matches path literals, but not divisions of identifiers
+var pathPattern = regexp.MustCompile(`(^|[^\w)\]])/(storage|public)/([A-Za-z_]\w*)`)
+
+// DetectPaths returns the storage and public path literals of code, outside
+// of comments and strings, sorted and without duplicates
+func DetectPaths(code []byte) []Path {
+	seen := make(map[Path]bool)
+	var paths []Path
+	for _, match := range pathPattern.FindAllSubmatch(StripCommentsAndStrings(code), -1) {
+		path := Path{Domain: string(match[2]), Identifier: string(match[3])}
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool { return paths[i].String() < paths[j].String() })
+	return paths
+}
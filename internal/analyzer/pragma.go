@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrSkipped is returned for files opting out of code generation with the
+// // codegen:skip pragma
+var ErrSkipped = errors.New("skipped by // codegen:skip")
+
+// defaultDeprecationMessage is used for // codegen:deprecated pragmas without a message
+const defaultDeprecationMessage = "This interaction is deprecated"
+
+// MessageKeys are the pragmas holding user-facing messages of an interaction,
+// e.g. // codegen:title Transfer FLOW
+var MessageKeys = []string{"title", "description"}
+
+// pragmaPattern matches // codegen:<name> [argument] comments
+var pragmaPattern = regexp.MustCompile(`^//\s*codegen:([a-zA-Z-]+)\s*(.*)$`)
+
+// Pragma returns the argument of the // codegen:<name> pragma of source code
+// and whether it is present
+func Pragma(content []byte, name string) (string, bool) {
+	argument, ok := parsePragmas(content)[name]
+	return argument, ok
+}
+
+// parsePragmas returns the // codegen: pragmas of source code by name
+func parsePragmas(content []byte) map[string]string {
+	pragmas := make(map[string]string)
+	for _, line := range strings.Split(string(content), "\n") {
+		if match := pragmaPattern.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			pragmas[match[1]] = strings.TrimSpace(match[2])
+		}
+	}
+	return pragmas
+}
+
+// messagePragmas returns the non-empty message pragmas of pragmas by key, or
+// nil if there are none
+func messagePragmas(pragmas map[string]string) map[string]string {
+	var messages map[string]string
+	for _, key := range MessageKeys {
+		if message := pragmas[key]; message != "" {
+			if messages == nil {
+				messages = make(map[string]string)
+			}
+			messages[key] = message
+		}
+	}
+	return messages
+}
@@ -0,0 +1,31 @@
+package analyzer
+
+import (
+	"encoding/base64"
+)
+
+// Version is the version of cadence-codegen recorded in reports
+var Version = "dev"
+
+// SourcePath returns the path of the originating .cdc file, or its name for
+// reports without paths
+func (r AnalysisResult) SourcePath() string {
+	if r.FilePath != "" {
+		return r.FilePath
+	}
+	return r.FileName
+}
+
+// CodeHash returns the ManifestHash of the embedded Cadence code, as generated
+// clients embed and check it, or an empty string if the report has no base64
+// code
+func (r AnalysisResult) CodeHash() string {
+	if r.Base64 == "" {
+		return ""
+	}
+	code, err := base64.StdEncoding.DecodeString(r.Base64)
+	if err != nil {
+		return ""
+	}
+	return ManifestHash(string(code))
+}
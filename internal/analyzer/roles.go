@@ -0,0 +1,84 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Signer roles of a transaction besides authorizing it, which every signer does
+const (
+	RoleProposer = "proposer"
+	RolePayer    = "payer"
+)
+
+// roleOrder lists the signer roles in the order of the transaction envelope
+var roleOrder = []string{RoleProposer, RolePayer}
+
+// detectRoles returns the signer holding each role of a transaction by role.
+// The // codegen:roles payer=<signer> proposer=<signer> pragma assigns them,
+// otherwise signers named after a role hold it. It returns nil if no signer
+// holds a role and an error if the pragma names an unknown role or signer.
+func detectRoles(signers []Parameter, pragma string, annotated bool) (map[string]string, error) {
+	names := make(map[string]bool)
+	for _, signer := range signers {
+		names[signer.Name] = true
+	}
+
+	var roles map[string]string
+	set := func(role string, signer string) {
+		if roles == nil {
+			roles = make(map[string]string)
+		}
+		roles[role] = signer
+	}
+	if annotated {
+		for _, assignment := range strings.Fields(pragma) {
+			role, signer, ok := strings.Cut(assignment, "=")
+			if !ok || (role != RoleProposer && role != RolePayer) {
+				return nil, fmt.Errorf("// codegen:roles expects payer=<signer> or proposer=<signer>, got %q", assignment)
+			}
+			if !names[signer] {
+				return nil, fmt.Errorf("// codegen:roles names %s, which is not a prepare parameter", signer)
+			}
+			set(role, signer)
+		}
+		return roles, nil
+	}
+
+	for _, signer := range signers {
+		for _, role := range roleOrder {
+			if strings.EqualFold(signer.Name, role) {
+				set(role, signer.Name)
+			}
+		}
+	}
+	return roles, nil
+}
+
+// SignerRoles returns the roles held by the signer of the transaction, in
+// envelope order
+func (r AnalysisResult) SignerRoles(signer string) []string {
+	var roles []string
+	for _, role := range roleOrder {
+		if r.Roles[role] == signer {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// SignerLabel returns the name of the signer suffixed with the roles it holds
+// other than the one it is named after, e.g. adminAsPayer or
+// adminAsProposerAndPayer, naming generated signer parameters
+func (r AnalysisResult) SignerLabel(signer string) string {
+	var roles []string
+	for _, role := range r.SignerRoles(signer) {
+		if !strings.EqualFold(signer, role) {
+			roles = append(roles, strings.ToUpper(role[:1])+role[1:])
+		}
+	}
+	if len(roles) == 0 {
+		return signer
+	}
+	return signer + "As" + strings.Join(roles, "And")
+}
@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Setup marks a transaction preparing the account of its signer, e.g. by
+// creating a collection or a vault
+type Setup struct {
+	Check string `json:"check,omitempty"` // File name of the script returning whether the account is already set up
+}
+
+// AccountSetup is a setup transaction run by account setup helpers
+type AccountSetup struct {
+	Transaction string // File name of the setup transaction
+	Check       string // File name of its check script, if any
+	CheckParam  string // Name of the address parameter of the check script
+}
+
+// createEmptyPattern matches the creation of empty collections and vaults,
+// e.g. createEmptyCollection(
+var createEmptyPattern = regexp.MustCompile(`\bcreateEmpty\w*\s*\(`)
+
+// detectSetup returns the setup of a transaction. The // codegen:setup [check]
+// pragma marks setups, optionally naming a script that takes the address and
+// returns whether the account is already set up. Otherwise transactions
+// without parameters saving an empty collection or vault are setups. It
+// returns nil for other transactions and an error if the pragma marks a
+// transaction that cannot run unattended.
+func detectSetup(transaction AnalysisResult, code []byte, pragma string, annotated bool) (*Setup, error) {
+	unattended := len(transaction.Parameters) == 0 && len(transaction.Signers) == 1
+	if annotated {
+		if !unattended {
+			return nil, fmt.Errorf("// codegen:setup needs a transaction without parameters and a single signer")
+		}
+		setup := &Setup{}
+		if pragma != "" {
+			setup.Check = strings.TrimSuffix(pragma, ".cdc") + ".cdc"
+		}
+		return setup, nil
+	}
+
+	stripped := StripCommentsAndStrings(code)
+	if unattended && createEmptyPattern.Match(stripped) && bytes.Contains(stripped, []byte(".save(")) {
+		return &Setup{}, nil
+	}
+	return nil, nil
+}
+
+// AccountSetups returns the setup transactions of the report in the order
+// they run, by file path. It returns an error if a check script does not
+// exist or does not take a single address and return a Bool.
+func (r Report) AccountSetups() ([]AccountSetup, error) {
+	var names []string
+	for name, transaction := range r.Transactions {
+		if transaction.Setup != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		first, second := r.Transactions[names[i]].SourcePath(), r.Transactions[names[j]].SourcePath()
+		if first != second {
+			return first < second
+		}
+		return names[i] < names[j]
+	})
+
+	var setups []AccountSetup
+	for _, name := range names {
+		setup := AccountSetup{Transaction: name, Check: r.Transactions[name].Setup.Check}
+		if setup.Check != "" {
+			check, ok := r.Scripts[setup.Check]
+			if !ok {
+				return nil, fmt.Errorf("check script %s of setup transaction %s not found", setup.Check, name)
+			}
+			if len(check.Parameters) != 1 || check.Parameters[0].TypeStr != "Address" || check.ReturnType != "Bool" {
+				return nil, fmt.Errorf("check script %s of setup transaction %s must take an Address and return a Bool", setup.Check, name)
+			}
+			setup.CheckParam = check.Parameters[0].Name
+		}
+		setups = append(setups, setup)
+	}
+	return setups, nil
+}
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Tag strategies deriving the tag grouping a script or transaction
+const (
+	TagStrategyDirectory = "directory" // Full directory path in camelCase, the default
+	TagStrategyFirstDir  = "first-dir" // First directory below the analyzed directory
+	TagStrategyPragma    = "pragma"    // Argument of the // codegen:tag pragma
+	TagStrategyNone      = "none"      // No tags
+)
+
+// deriveTag returns the tag of the file at filePath with pragmas according to
+// the tag strategy
+func (a *Analyzer) deriveTag(filePath string, pragmas map[string]string) string {
+	switch a.TagStrategy {
+	case TagStrategyNone:
+		return ""
+	case TagStrategyPragma:
+		return camelTag([]string{pragmas["tag"]})
+	case TagStrategyFirstDir:
+		base := a.BaseDir
+		if base == "" {
+			base = a.root
+		}
+		parts := directoryParts(filepath.Dir(filePath), base)
+		if len(parts) == 0 {
+			return ""
+		}
+		return camelTag(parts[:1])
+	default:
+		return camelTag(directoryParts(filepath.Dir(filePath), a.BaseDir))
+	}
+}
+
+// directoryParts splits dir, relative to base if set and dir is inside it,
+// into its non-empty parts
+func directoryParts(dir string, base string) []string {
+	if base != "" {
+		if rel, err := filepath.Rel(base, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			dir = rel
+		}
+	}
+	if dir == "." {
+		return nil
+	}
+
+	// Split the path and remove any empty parts
+	var validParts []string
+	for _, part := range strings.Split(dir, string(filepath.Separator)) {
+		if part != "" && part != "." {
+			validParts = append(validParts, part)
+		}
+	}
+	return validParts
+}
+
+// camelTag joins the directory parts into a camelCase tag with an uppercase
+// first character
+func camelTag(parts []string) string {
+	validParts := make([]string, len(parts))
+	for i, part := range parts {
+		// Split by underscores or hyphens
+		subParts := strings.FieldsFunc(part, func(r rune) bool {
+			return r == '_' || r == '-'
+		})
+		// Capitalize each part
+		for j, subPart := range subParts {
+			if i == 0 && j == 0 {
+				// First word starts with lowercase
+				subParts[j] = strings.ToLower(subPart)
+			} else {
+				subParts[j] = strings.Title(strings.ToLower(subPart))
+			}
+		}
+		validParts[i] = strings.Join(subParts, "")
+	}
+
+	// Join all parts
+	tag := strings.Join(validParts, "")
+	if tag != "" {
+		// Ensure first character is uppercase for Swift enum
+		tag = strings.Title(tag)
+	}
+	return tag
+}
+
+// SetTagStrategy sets the derivation of the tags, one of the TagStrategy
+// constants. The directory strategy is used if empty.
+func (a *Analyzer) SetTagStrategy(strategy string) {
+	a.TagStrategy = strategy
+}
@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unionValueKinds are the kinds of decoded values of Cadence types, which
+// generated clients tell the members of a union apart by, e.g. with typeof
+// in TypeScript
+var unionValueKinds = map[string]string{
+	"String":  "string",
+	"Address": "string",
+	"UFix64":  "string",
+	"Fix64":   "string",
+	"UInt128": "string",
+	"UInt256": "string",
+	"Int128":  "string",
+	"Int256":  "string",
+	"Int":     "number",
+	"UInt":    "number",
+	"UInt8":   "number",
+	"UInt16":  "number",
+	"UInt32":  "number",
+	"UInt64":  "number",
+	"Int8":    "number",
+	"Int16":   "number",
+	"Int32":   "number",
+	"Int64":   "number",
+	"Bool":    "boolean",
+}
+
+// unguardedTypes are built-in types whose decoded values cannot be told apart
+// from those of other types
+var unguardedTypes = map[string]bool{
+	"AnyStruct": true, "AnyResource": true, "Any": true, "Never": true, "Void": true,
+	"Character": true, "Type": true, "Path": true, "StoragePath": true, "PublicPath": true,
+	"PrivatePath": true, "CapabilityPath": true, "Capability": true,
+	"Word8": true, "Word16": true, "Word32": true, "Word64": true, "Word128": true, "Word256": true,
+	"Fix128": true, "UFix128": true,
+}
+
+// unionValueKind returns the kind of the decoded values of a member of a
+// union, e.g. string for UFix64 or the type itself for a struct, or an empty
+// string if its values cannot be told apart from others
+func unionValueKind(member string) string {
+	member = strings.TrimSuffix(member, "?")
+	switch {
+	case strings.HasPrefix(member, "[") && strings.HasSuffix(member, "]"):
+		return "array"
+	case strings.HasPrefix(member, "{") && strings.HasSuffix(member, "}"):
+		return "dictionary"
+	case unionValueKinds[member] != "":
+		return unionValueKinds[member]
+	case unguardedTypes[member] || strings.ContainsAny(member, "&<>(){}"):
+		return ""
+	}
+	return member
+}
+
+// detectReturnUnion returns the types a script returning AnyStruct can return,
+// listed by the // codegen:returns StorageInfo | String pragma in the order
+// they are tried. It returns nil for scripts without the pragma and an error
+// if the pragma does not match the script or lists types whose values cannot
+// be told apart, e.g. UFix64 and String, which both decode as strings.
+func detectReturnUnion(script AnalysisResult, pragma string, annotated bool, structs map[string]Struct) ([]string, error) {
+	if !annotated {
+		return nil, nil
+	}
+	if strings.TrimSuffix(script.ReturnType, "?") != "AnyStruct" {
+		return nil, fmt.Errorf("// codegen:returns needs a script returning AnyStruct, not %q", script.ReturnType)
+	}
+
+	var members []string
+	seen := make(map[string]bool)
+	kinds := make(map[string]string)
+	var optional string
+	for _, member := range strings.Split(pragma, "|") {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			return nil, fmt.Errorf("// codegen:returns expects types separated by |, got %q", pragma)
+		}
+		if seen[member] {
+			return nil, fmt.Errorf("// codegen:returns lists %s twice", member)
+		}
+		seen[member] = true
+		kind := unionValueKind(member)
+		if kind == "" {
+			return nil, fmt.Errorf("// codegen:returns cannot tell values of %s apart from other types", member)
+		}
+		if kind == strings.TrimSuffix(member, "?") && !strings.Contains(kind, ".") {
+			// Types of imported contracts are resolved later, those of the script are known
+			if _, ok := structs[kind]; !ok {
+				return nil, fmt.Errorf("// codegen:returns lists %s, which is not a struct of the script", member)
+			}
+		}
+		if other, ok := kinds[kind]; ok {
+			return nil, fmt.Errorf("// codegen:returns cannot tell %s and %s apart, both decode as %s", other, member, kind)
+		}
+		kinds[kind] = member
+		if strings.HasSuffix(member, "?") {
+			if optional != "" {
+				return nil, fmt.Errorf("// codegen:returns cannot tell %s and %s apart, both can be nil", optional, member)
+			}
+			optional = member
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
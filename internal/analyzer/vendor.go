@@ -0,0 +1,181 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// LockfileName is the lockfile of vendored contracts in the working directory
+const LockfileName = "cadence-codegen.lock"
+
+// VendorDir is the default directory of vendored contract sources
+const VendorDir = "vendored"
+
+// VendoredContract is a contract source downloaded from chain
+type VendoredContract struct {
+	Network string `json:"network"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Path    string `json:"path"`   // Slash separated path of the source, relative to the lockfile
+	SHA256  string `json:"sha256"` // Hex SHA-256 of the source
+}
+
+// Lockfile records the vendored contract sources used instead of fetching
+// contracts from chain
+type Lockfile struct {
+	Contracts []VendoredContract `json:"contracts"`
+	dir       string
+}
+
+// LoadLockfile loads the lockfile at path, or returns nil if it does not exist
+func LoadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	lock := &Lockfile{}
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile %s: %w", path, err)
+	}
+	lock.dir = filepath.Dir(path)
+	return lock, nil
+}
+
+// Find returns the vendored contract name of network, or nil
+func (l *Lockfile) Find(network string, name string) *VendoredContract {
+	for i := range l.Contracts {
+		if l.Contracts[i].Network == network && l.Contracts[i].Name == name {
+			return &l.Contracts[i]
+		}
+	}
+	return nil
+}
+
+// Source reads the vendored source of contract, failing if it does not match
+// the recorded hash
+func (l *Lockfile) Source(contract VendoredContract) ([]byte, error) {
+	code, err := os.ReadFile(filepath.Join(l.dir, filepath.FromSlash(contract.Path)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vendored contract %s: %w", contract.Name, err)
+	}
+	sum := sha256.Sum256(code)
+	if hex.EncodeToString(sum[:]) != contract.SHA256 {
+		return nil, fmt.Errorf("vendored contract %s does not match the lockfile hash", contract.Path)
+	}
+	return code, nil
+}
+
+// Write writes the vendored contracts to path, sorted by network and name
+func (l *Lockfile) Write(path string) error {
+	sort.Slice(l.Contracts, func(i, j int) bool {
+		if l.Contracts[i].Network != l.Contracts[j].Network {
+			return l.Contracts[i].Network < l.Contracts[j].Network
+		}
+		return l.Contracts[i].Name < l.Contracts[j].Name
+	})
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal lockfile: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+	return nil
+}
+
+// Vendor downloads the contracts imported by the report from mainnet and
+// testnet into dir and records them in a lockfile at lockPath. It returns the
+// lockfile and the contracts that could not be downloaded with the reason;
+// those keep the entry of a previous lockfile at lockPath.
+func Vendor(report Report, dir string, lockPath string) (*Lockfile, map[string]string, error) {
+	previous, err := LoadLockfile(lockPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	lock := &Lockfile{Contracts: make([]VendoredContract, 0), dir: filepath.Dir(lockPath)}
+	failed := make(map[string]string)
+
+	contracts := make(map[string]bool)
+	for _, results := range []map[string]AnalysisResult{report.Scripts, report.Transactions} {
+		for _, result := range results {
+			for _, imp := range result.Imports {
+				contracts[imp.Contract] = true
+			}
+		}
+	}
+	var names []string
+	for name := range contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, network := range []string{"mainnet", "testnet"} {
+		addresses, _ := report.Addresses[network].(map[string]interface{})
+		for _, name := range names {
+			address, ok := addresses["0x"+name].(string)
+			if !ok {
+				continue
+			}
+			code, err := FetchContractCode(network, address, name)
+			if err != nil {
+				failed[network+"/"+name] = err.Error()
+				if previous != nil {
+					if contract := previous.Find(network, name); contract != nil {
+						lock.Contracts = append(lock.Contracts, *contract)
+					}
+				}
+				continue
+			}
+
+			path := filepath.Join(dir, network, name+".cdc")
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return nil, nil, fmt.Errorf("failed to create vendor directory: %w", err)
+			}
+			if err := os.WriteFile(path, code, 0644); err != nil {
+				return nil, nil, fmt.Errorf("failed to write vendored contract: %w", err)
+			}
+			relative, err := filepath.Rel(lock.dir, path)
+			if err != nil {
+				relative = path
+			}
+			sum := sha256.Sum256(code)
+			lock.Contracts = append(lock.Contracts, VendoredContract{
+				Network: network,
+				Name:    name,
+				Address: address,
+				Path:    filepath.ToSlash(relative),
+				SHA256:  hex.EncodeToString(sum[:]),
+			})
+		}
+	}
+
+	if err := lock.Write(lockPath); err != nil {
+		return nil, nil, err
+	}
+	return lock, failed, nil
+}
+
+// contractCode returns the code of the contract deployed to address on
+// network, from the lockfile if it is vendored or else from chain
+func (a *Analyzer) contractCode(network string, address string, contractName string) ([]byte, error) {
+	if a.Lockfile != nil {
+		if contract := a.Lockfile.Find(network, contractName); contract != nil {
+			return a.Lockfile.Source(*contract)
+		}
+	}
+	return FetchContractCode(network, address, contractName)
+}
+
+// SetLockfile makes the analyzer read vendored contracts of lock instead of
+// fetching them from chain
+func (a *Analyzer) SetLockfile(lock *Lockfile) {
+	a.Lockfile = lock
+}
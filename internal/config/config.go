@@ -0,0 +1,160 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// DefaultFile is the name of the configuration file read from the working directory
+const DefaultFile = "cadence-codegen.json"
+
+// App is the metadata shown by wallets when a user connects
+type App struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Icon        string `json:"icon,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// WalletConnect configures the WalletConnect integration of fcl
+type WalletConnect struct {
+	ProjectID string `json:"projectId,omitempty"`
+}
+
+// Budget limits the embedded code size in bytes and the parameter count of a
+// script or transaction, exceeding them is reported as warnings while
+// generating code. A zero limit disables its check.
+type Budget struct {
+	MaxCodeSize   int `json:"maxCodeSize"`
+	MaxParameters int `json:"maxParameters"`
+}
+
+// Kotlin configures the code of the kotlin target
+type Kotlin struct {
+	// Serialization annotates the data classes for kotlinx.serialization
+	// (kotlinx) or Moshi (moshi), defaults to none
+	Serialization string `json:"serialization,omitempty"`
+	// Coroutines makes functions suspend (suspend) or return a Flow (flow),
+	// defaults to suspend
+	Coroutines string `json:"coroutines,omitempty"`
+	// Compose generates a composable function per script producing the
+	// loading, success or error state of its result
+	Compose bool `json:"compose,omitempty"`
+}
+
+// Config is the cadence-codegen configuration file
+type Config struct {
+	// Network is the default network of generated code (mainnet/testnet/emulator)
+	Network       string        `json:"network,omitempty"`
+	App           App           `json:"app"`
+	WalletConnect WalletConnect `json:"walletConnect"`
+	// AccessNodes lists access node endpoints per network in order of priority,
+	// generated clients fail over to the next one on server errors and timeouts
+	AccessNodes map[string][]string `json:"accessNodes,omitempty"`
+	// Registry is the base URL of the interaction registry used by publish and pull
+	Registry string `json:"registry,omitempty"`
+	// Budget overrides the default limits of the code size and parameter count
+	// of scripts and transactions
+	Budget *Budget `json:"budget,omitempty"`
+	// TagStrategy derives the tags of scripts and transactions
+	// (directory/first-dir/pragma/none), defaults to directory
+	TagStrategy string `json:"tagStrategy,omitempty"`
+	// Kotlin configures the flavors of the generated Kotlin code
+	Kotlin *Kotlin `json:"kotlin,omitempty"`
+}
+
+// Default returns the configuration used when no configuration file exists
+func Default() *Config {
+	return &Config{
+		Network: "mainnet",
+		App: App{
+			Title: "Flow App",
+		},
+	}
+}
+
+// Load reads a configuration file. A missing file yields the default configuration
+// unless required is set.
+func Load(path string, required bool) (*Config, error) {
+	cfg := Default()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !required {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks the configuration values
+func (c *Config) Validate() error {
+	if !supportedNetwork(c.Network) {
+		return fmt.Errorf("unsupported network in config file: %s", c.Network)
+	}
+	for network, nodes := range c.AccessNodes {
+		if !supportedNetwork(network) {
+			return fmt.Errorf("unsupported network of access nodes in config file: %s", network)
+		}
+		if len(nodes) == 0 {
+			return fmt.Errorf("no access nodes listed for %s in config file", network)
+		}
+	}
+	if c.Budget != nil && (c.Budget.MaxCodeSize < 0 || c.Budget.MaxParameters < 0) {
+		return fmt.Errorf("negative budget limit in config file")
+	}
+	if !supportedTagStrategy(c.TagStrategy) {
+		return fmt.Errorf("unsupported tag strategy in config file: %s", c.TagStrategy)
+	}
+	if c.Kotlin != nil && !supportedKotlinSerialization(c.Kotlin.Serialization) {
+		return fmt.Errorf("unsupported Kotlin serialization in config file: %s", c.Kotlin.Serialization)
+	}
+	if c.Kotlin != nil && !supportedKotlinCoroutines(c.Kotlin.Coroutines) {
+		return fmt.Errorf("unsupported Kotlin coroutines in config file: %s", c.Kotlin.Coroutines)
+	}
+	return nil
+}
+
+func supportedNetwork(network string) bool {
+	switch network {
+	case "mainnet", "testnet", "emulator":
+		return true
+	default:
+		return false
+	}
+}
+
+func supportedTagStrategy(strategy string) bool {
+	switch strategy {
+	case "", "directory", "first-dir", "pragma", "none":
+		return true
+	default:
+		return false
+	}
+}
+
+func supportedKotlinSerialization(serialization string) bool {
+	switch serialization {
+	case "", "none", "kotlinx", "moshi":
+		return true
+	default:
+		return false
+	}
+}
+
+func supportedKotlinCoroutines(coroutines string) bool {
+	switch coroutines {
+	case "", "suspend", "flow":
+		return true
+	default:
+		return false
+	}
+}
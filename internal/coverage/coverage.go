@@ -0,0 +1,267 @@
+package coverage
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/golang"
+	"github.com/outblock/cadence-codegen/internal/generator/kotlin"
+	"github.com/outblock/cadence-codegen/internal/generator/swift"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+	"github.com/outblock/cadence-codegen/internal/graph"
+)
+
+// Target is a code generation target checked for bindings
+type Target struct {
+	Name string
+	// Generate generates the code of the target for a report
+	Generate func(report analyzer.Report) (string, error)
+	// Pattern returns a pattern matching the binding of a Cadence file in the generated code
+	Pattern func(filename string) string
+}
+
+// Targets lists the code generation targets included in the coverage report
+var Targets = []Target{
+	{
+		Name: "swift",
+		Generate: func(report analyzer.Report) (string, error) {
+			return swift.New(report).Generate()
+		},
+		Pattern: func(filename string) string {
+			return `\bcase ` + regexp.QuoteMeta(swift.FunctionName(filename)) + `\b`
+		},
+	},
+	{
+		Name: "typescript",
+		Generate: func(report analyzer.Report) (string, error) {
+			return typescript.New(report).Generate()
+		},
+		Pattern: func(filename string) string {
+			return `public async ` + regexp.QuoteMeta(typescript.FunctionName(filename)) + `\(`
+		},
+	},
+	{
+		Name: "golang",
+		Generate: func(report analyzer.Report) (string, error) {
+			return golang.New(report).Generate()
+		},
+		Pattern: func(filename string) string {
+			return `func Encode` + regexp.QuoteMeta(golang.FunctionName(filename)) + `Arguments\(`
+		},
+	},
+	{
+		Name: "kotlin",
+		Generate: func(report analyzer.Report) (string, error) {
+			return kotlin.New(report).Generate()
+		},
+		Pattern: func(filename string) string {
+			return `suspend fun ` + regexp.QuoteMeta(kotlin.FunctionName(filename)) + `\(`
+		},
+	},
+}
+
+// FileCoverage reports which targets produced bindings for a Cadence file
+type FileCoverage struct {
+	File    string          `json:"file"`
+	Type    string          `json:"type"`
+	Tag     string          `json:"tag,omitempty"`
+	Targets map[string]bool `json:"targets"`
+	Reason  string          `json:"reason,omitempty"`
+}
+
+// StructCoverage reports whether a referenced struct type was resolved
+type StructCoverage struct {
+	Name     string   `json:"name"`
+	Resolved bool     `json:"resolved"`
+	UsedBy   []string `json:"usedBy"`
+}
+
+// ContractCoverage reports a contract that could not be fetched from chain
+type ContractCoverage struct {
+	Contract string `json:"contract"`
+	Error    string `json:"error"`
+}
+
+// Summary holds the totals of a coverage report
+type Summary struct {
+	Files             int            `json:"files"`
+	Bound             map[string]int `json:"bound"`
+	ResolvedStructs   int            `json:"resolvedStructs"`
+	UnresolvedStructs int            `json:"unresolvedStructs"`
+	FailedContracts   int            `json:"failedContracts"`
+}
+
+// Report is the coverage report of an analysis
+type Report struct {
+	Files     []FileCoverage     `json:"files"`
+	Structs   []StructCoverage   `json:"structs"`
+	Contracts []ContractCoverage `json:"unresolvedContracts"`
+	Summary   Summary            `json:"summary"`
+	// targets holds the target names in table column order
+	targets []string
+}
+
+// Build generates the code of every target for the report and checks which
+// analyzed files produced bindings
+func Build(report analyzer.Report) (*Report, error) {
+	coverage := &Report{
+		Files:     make([]FileCoverage, 0),
+		Structs:   make([]StructCoverage, 0),
+		Contracts: make([]ContractCoverage, 0),
+		Summary:   Summary{Bound: make(map[string]int)},
+	}
+
+	codes := make(map[string]string)
+	for _, target := range Targets {
+		code, err := target.Generate(report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s code: %w", target.Name, err)
+		}
+		codes[target.Name] = code
+		coverage.targets = append(coverage.targets, target.Name)
+	}
+
+	addResults := func(results map[string]analyzer.AnalysisResult) {
+		for filename, result := range results {
+			file := FileCoverage{
+				File:    filename,
+				Type:    result.Type,
+				Tag:     result.Tag,
+				Targets: make(map[string]bool),
+			}
+			for _, target := range Targets {
+				bound := regexp.MustCompile(target.Pattern(filename)).MatchString(codes[target.Name])
+				file.Targets[target.Name] = bound
+				if bound {
+					coverage.Summary.Bound[target.Name]++
+				}
+			}
+			coverage.Files = append(coverage.Files, file)
+		}
+	}
+	addResults(report.Transactions)
+	addResults(report.Scripts)
+	for path, reason := range report.Skipped {
+		file := FileCoverage{
+			File:    path,
+			Type:    "skipped",
+			Targets: make(map[string]bool),
+			Reason:  reason,
+		}
+		for _, target := range Targets {
+			file.Targets[target.Name] = false
+		}
+		coverage.Files = append(coverage.Files, file)
+	}
+	sort.Slice(coverage.Files, func(i, j int) bool {
+		return coverage.Files[i].File < coverage.Files[j].File
+	})
+	coverage.Summary.Files = len(coverage.Files)
+
+	// Collect the referenced struct types and their users from the dependency graph
+	dependencies := graph.Build(report)
+	usedBy := make(map[string][]string)
+	for _, edge := range dependencies.Edges {
+		if edge.Kind == graph.EdgeUses {
+			usedBy[edge.To] = append(usedBy[edge.To], nodeLabel(edge.From))
+		}
+	}
+	for _, node := range dependencies.Nodes {
+		if node.Kind != graph.KindStruct {
+			continue
+		}
+		users := usedBy[node.ID]
+		if users == nil {
+			users = make([]string, 0)
+		}
+		sort.Strings(users)
+		coverage.Structs = append(coverage.Structs, StructCoverage{
+			Name:     node.Label,
+			Resolved: node.Resolved,
+			UsedBy:   users,
+		})
+		if node.Resolved {
+			coverage.Summary.ResolvedStructs++
+		} else {
+			coverage.Summary.UnresolvedStructs++
+		}
+	}
+
+	for contract, err := range report.UnresolvedContracts {
+		coverage.Contracts = append(coverage.Contracts, ContractCoverage{Contract: contract, Error: err})
+	}
+	sort.Slice(coverage.Contracts, func(i, j int) bool {
+		return coverage.Contracts[i].Contract < coverage.Contracts[j].Contract
+	})
+	coverage.Summary.FailedContracts = len(coverage.Contracts)
+
+	return coverage, nil
+}
+
+// nodeLabel strips the kind prefix from a graph node ID
+func nodeLabel(id string) string {
+	if idx := strings.Index(id, ":"); idx >= 0 {
+		return id[idx+1:]
+	}
+	return id
+}
+
+// mark renders a coverage flag for the table
+func mark(ok bool) string {
+	if ok {
+		return "yes"
+	}
+	return "no"
+}
+
+// Table renders the coverage report as human readable tables
+func (r *Report) Table() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+
+	header := []string{"FILE", "TYPE"}
+	for _, target := range r.targets {
+		header = append(header, strings.ToUpper(target))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, file := range r.Files {
+		row := []string{file.File, file.Type}
+		for _, target := range r.targets {
+			row = append(row, mark(file.Targets[target]))
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	if len(r.Structs) > 0 {
+		sb.WriteString("\n")
+		fmt.Fprintln(w, "STRUCT\tRESOLVED\tUSED BY")
+		for _, s := range r.Structs {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", s.Name, mark(s.Resolved), strings.Join(s.UsedBy, ", "))
+		}
+		w.Flush()
+	}
+
+	if len(r.Contracts) > 0 {
+		sb.WriteString("\n")
+		fmt.Fprintln(w, "UNRESOLVED CONTRACT\tERROR")
+		for _, c := range r.Contracts {
+			fmt.Fprintf(w, "%s\t%s\n", c.Contract, c.Error)
+		}
+		w.Flush()
+	}
+
+	sb.WriteString("\n")
+	var bound []string
+	for _, target := range r.targets {
+		bound = append(bound, fmt.Sprintf("%s %d/%d", target, r.Summary.Bound[target], r.Summary.Files))
+	}
+	fmt.Fprintf(&sb, "Files: %d (bindings: %s)\n", r.Summary.Files, strings.Join(bound, ", "))
+	fmt.Fprintf(&sb, "Structs: %d resolved, %d unresolved\n", r.Summary.ResolvedStructs, r.Summary.UnresolvedStructs)
+	fmt.Fprintf(&sb, "Contracts that could not be fetched: %d\n", r.Summary.FailedContracts)
+	return sb.String()
+}
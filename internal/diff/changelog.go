@@ -0,0 +1,79 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// kindTitles are the titles of the kinds of changes, in changelog order
+var kindTitles = []struct {
+	Kind  string
+	Title string
+}{
+	{"script", "Script"},
+	{"transaction", "Transaction"},
+	{"struct", "Struct"},
+	{"event", "Event"},
+}
+
+// Changelog renders the changes as a Keep a Changelog section with Added,
+// Changed and Removed entries. Breaking changes are marked as such. The date
+// is omitted from the heading if empty.
+func (r *Result) Changelog(version string, date string) string {
+	var added, changed, removed []string
+	for _, kind := range kindTitles {
+		changes := make([]Change, 0)
+		for _, change := range r.Changes {
+			if change.Kind == kind.Kind {
+				changes = append(changes, change)
+			}
+		}
+		sort.SliceStable(changes, func(i, j int) bool {
+			return changes[i].Name < changes[j].Name
+		})
+
+		for _, change := range changes {
+			entry := fmt.Sprintf("%s `%s`", kind.Title, change.Name)
+			switch change.Message {
+			case "added":
+				added = append(added, entry)
+			case "removed":
+				removed = append(removed, entry)
+			default:
+				entry += ": " + change.Message
+				if change.Bump == BumpMajor {
+					entry = "**Breaking:** " + entry
+				}
+				changed = append(changed, entry)
+			}
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("## " + version)
+	if date != "" {
+		builder.WriteString(" - " + date)
+	}
+	builder.WriteString("\n")
+	if len(r.Changes) == 0 {
+		builder.WriteString("\nNo changes.\n")
+	}
+	for _, section := range []struct {
+		Title   string
+		Entries []string
+	}{
+		{"Added", added},
+		{"Changed", changed},
+		{"Removed", removed},
+	} {
+		if len(section.Entries) == 0 {
+			continue
+		}
+		builder.WriteString("\n### " + section.Title + "\n\n")
+		for _, entry := range section.Entries {
+			builder.WriteString("- " + entry + "\n")
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,290 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// Bump is a semantic version bump
+type Bump string
+
+const (
+	BumpNone  Bump = "none"
+	BumpPatch Bump = "patch"
+	BumpMinor Bump = "minor"
+	BumpMajor Bump = "major"
+)
+
+// rank orders bumps from least to most significant
+var rank = map[Bump]int{
+	BumpNone:  0,
+	BumpPatch: 1,
+	BumpMinor: 2,
+	BumpMajor: 3,
+}
+
+// Change describes a single difference between two reports
+type Change struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Message  string `json:"message"`
+	Bump     Bump   `json:"bump"`
+	Breaking bool   `json:"breaking"` // Whether code using the generated SDK breaks, i.e. a major change
+}
+
+// String renders the change as a single line
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s %s: %s", c.Bump, c.Kind, c.Name, c.Message)
+}
+
+// Result is the difference between two reports
+type Result struct {
+	Changes  []Change `json:"changes"`
+	Bump     Bump     `json:"bump"`
+	Breaking int      `json:"breaking"` // Number of breaking changes
+}
+
+// Compare returns the changes from the old report to the new one and the
+// suggested version bump of the generated SDK
+func Compare(oldReport analyzer.Report, newReport analyzer.Report) *Result {
+	result := &Result{Changes: make([]Change, 0), Bump: BumpNone}
+
+	compareResults(result, "transaction", oldReport.Transactions, newReport.Transactions)
+	compareResults(result, "script", oldReport.Scripts, newReport.Scripts)
+	compareStructs(result, oldReport.Structs, newReport.Structs)
+	compareEvents(result, oldReport.Events, newReport.Events)
+
+	sort.SliceStable(result.Changes, func(i, j int) bool {
+		if rank[result.Changes[i].Bump] != rank[result.Changes[j].Bump] {
+			return rank[result.Changes[i].Bump] > rank[result.Changes[j].Bump]
+		}
+		if result.Changes[i].Kind != result.Changes[j].Kind {
+			return result.Changes[i].Kind < result.Changes[j].Kind
+		}
+		return result.Changes[i].Name < result.Changes[j].Name
+	})
+	return result
+}
+
+// BreakingChanges returns the major changes, which break code using the generated SDK
+func (r *Result) BreakingChanges() []Change {
+	var breaking []Change
+	for _, change := range r.Changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+// CompareNames returns the functions recorded by file name, e.g. in a names
+// file, whose script or transaction is no longer in the report
+func CompareNames(recorded map[string]string, report analyzer.Report) *Result {
+	result := &Result{Changes: make([]Change, 0), Bump: BumpNone}
+	var fileNames []string
+	for fileName := range recorded {
+		_, isScript := report.Scripts[fileName]
+		_, isTransaction := report.Transactions[fileName]
+		if !isScript && !isTransaction {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		result.add("function", recorded[fileName], BumpMajor, "removed, recorded for %s", fileName)
+	}
+	return result
+}
+
+// add records a change and raises the suggested bump if needed
+func (r *Result) add(kind string, name string, bump Bump, format string, args ...interface{}) {
+	r.Changes = append(r.Changes, Change{
+		Kind:     kind,
+		Name:     name,
+		Message:  fmt.Sprintf(format, args...),
+		Bump:     bump,
+		Breaking: bump == BumpMajor,
+	})
+	if bump == BumpMajor {
+		r.Breaking++
+	}
+	if rank[bump] > rank[r.Bump] {
+		r.Bump = bump
+	}
+}
+
+// sortedKeys returns the sorted union of the keys of two maps
+func sortedKeys[T any](a map[string]T, b map[string]T) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]T{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// compareResults compares the scripts or transactions of two reports
+func compareResults(r *Result, kind string, oldResults map[string]analyzer.AnalysisResult, newResults map[string]analyzer.AnalysisResult) {
+	for _, name := range sortedKeys(oldResults, newResults) {
+		oldResult, inOld := oldResults[name]
+		newResult, inNew := newResults[name]
+		switch {
+		case !inOld:
+			r.add(kind, name, BumpMinor, "added")
+			continue
+		case !inNew:
+			r.add(kind, name, BumpMajor, "removed")
+			continue
+		}
+
+		changed := false
+		oldParams, newParams := oldResult.Parameters, newResult.Parameters
+		for i := 0; i < len(oldParams) || i < len(newParams); i++ {
+			switch {
+			case i >= len(newParams):
+				r.add(kind, name, BumpMajor, "parameter %s removed", oldParams[i].Name)
+			case i >= len(oldParams):
+				r.add(kind, name, BumpMajor, "parameter %s added", newParams[i].Name)
+			case oldParams[i].Name != newParams[i].Name:
+				r.add(kind, name, BumpMajor, "parameter %s renamed to %s", oldParams[i].Name, newParams[i].Name)
+			case oldParams[i].TypeStr != newParams[i].TypeStr:
+				r.add(kind, name, BumpMajor, "parameter %s type changed from %s to %s", oldParams[i].Name, oldParams[i].TypeStr, newParams[i].TypeStr)
+			default:
+				continue
+			}
+			changed = true
+		}
+
+		if oldResult.ReturnType != newResult.ReturnType {
+			r.add(kind, name, BumpMajor, "return type changed from %s to %s", orNone(oldResult.ReturnType), orNone(newResult.ReturnType))
+			changed = true
+		}
+		oldUnion, newUnion := strings.Join(oldResult.ReturnUnion, " | "), strings.Join(newResult.ReturnUnion, " | ")
+		if oldUnion != newUnion {
+			r.add(kind, name, BumpMajor, "returned types changed from %s to %s", orNone(oldUnion), orNone(newUnion))
+			changed = true
+		}
+		if oldResult.Tag != newResult.Tag {
+			r.add(kind, name, BumpMajor, "tag changed from %s to %s", orNone(oldResult.Tag), orNone(newResult.Tag))
+			changed = true
+		}
+
+		// Code changes that keep the signature only change the embedded Cadence
+		if !changed && oldResult.Base64 != newResult.Base64 {
+			r.add(kind, name, BumpPatch, "code changed")
+		}
+	}
+}
+
+// compareStructs compares the structs of two reports. Added fields are
+// compatible for generated types, removed or changed fields are not.
+func compareStructs(r *Result, oldStructs map[string]analyzer.Struct, newStructs map[string]analyzer.Struct) {
+	for _, name := range sortedKeys(oldStructs, newStructs) {
+		oldStruct, inOld := oldStructs[name]
+		newStruct, inNew := newStructs[name]
+		switch {
+		case !inOld:
+			r.add("struct", name, BumpMinor, "added")
+			continue
+		case !inNew:
+			r.add("struct", name, BumpMajor, "removed")
+			continue
+		}
+		compareFields(r, "struct", name, oldStruct.Fields, newStruct.Fields)
+	}
+}
+
+// compareEvents compares the events of two reports
+func compareEvents(r *Result, oldEvents map[string]analyzer.Event, newEvents map[string]analyzer.Event) {
+	for _, name := range sortedKeys(oldEvents, newEvents) {
+		oldEvent, inOld := oldEvents[name]
+		newEvent, inNew := newEvents[name]
+		switch {
+		case !inOld:
+			r.add("event", name, BumpMinor, "added")
+			continue
+		case !inNew:
+			r.add("event", name, BumpMajor, "removed")
+			continue
+		}
+		compareFields(r, "event", name, oldEvent.Fields, newEvent.Fields)
+	}
+}
+
+// compareFields compares the fields of a struct or event by name
+func compareFields(r *Result, kind string, name string, oldFields []analyzer.Field, newFields []analyzer.Field) {
+	oldByName := make(map[string]analyzer.Field)
+	for _, field := range oldFields {
+		oldByName[field.Name] = field
+	}
+	newByName := make(map[string]analyzer.Field)
+	for _, field := range newFields {
+		newByName[field.Name] = field
+	}
+	for _, fieldName := range sortedKeys(oldByName, newByName) {
+		oldField, inOld := oldByName[fieldName]
+		newField, inNew := newByName[fieldName]
+		switch {
+		case !inOld:
+			r.add(kind, name, BumpMinor, "field %s added", fieldName)
+		case !inNew:
+			r.add(kind, name, BumpMajor, "field %s removed", fieldName)
+		case oldField.TypeStr != newField.TypeStr || oldField.Optional != newField.Optional:
+			r.add(kind, name, BumpMajor, "field %s type changed from %s to %s", fieldName, oldField.TypeStr, newField.TypeStr)
+		}
+	}
+}
+
+// orNone renders an empty value as "none"
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// NextVersion applies a bump to a semantic version such as 1.2.3 or v1.2.3.
+// Pre-release and build metadata are dropped.
+func NextVersion(version string, bump Bump) (string, error) {
+	prefix := ""
+	if strings.HasPrefix(version, "v") {
+		prefix = "v"
+		version = version[1:]
+	}
+	if idx := strings.IndexAny(version, "-+"); idx >= 0 {
+		version = version[:idx]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid semantic version: %s", version)
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid semantic version: %s", version)
+		}
+		numbers[i] = n
+	}
+
+	switch bump {
+	case BumpMajor:
+		numbers = [3]int{numbers[0] + 1, 0, 0}
+	case BumpMinor:
+		numbers = [3]int{numbers[0], numbers[1] + 1, 0}
+	case BumpPatch:
+		numbers[2]++
+	}
+	return fmt.Sprintf("%s%d.%d.%d", prefix, numbers[0], numbers[1], numbers[2]), nil
+}
@@ -0,0 +1,245 @@
+package fixtures
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// SampleAddress is the address used in generated sample values
+const SampleAddress = "0x0000000000000001"
+
+// SampleTransactionID is the transaction ID used in generated sample values
+const SampleTransactionID = "0000000000000000000000000000000000000000000000000000000000000001"
+
+// Variant selects the values of generated fixtures
+type Variant string
+
+const (
+	// VariantSample fills optionals and collections with typical values
+	VariantSample Variant = "sample"
+	// VariantMin uses nil optionals, empty collections and the minimum of numbers
+	VariantMin Variant = "min"
+	// VariantMax fills optionals and collections and uses the maximum of numbers
+	VariantMax Variant = "max"
+)
+
+// maxSafeInteger is the largest integer JavaScript numbers represent exactly.
+// Integers decoded into numbers by fcl are clamped to it.
+const maxSafeInteger = 1<<53 - 1
+
+// integerBits are the sizes of the fixed size integer types
+var integerBits = map[string]uint{
+	"Int8": 8, "Int16": 16, "Int32": 32, "Int64": 64, "Int128": 128, "Int256": 256,
+	"UInt8": 8, "UInt16": 16, "UInt32": 32, "UInt64": 64, "UInt128": 128, "UInt256": 256,
+	"Word8": 8, "Word16": 16, "Word32": 32, "Word64": 64, "Word128": 128, "Word256": 256,
+}
+
+// Generator builds sample values for Cadence types, in the JSON shape
+// returned by fcl after decoding
+type Generator struct {
+	Report  analyzer.Report
+	Variant Variant
+}
+
+// New creates a new fixture generator
+func New(report analyzer.Report) *Generator {
+	return &Generator{
+		Report:  report,
+		Variant: VariantSample,
+	}
+}
+
+// SetVariant sets the variant of generated values
+func (g *Generator) SetVariant(variant Variant) error {
+	switch variant {
+	case VariantSample, VariantMin, VariantMax:
+		g.Variant = variant
+		return nil
+	default:
+		return fmt.Errorf("unsupported fixture variant: %s", variant)
+	}
+}
+
+// Sample returns a sample value for the given Cadence type
+func (g *Generator) Sample(cadenceType string) interface{} {
+	return g.sample(cadenceType, make(map[string]bool))
+}
+
+// Catalog holds a fixture of every struct and script result
+type Catalog struct {
+	Structs map[string]interface{} `json:"structs"`
+	Scripts map[string]interface{} `json:"scripts"`
+}
+
+// Catalog returns fixtures of the structs of the report keyed by name and of
+// the script results keyed by the function name nameFunc returns for a file
+func (g *Generator) Catalog(nameFunc func(filename string) string) *Catalog {
+	catalog := &Catalog{
+		Structs: make(map[string]interface{}),
+		Scripts: make(map[string]interface{}),
+	}
+	for name := range g.Report.Structs {
+		catalog.Structs[name] = g.Sample(name)
+	}
+	for filename, script := range g.Report.Scripts {
+		var result interface{}
+		if script.ReturnType != "" {
+			result = g.Sample(script.ReturnType)
+		}
+		catalog.Scripts[nameFunc(filename)] = result
+	}
+	return catalog
+}
+
+// sample builds a sample value, tracking visited structs to stop on recursive types
+func (g *Generator) sample(cadenceType string, visiting map[string]bool) interface{} {
+	cadenceType = strings.TrimSpace(cadenceType)
+
+	// Optional types use a sample of the wrapped type, or nil for minimal fixtures
+	if strings.HasSuffix(cadenceType, "?") {
+		if g.Variant == VariantMin {
+			return nil
+		}
+		return g.sample(strings.TrimSuffix(cadenceType, "?"), visiting)
+	}
+
+	// Array types contain a single sample element, none or three
+	if strings.HasPrefix(cadenceType, "[") && strings.HasSuffix(cadenceType, "]") {
+		elementType := strings.TrimPrefix(strings.TrimSuffix(cadenceType, "]"), "[")
+		// Constant sized arrays like [UInt8; 32]
+		if idx := strings.Index(elementType, ";"); idx >= 0 {
+			elementType = elementType[:idx]
+		}
+		elements := []interface{}{}
+		for i := 0; i < g.collectionSize(); i++ {
+			elements = append(elements, g.sample(elementType, visiting))
+		}
+		return elements
+	}
+
+	// Dictionary types contain a single sample entry, or none
+	if strings.HasPrefix(cadenceType, "{") && strings.HasSuffix(cadenceType, "}") {
+		inner := strings.TrimPrefix(strings.TrimSuffix(cadenceType, "}"), "{")
+		parts := strings.SplitN(inner, ":", 2)
+		if len(parts) == 2 && g.Variant != VariantMin {
+			return map[string]interface{}{
+				sampleKey(strings.TrimSpace(parts[0])): g.sample(parts[1], visiting),
+			}
+		}
+		return map[string]interface{}{}
+	}
+
+	if value, ok := g.number(cadenceType); ok {
+		return value
+	}
+
+	switch cadenceType {
+	case "String":
+		if g.Variant == VariantMin {
+			return ""
+		}
+		return "test"
+	case "Character":
+		if g.Variant == VariantMin {
+			return "a"
+		}
+		return "test"
+	case "Address":
+		return SampleAddress
+	case "Bool":
+		return g.Variant != VariantMin
+	case "AnyStruct", "Void":
+		return nil
+	}
+
+	// Struct types use samples of their fields
+	structName := strings.ReplaceAll(cadenceType, ".", "")
+	if structDef, ok := g.Report.Structs[structName]; ok && !visiting[structName] {
+		visiting[structName] = true
+		defer delete(visiting, structName)
+
+		value := make(map[string]interface{})
+		for _, field := range structDef.Fields {
+			if field.Optional && g.Variant == VariantMin {
+				value[field.Name] = nil
+				continue
+			}
+			value[field.Name] = g.sample(field.TypeStr, visiting)
+		}
+		return value
+	}
+
+	return map[string]interface{}{}
+}
+
+// collectionSize returns the number of elements of sampled arrays
+func (g *Generator) collectionSize() int {
+	switch g.Variant {
+	case VariantMin:
+		return 0
+	case VariantMax:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// number returns a sample of a numeric type, within the range of the type
+func (g *Generator) number(cadenceType string) (interface{}, bool) {
+	switch cadenceType {
+	case "UFix64":
+		return map[Variant]string{VariantSample: "1.00000000", VariantMin: "0.00000000", VariantMax: "184467440737.09551615"}[g.Variant], true
+	case "Fix64":
+		return map[Variant]string{VariantSample: "1.00000000", VariantMin: "-92233720368.54775808", VariantMax: "92233720368.54775807"}[g.Variant], true
+	}
+
+	bits, fixed := integerBits[cadenceType]
+	if !fixed && cadenceType != "Int" && cadenceType != "UInt" {
+		return nil, false
+	}
+	// fcl decodes integers up to 64 bits into numbers and wider ones into strings
+	asString := bits > 64
+
+	value := big.NewInt(1)
+	signed := strings.HasPrefix(cadenceType, "Int")
+	switch {
+	case g.Variant == VariantMin && !signed:
+		value = big.NewInt(0)
+	case g.Variant == VariantMin && fixed:
+		value = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), bits-1))
+	case g.Variant == VariantMin:
+		value = big.NewInt(-maxSafeInteger)
+	case g.Variant == VariantMax && signed && fixed:
+		value = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bits-1), big.NewInt(1))
+	case g.Variant == VariantMax && fixed:
+		value = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bits), big.NewInt(1))
+	case g.Variant == VariantMax:
+		value = big.NewInt(maxSafeInteger)
+	}
+
+	if asString {
+		return value.String(), true
+	}
+	if value.Cmp(big.NewInt(maxSafeInteger)) > 0 {
+		value = big.NewInt(maxSafeInteger)
+	}
+	if value.Cmp(big.NewInt(-maxSafeInteger)) < 0 {
+		value = big.NewInt(-maxSafeInteger)
+	}
+	return value.Int64(), true
+}
+
+// sampleKey returns a sample dictionary key for the given Cadence key type
+func sampleKey(cadenceType string) string {
+	switch cadenceType {
+	case "Address":
+		return SampleAddress
+	case "String", "Character":
+		return "key"
+	default:
+		return "1"
+	}
+}
@@ -0,0 +1,256 @@
+package flix
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// Status is the outcome of verifying a published template
+type Status string
+
+const (
+	// StatusMatch means the local source has the hash of the template code
+	StatusMatch Status = "match"
+	// StatusMismatch means the local source changed since the template was published
+	StatusMismatch Status = "mismatch"
+	// StatusMissing means no local source was found for the template
+	StatusMissing Status = "missing"
+	// StatusIDMismatch means the template ID is not the hash of its fields
+	StatusIDMismatch Status = "id-mismatch"
+	// StatusStaleAudit means an audit signs the ID of no template
+	StatusStaleAudit Status = "stale-audit"
+)
+
+// AuditType is the f_type of FLIX template audits
+const AuditType = "InteractionTemplateAudit"
+
+// Result is the verification of a published FLIX template against its local
+// .cdc source, or of an audit that matches no template
+type Result struct {
+	Template      string   `json:"template"`
+	Source        string   `json:"source,omitempty"`
+	Status        Status   `json:"status"`
+	PublishedHash string   `json:"publishedHash,omitempty"`
+	LocalHash     string   `json:"localHash,omitempty"`
+	ID            string   `json:"id"`
+	ComputedID    string   `json:"computedId,omitempty"`
+	Audits        []string `json:"audits,omitempty"`
+}
+
+// String formats the result as template: status, with the hashes of mismatches
+func (r Result) String() string {
+	switch r.Status {
+	case StatusMismatch:
+		return fmt.Sprintf("%s: %s, %s has cadence hash %s instead of %s", r.Template, r.Status, r.Source, r.LocalHash, r.PublishedHash)
+	case StatusMissing:
+		return fmt.Sprintf("%s: %s, no .cdc source found", r.Template, r.Status)
+	case StatusIDMismatch:
+		return fmt.Sprintf("%s: %s, the template fields hash to %s instead of %q", r.Template, r.Status, r.ComputedID, r.ID)
+	case StatusStaleAudit:
+		return fmt.Sprintf("%s: %s, no template has the audited ID %s", r.Template, r.Status, r.ID)
+	default:
+		return fmt.Sprintf("%s: %s %s", r.Template, r.Status, r.PublishedHash)
+	}
+}
+
+// Verify checks that the .cdc sources below sourcesPath still hash like the
+// code of the FLIX templates at templatesPath, a template file or a directory
+// of them. A template is matched with the source of the same relative path, or
+// else with the only source of the same file name. The ID of every template is
+// recomputed, and the audits among the templates must audit one of their IDs;
+// audit signatures are not verified.
+func Verify(templatesPath string, sourcesPath string) ([]Result, error) {
+	templates, audits, err := findTemplates(templatesPath)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := findSources(sourcesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	ids := make(map[string]int)
+	for _, template := range templates {
+		data, err := os.ReadFile(template.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		code, err := analyzer.FLIXCadence(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", template.path, err)
+		}
+		var header struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(data, &header); err != nil {
+			return nil, fmt.Errorf("%s: failed to parse FLIX template: %w", template.path, err)
+		}
+		id, err := TemplateID(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", template.path, err)
+		}
+		result := Result{
+			Template:      template.path,
+			Status:        StatusMissing,
+			PublishedHash: analyzer.CadenceHash(code),
+			ID:            header.ID,
+			ComputedID:    id,
+		}
+
+		source := sources.find(template.rel)
+		if source != "" {
+			content, err := os.ReadFile(source)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read file: %w", err)
+			}
+			local, err := analyzer.FLIXSource(data, content)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", source, err)
+			}
+			result.Source = source
+			result.LocalHash = analyzer.CadenceHash(local)
+			result.Status = StatusMatch
+			if result.LocalHash != result.PublishedHash {
+				result.Status = StatusMismatch
+			}
+		}
+		if result.Status == StatusMatch && result.ID != result.ComputedID {
+			result.Status = StatusIDMismatch
+		}
+		ids[id] = len(results)
+		results = append(results, result)
+	}
+
+	for _, audit := range audits {
+		if i, ok := ids[audit.id]; ok {
+			results[i].Audits = append(results[i].Audits, audit.path)
+			continue
+		}
+		results = append(results, Result{Template: audit.path, Status: StatusStaleAudit, ID: audit.id})
+	}
+	return results, nil
+}
+
+// Failed reports whether any template has no matching local source or ID, or
+// any audit is stale
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Status != StatusMatch {
+			return true
+		}
+	}
+	return false
+}
+
+// templateFile is a FLIX template and its path relative to the templates root
+type templateFile struct {
+	path string
+	rel  string
+}
+
+// auditFile is a FLIX template audit and the template ID it audits
+type auditFile struct {
+	path string
+	id   string
+}
+
+// findTemplates lists the FLIX templates and template audits at path, a
+// template or a directory
+func findTemplates(path string) ([]templateFile, []auditFile, error) {
+	var templates []templateFile
+	var audits []auditFile
+	err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(file) != ".json" {
+			return nil
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+		if id, ok := auditedID(data); ok {
+			audits = append(audits, auditFile{path: file, id: id})
+			return nil
+		}
+		if !analyzer.IsFLIX(data) {
+			return nil
+		}
+		rel, err := filepath.Rel(path, file)
+		if err != nil || rel == "." {
+			rel = filepath.Base(file)
+		}
+		templates = append(templates, templateFile{path: file, rel: rel})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(templates) == 0 {
+		return nil, nil, fmt.Errorf("no FLIX templates found in %s", path)
+	}
+	return templates, audits, nil
+}
+
+// auditedID returns the template ID audited by data if it is a FLIX audit
+func auditedID(data []byte) (string, bool) {
+	var audit struct {
+		FType string `json:"f_type"`
+		Data  struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(data, &audit) != nil || audit.FType != AuditType {
+		return "", false
+	}
+	return audit.Data.ID, true
+}
+
+// sourceFiles are the .cdc files below a root by relative path
+type sourceFiles map[string]string
+
+// findSources lists the .cdc files at path, a file or a directory
+func findSources(path string) (sourceFiles, error) {
+	sources := make(sourceFiles)
+	err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(file) != ".cdc" {
+			return nil
+		}
+		rel, err := filepath.Rel(path, file)
+		if err != nil || rel == "." {
+			rel = filepath.Base(file)
+		}
+		sources[rel] = file
+		return nil
+	})
+	return sources, err
+}
+
+// find returns the source of the template at rel, by relative path or else by
+// file name if only one source has it
+func (s sourceFiles) find(rel string) string {
+	rel = strings.TrimSuffix(rel, filepath.Ext(rel)) + ".cdc"
+	if source, ok := s[rel]; ok {
+		return source
+	}
+	var matches []string
+	for sourceRel, source := range s {
+		if filepath.Base(sourceRel) == filepath.Base(rel) {
+			matches = append(matches, source)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0]
+	}
+	return ""
+}
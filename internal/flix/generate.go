@@ -0,0 +1,131 @@
+package flix
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// GeneratedTemplate is a template generated for a script or transaction and
+// its slash separated path relative to the output directory
+type GeneratedTemplate struct {
+	Path     string
+	Template Template
+}
+
+// Generate builds a FLIX 1.1.0 template for every script and transaction of
+// report, at the path of its source with a .json extension. Dependencies list
+// the addresses of the report per network; network and dependency pins need
+// the deployed contract code and are left empty. Scripts get the JSON schema
+// of their result, see ResultSchema, and every template its ID, see GeneratedID.
+func Generate(report analyzer.Report) ([]GeneratedTemplate, error) {
+	addresses := report.ContractAddresses()
+	var networks []string
+	for network := range addresses {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	var templates []GeneratedTemplate
+	for _, results := range []map[string]analyzer.AnalysisResult{report.Scripts, report.Transactions} {
+		for _, result := range results {
+			template, err := generateTemplate(result, report.Structs, addresses, networks)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", result.SourcePath(), err)
+			}
+			templates = append(templates, GeneratedTemplate{
+				Path:     path.Join(path.Dir(result.SourcePath()), strings.TrimSuffix(result.FileName, ".cdc")+".json"),
+				Template: template,
+			})
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Path < templates[j].Path
+	})
+	return templates, nil
+}
+
+// generateTemplate builds the template of a script or transaction
+func generateTemplate(result analyzer.AnalysisResult, structs map[string]analyzer.Struct, addresses map[string]map[string]string, networks []string) (Template, error) {
+	code, err := base64.StdEncoding.DecodeString(result.Base64)
+	if err != nil || len(code) == 0 {
+		return Template{}, fmt.Errorf("the report has no code, analyze with --base64")
+	}
+	body, err := analyzer.FLIXBody(code)
+	if err != nil {
+		return Template{}, err
+	}
+
+	kind := result.Type
+	if kind != "transaction" {
+		kind = "script"
+	}
+	template := Template{
+		FType:    analyzer.FLIXType,
+		FVersion: Version,
+		Data: Data{
+			Type:         kind,
+			Messages:     messages(result),
+			Cadence:      Cadence{Body: body, NetworkPins: []NetworkPin{}},
+			Dependencies: []Dependency{},
+			Parameters:   []Parameter{},
+		},
+	}
+
+	for _, imp := range result.Imports {
+		contract := Contract{Contract: strings.Trim(imp.Contract, `"`), Networks: []Network{}}
+		for _, network := range networks {
+			if address, ok := addresses[network]["0x"+contract.Contract]; ok {
+				contract.Networks = append(contract.Networks, Network{Network: network, Address: address})
+			}
+		}
+		template.Data.Dependencies = append(template.Data.Dependencies, Dependency{Contracts: []Contract{contract}})
+	}
+	for index, param := range result.Parameters {
+		template.Data.Parameters = append(template.Data.Parameters, Parameter{
+			Label:    param.Name,
+			Index:    index,
+			Type:     param.TypeStr,
+			Messages: []Message{},
+		})
+	}
+	if kind == "script" {
+		template.Data.ResultSchema = ResultSchema(result, structs)
+	}
+	template.ID, err = GeneratedID(template)
+	return template, err
+}
+
+// messages returns the messages of a script or transaction in the default
+// locale and then in its translations by locale, in the order of
+// analyzer.MessageKeys
+func messages(result analyzer.AnalysisResult) []Message {
+	var locales []string
+	for locale := range result.Translations {
+		if locale != analyzer.FLIXDefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+
+	messages := []Message{}
+	for _, key := range analyzer.MessageKeys {
+		translations := []Translation{}
+		if message, ok := result.Messages[key]; ok {
+			translations = append(translations, Translation{Tag: analyzer.FLIXDefaultLocale, Translation: message})
+		}
+		for _, locale := range locales {
+			if message, ok := result.Translations[locale][key]; ok && message != "" {
+				translations = append(translations, Translation{Tag: locale, Translation: message})
+			}
+		}
+		if len(translations) > 0 {
+			messages = append(messages, Message{Key: key, I18n: translations})
+		}
+	}
+	return messages
+}
@@ -0,0 +1,283 @@
+package flix
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"golang.org/x/crypto/sha3"
+)
+
+// TemplateID computes the ID of a FLIX 1.0.0 or 1.1.0 interaction template:
+// the hex encoded SHA3-256 of the hex encoded RLP of the hashes of its fields,
+// as fcl and flixkit compute it. Extensions such as result_schema are not
+// part of the ID.
+func TemplateID(data []byte) (string, error) {
+	var header struct {
+		FType    string `json:"f_type"`
+		FVersion string `json:"f_version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return "", fmt.Errorf("failed to parse FLIX template: %w", err)
+	}
+	if header.FType != analyzer.FLIXType {
+		return "", fmt.Errorf("not a FLIX interaction template")
+	}
+
+	var fields []interface{}
+	var err error
+	switch header.FVersion {
+	case "1.0.0":
+		fields, err = templateFields100(data)
+	case "1.1.0":
+		var template Template
+		if err = json.Unmarshal(data, &template); err != nil {
+			return "", fmt.Errorf("failed to parse FLIX template: %w", err)
+		}
+		fields = templateFields110(template)
+	default:
+		return "", fmt.Errorf("unsupported FLIX version %q", header.FVersion)
+	}
+	if err != nil {
+		return "", err
+	}
+	encoded, err := rlpEncode(fields)
+	if err != nil {
+		return "", err
+	}
+	return shaHex(hex.EncodeToString(encoded)), nil
+}
+
+// GeneratedID computes the ID of a generated 1.1.0 template
+func GeneratedID(template Template) (string, error) {
+	encoded, err := rlpEncode(templateFields110(template))
+	if err != nil {
+		return "", err
+	}
+	return shaHex(hex.EncodeToString(encoded)), nil
+}
+
+// shaHex returns the hex encoded SHA3-256 of a string
+func shaHex(value string) string {
+	sum := sha3.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// templateFields110 returns the hashed fields of a 1.1.0 template
+func templateFields110(template Template) []interface{} {
+	dependencies := []interface{}{}
+	for _, dependency := range template.Data.Dependencies {
+		contracts := []interface{}{}
+		for _, contract := range dependency.Contracts {
+			networks := []interface{}{}
+			for _, network := range contract.Networks {
+				fields := []interface{}{shaHex(network.Network)}
+				if network.DependencyPin != nil {
+					fields = append(fields, shaHex(network.DependencyPin.Pin))
+				}
+				networks = append(networks, fields)
+			}
+			contracts = append(contracts, []interface{}{shaHex(contract.Contract), networks})
+		}
+		dependencies = append(dependencies, []interface{}{contracts})
+	}
+
+	parameters := append([]Parameter(nil), template.Data.Parameters...)
+	sort.SliceStable(parameters, func(i, j int) bool {
+		return parameters[i].Index < parameters[j].Index
+	})
+	encodedParameters := []interface{}{}
+	for _, param := range parameters {
+		encodedParameters = append(encodedParameters, []interface{}{
+			shaHex(param.Label),
+			[]interface{}{shaHex(fmt.Sprint(param.Index)), shaHex(param.Type), messageFields110(param.Messages)},
+		})
+	}
+
+	return []interface{}{
+		shaHex(template.FType),
+		shaHex(template.FVersion),
+		shaHex(template.Data.Type),
+		shaHex(template.Data.Interface),
+		messageFields110(template.Data.Messages),
+		shaHex(template.Data.Cadence.Body),
+		dependencies,
+		encodedParameters,
+	}
+}
+
+// messageFields110 returns the hashed fields of 1.1.0 messages
+func messageFields110(messages []Message) []interface{} {
+	fields := []interface{}{}
+	for _, message := range messages {
+		translations := []interface{}{}
+		for _, translation := range message.I18n {
+			translations = append(translations, []interface{}{shaHex(translation.Tag), shaHex(translation.Translation)})
+		}
+		fields = append(fields, []interface{}{shaHex(message.Key), translations})
+	}
+	return fields
+}
+
+// orderedObject is a JSON object with the order of its keys, which the IDs of
+// 1.0.0 templates depend on
+type orderedObject []orderedField
+
+// orderedField is a key of an orderedObject and its raw value
+type orderedField struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// UnmarshalJSON decodes a JSON object keeping the order of its keys. null
+// decodes into no keys.
+func (o *orderedObject) UnmarshalJSON(data []byte) error {
+	*o = nil
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object")
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		*o = append(*o, orderedField{Key: token.(string), Value: value})
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// templateFields100 returns the hashed fields of a 1.0.0 template
+func templateFields100(data []byte) ([]interface{}, error) {
+	var template struct {
+		FType    string `json:"f_type"`
+		FVersion string `json:"f_version"`
+		Data     struct {
+			Type         string        `json:"type"`
+			Interface    string        `json:"interface"`
+			Messages     orderedObject `json:"messages"`
+			Cadence      string        `json:"cadence"`
+			Dependencies orderedObject `json:"dependencies"`
+			Arguments    orderedObject `json:"arguments"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse FLIX template: %w", err)
+	}
+
+	messages, err := messageFields100(template.Data.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies := []interface{}{}
+	for _, placeholder := range template.Data.Dependencies {
+		var contracts orderedObject
+		if err := json.Unmarshal(placeholder.Value, &contracts); err != nil {
+			return nil, fmt.Errorf("failed to parse FLIX dependencies: %w", err)
+		}
+		encodedContracts := []interface{}{}
+		for _, contract := range contracts {
+			var networks orderedObject
+			if err := json.Unmarshal(contract.Value, &networks); err != nil {
+				return nil, fmt.Errorf("failed to parse FLIX dependencies: %w", err)
+			}
+			encodedNetworks := []interface{}{}
+			for _, network := range networks {
+				var pin struct {
+					Address        string      `json:"address"`
+					FqAddress      string      `json:"fq_address"`
+					Contract       string      `json:"contract"`
+					Pin            string      `json:"pin"`
+					PinBlockHeight json.Number `json:"pin_block_height"`
+				}
+				if err := json.Unmarshal(network.Value, &pin); err != nil {
+					return nil, fmt.Errorf("failed to parse FLIX dependencies: %w", err)
+				}
+				encodedNetworks = append(encodedNetworks, []interface{}{
+					shaHex(network.Key),
+					[]interface{}{
+						shaHex(pin.Address),
+						shaHex(pin.FqAddress),
+						shaHex(pin.Contract),
+						shaHex(pin.Pin),
+						shaHex(pin.PinBlockHeight.String()),
+					},
+				})
+			}
+			encodedContracts = append(encodedContracts, []interface{}{shaHex(contract.Key), encodedNetworks})
+		}
+		dependencies = append(dependencies, []interface{}{shaHex(placeholder.Key), encodedContracts})
+	}
+
+	arguments := []interface{}{}
+	for _, argument := range template.Data.Arguments {
+		var value struct {
+			Index    json.Number   `json:"index"`
+			Type     string        `json:"type"`
+			Balance  string        `json:"balance"`
+			Messages orderedObject `json:"messages"`
+		}
+		if err := json.Unmarshal(argument.Value, &value); err != nil {
+			return nil, fmt.Errorf("failed to parse FLIX arguments: %w", err)
+		}
+		argumentMessages, err := messageFields100(value.Messages)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, []interface{}{
+			shaHex(argument.Key),
+			[]interface{}{shaHex(value.Index.String()), shaHex(value.Type), shaHex(value.Balance), argumentMessages},
+		})
+	}
+
+	return []interface{}{
+		shaHex(template.FType),
+		shaHex(template.FVersion),
+		shaHex(template.Data.Type),
+		shaHex(template.Data.Interface),
+		messages,
+		shaHex(template.Data.Cadence),
+		dependencies,
+		arguments,
+	}, nil
+}
+
+// messageFields100 returns the hashed fields of 1.0.0 messages, an object of
+// i18n objects by key
+func messageFields100(messages orderedObject) ([]interface{}, error) {
+	fields := []interface{}{}
+	for _, message := range messages {
+		var value struct {
+			I18n orderedObject `json:"i18n"`
+		}
+		if err := json.Unmarshal(message.Value, &value); err != nil {
+			return nil, fmt.Errorf("failed to parse FLIX messages: %w", err)
+		}
+		translations := []interface{}{}
+		for _, translation := range value.I18n {
+			var text string
+			if err := json.Unmarshal(translation.Value, &text); err != nil {
+				return nil, fmt.Errorf("failed to parse FLIX messages: %w", err)
+			}
+			translations = append(translations, []interface{}{shaHex(translation.Key), shaHex(text)})
+		}
+		fields = append(fields, []interface{}{shaHex(message.Key), translations})
+	}
+	return fields, nil
+}
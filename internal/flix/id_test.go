@@ -0,0 +1,37 @@
+package flix
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestTemplateID(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"1.1.0", "testdata/hello_world_1.1.0.json"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := os.ReadFile(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var template struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(data, &template); err != nil {
+				t.Fatal(err)
+			}
+			id, err := TemplateID(data)
+			if err != nil {
+				t.Fatalf("TemplateID() error = %v", err)
+			}
+			if id != template.ID {
+				t.Errorf("TemplateID() = %s, want %s", id, template.ID)
+			}
+		})
+	}
+}
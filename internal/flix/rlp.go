@@ -0,0 +1,41 @@
+package flix
+
+import "fmt"
+
+// rlpEncode encodes a value of strings and lists of values with the
+// recursive length prefix encoding FLIX template IDs hash
+func rlpEncode(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		data := []byte(v)
+		if len(data) == 1 && data[0] < 0x80 {
+			return data, nil
+		}
+		return append(rlpLength(len(data), 0x80), data...), nil
+	case []interface{}:
+		var payload []byte
+		for _, item := range v {
+			encoded, err := rlpEncode(item)
+			if err != nil {
+				return nil, err
+			}
+			payload = append(payload, encoded...)
+		}
+		return append(rlpLength(len(payload), 0xc0), payload...), nil
+	default:
+		return nil, fmt.Errorf("cannot RLP encode %T", value)
+	}
+}
+
+// rlpLength returns the prefix of a string or list payload of length n, with
+// offset 0x80 for strings and 0xc0 for lists
+func rlpLength(n int, offset byte) []byte {
+	if n < 56 {
+		return []byte{offset + byte(n)}
+	}
+	var length []byte
+	for ; n > 0; n >>= 8 {
+		length = append([]byte{byte(n)}, length...)
+	}
+	return append([]byte{offset + 55 + byte(len(length))}, length...)
+}
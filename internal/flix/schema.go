@@ -0,0 +1,162 @@
+package flix
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// schemaDialect is the JSON schema version of result schemas
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// scalarSchemas are the JSON schemas of Cadence scalar values as fcl decodes
+// them: integers beyond 64 bits and fixed point numbers are strings
+var scalarSchemas = map[string]map[string]interface{}{
+	"String":    {"type": "string"},
+	"Character": {"type": "string"},
+	"Bool":      {"type": "boolean"},
+	"Int":       {"type": "integer"},
+	"Int8":      {"type": "integer", "minimum": -128, "maximum": 127},
+	"Int16":     {"type": "integer", "minimum": -32768, "maximum": 32767},
+	"Int32":     {"type": "integer", "minimum": -2147483648, "maximum": 2147483647},
+	"Int64":     {"type": "integer"},
+	"Int128":    {"type": "string", "pattern": `^-?\d+$`},
+	"Int256":    {"type": "string", "pattern": `^-?\d+$`},
+	"UInt":      {"type": "integer", "minimum": 0},
+	"UInt8":     {"type": "integer", "minimum": 0, "maximum": 255},
+	"UInt16":    {"type": "integer", "minimum": 0, "maximum": 65535},
+	"UInt32":    {"type": "integer", "minimum": 0, "maximum": 4294967295},
+	"UInt64":    {"type": "integer", "minimum": 0},
+	"UInt128":   {"type": "string", "pattern": `^\d+$`},
+	"UInt256":   {"type": "string", "pattern": `^\d+$`},
+	"UFix64":    {"type": "string", "pattern": `^\d+\.\d{1,8}$`},
+	"Fix64":     {"type": "string", "pattern": `^-?\d+\.\d{1,8}$`},
+	"Address":   {"type": "string", "pattern": "^0x[0-9a-fA-F]{1,16}$"},
+}
+
+// ResultSchema returns the JSON schema of the decoded result of a script, with
+// the structs of report it returns under $defs, or nil if the script returns
+// nothing. Scripts returning a union of types with // codegen:returns accept
+// any of them.
+func ResultSchema(result analyzer.AnalysisResult, structs map[string]analyzer.Struct) map[string]interface{} {
+	if result.ReturnType == "" {
+		return nil
+	}
+	defs := make(map[string]interface{})
+	var schema map[string]interface{}
+	if len(result.ReturnUnion) > 0 {
+		var members []interface{}
+		for _, member := range result.ReturnUnion {
+			members = append(members, typeSchema(member, structs, defs))
+		}
+		schema = map[string]interface{}{"anyOf": members}
+	} else {
+		schema = typeSchema(result.ReturnType, structs, defs)
+	}
+
+	root := map[string]interface{}{"$schema": schemaDialect}
+	for key, value := range schema {
+		root[key] = value
+	}
+	if len(defs) > 0 {
+		root["$defs"] = defs
+	}
+	return root
+}
+
+// typeSchema returns the JSON schema of a Cadence type, adding the schemas of
+// the structs it references to defs. Types without a known schema, such as
+// AnyStruct, accept any value.
+func typeSchema(typeStr string, structs map[string]analyzer.Struct, defs map[string]interface{}) map[string]interface{} {
+	typeStr = strings.TrimLeft(strings.TrimSpace(typeStr), "@&")
+	switch {
+	case strings.HasSuffix(typeStr, "?"):
+		return map[string]interface{}{
+			"anyOf": []interface{}{
+				typeSchema(strings.TrimSuffix(typeStr, "?"), structs, defs),
+				map[string]interface{}{"type": "null"},
+			},
+		}
+	case strings.HasPrefix(typeStr, "[") && strings.HasSuffix(typeStr, "]"):
+		inner := typeStr[1 : len(typeStr)-1]
+		element, size, sized := splitTopLevel(inner, ';')
+		if !sized {
+			element = inner
+		}
+		schema := map[string]interface{}{"type": "array", "items": typeSchema(element, structs, defs)}
+		if n, err := strconv.Atoi(strings.TrimSpace(size)); sized && err == nil {
+			schema["minItems"] = n
+			schema["maxItems"] = n
+		}
+		return schema
+	case strings.HasPrefix(typeStr, "{") && strings.HasSuffix(typeStr, "}"):
+		// Dictionaries are decoded into objects, intersection types are any value
+		if _, value, ok := splitTopLevel(typeStr[1:len(typeStr)-1], ':'); ok {
+			return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(value, structs, defs)}
+		}
+		return map[string]interface{}{}
+	}
+
+	if scalar, ok := scalarSchemas[typeStr]; ok {
+		schema := make(map[string]interface{}, len(scalar))
+		for key, value := range scalar {
+			schema[key] = value
+		}
+		return schema
+	}
+	if name, composite, ok := findStruct(typeStr, structs); ok {
+		ref := map[string]interface{}{"$ref": "#/$defs/" + name}
+		if _, ok := defs[name]; ok {
+			return ref
+		}
+		// Recorded before its fields so that recursive structs refer to it
+		defs[name] = nil
+		properties := make(map[string]interface{})
+		required := make([]interface{}, 0)
+		for _, field := range composite.Fields {
+			properties[field.Name] = typeSchema(field.TypeStr, structs, defs)
+			if !field.Optional && !strings.HasSuffix(field.TypeStr, "?") {
+				required = append(required, field.Name)
+			}
+		}
+		defs[name] = map[string]interface{}{
+			"type":       "object",
+			"title":      typeStr,
+			"properties": properties,
+			"required":   required,
+		}
+		return ref
+	}
+	return map[string]interface{}{}
+}
+
+// findStruct returns the struct of report named typeStr, keyed with or without
+// the dot of structs declared in contracts, and the name of its schema
+func findStruct(typeStr string, structs map[string]analyzer.Struct) (string, analyzer.Struct, bool) {
+	for _, name := range []string{typeStr, strings.ReplaceAll(typeStr, ".", "")} {
+		if composite, ok := structs[name]; ok {
+			return strings.ReplaceAll(name, ".", ""), composite, true
+		}
+	}
+	return "", analyzer.Struct{}, false
+}
+
+// splitTopLevel splits s at the first separator outside of brackets, braces,
+// parentheses and angle brackets
+func splitTopLevel(s string, separator byte) (string, string, bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[', '{', '(', '<':
+			depth++
+		case ']', '}', ')', '>':
+			depth--
+		case separator:
+			if depth == 0 {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+	return s, "", false
+}
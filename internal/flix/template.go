@@ -0,0 +1,87 @@
+package flix
+
+// Version is the FLIX version of generated templates
+const Version = "1.1.0"
+
+// Template is a FLIX 1.1.0 interaction template
+type Template struct {
+	FType    string `json:"f_type"`
+	FVersion string `json:"f_version"`
+	ID       string `json:"id"`
+	Data     Data   `json:"data"`
+}
+
+// Data is the interaction of a template
+type Data struct {
+	Type         string       `json:"type"`
+	Interface    string       `json:"interface"`
+	Messages     []Message    `json:"messages"`
+	Cadence      Cadence      `json:"cadence"`
+	Dependencies []Dependency `json:"dependencies"`
+	Parameters   []Parameter  `json:"parameters"`
+	// ResultSchema is the JSON schema of the decoded result of a script. It
+	// extends FLIX and is not part of the template ID.
+	ResultSchema map[string]interface{} `json:"result_schema,omitempty"`
+}
+
+// Message is a user-facing message of a template or parameter by key, e.g.
+// title, in every locale
+type Message struct {
+	Key  string        `json:"key"`
+	I18n []Translation `json:"i18n"`
+}
+
+// Translation is a message in the locale of its tag, e.g. en-US
+type Translation struct {
+	Tag         string `json:"tag"`
+	Translation string `json:"translation"`
+}
+
+// Cadence is the code of a template, with imports by contract name
+type Cadence struct {
+	Body        string       `json:"body"`
+	NetworkPins []NetworkPin `json:"network_pins"`
+}
+
+// NetworkPin is the hash of the code with the imports of a network resolved
+type NetworkPin struct {
+	Network string `json:"network"`
+	PinSelf string `json:"pin_self"`
+}
+
+// Dependency groups the contracts of an import
+type Dependency struct {
+	Contracts []Contract `json:"contracts"`
+}
+
+// Contract is an imported contract and its address per network
+type Contract struct {
+	Contract string    `json:"contract"`
+	Networks []Network `json:"networks"`
+}
+
+// Network is the address of a contract on a network, pinned to its code at a
+// block height if the pin is known
+type Network struct {
+	Network                  string         `json:"network"`
+	Address                  string         `json:"address"`
+	DependencyPinBlockHeight uint64         `json:"dependency_pin_block_height"`
+	DependencyPin            *DependencyPin `json:"dependency_pin,omitempty"`
+}
+
+// DependencyPin is the hash of a deployed contract and of its imports
+type DependencyPin struct {
+	Pin                string          `json:"pin"`
+	PinSelf            string          `json:"pin_self"`
+	PinContractName    string          `json:"pin_contract_name"`
+	PinContractAddress string          `json:"pin_contract_address"`
+	Imports            []DependencyPin `json:"imports"`
+}
+
+// Parameter is an argument of the interaction
+type Parameter struct {
+	Label    string    `json:"label"`
+	Index    int       `json:"index"`
+	Type     string    `json:"type"`
+	Messages []Message `json:"messages"`
+}
@@ -0,0 +1,229 @@
+package flowcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// ProjectFile is the name of the Flow CLI project configuration
+const ProjectFile = "flow.json"
+
+// contract is a contract or dependency entry of flow.json, which is either
+// a source path or an object with a source and per network aliases
+type contract struct {
+	Source  string            `json:"source"`
+	Aliases map[string]string `json:"aliases"`
+}
+
+// UnmarshalJSON accepts both the string and the object form
+func (c *contract) UnmarshalJSON(data []byte) error {
+	var source string
+	if err := json.Unmarshal(data, &source); err == nil {
+		c.Source = source
+		return nil
+	}
+	type plain contract
+	return json.Unmarshal(data, (*plain)(c))
+}
+
+// account is an account entry of flow.json, which is either an address or
+// an object with an address
+type account struct {
+	Address string `json:"address"`
+}
+
+// UnmarshalJSON accepts both the string and the object form
+func (a *account) UnmarshalJSON(data []byte) error {
+	var address string
+	if err := json.Unmarshal(data, &address); err == nil {
+		a.Address = address
+		return nil
+	}
+	type plain account
+	return json.Unmarshal(data, (*plain)(a))
+}
+
+// deployment is a deployed contract of flow.json, which is either a contract
+// name or an object with a name and init arguments
+type deployment struct {
+	Name string `json:"name"`
+}
+
+// UnmarshalJSON accepts both the string and the object form
+func (d *deployment) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		d.Name = name
+		return nil
+	}
+	type plain deployment
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+// Project is the part of a Flow CLI project configuration used for code generation
+type Project struct {
+	Dir          string                             `json:"-"`
+	Contracts    map[string]contract                `json:"contracts"`
+	Dependencies map[string]contract                `json:"dependencies"`
+	Accounts     map[string]account                 `json:"accounts"`
+	Deployments  map[string]map[string][]deployment `json:"deployments"`
+}
+
+// LoadProject reads flow.json from a project directory
+func LoadProject(dir string) (*Project, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ProjectFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ProjectFile, err)
+	}
+
+	project := &Project{Dir: dir}
+	if err := json.Unmarshal(data, project); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ProjectFile, err)
+	}
+	return project, nil
+}
+
+// withPrefix adds the 0x prefix to an address
+func withPrefix(address string) string {
+	if strings.HasPrefix(address, "0x") {
+		return address
+	}
+	return "0x" + address
+}
+
+// Addresses returns the contract addresses per network in the addresses.json
+// format, keyed by import alias (e.g. 0xFungibleToken). Addresses come from
+// contract and dependency aliases and from deployments.
+func (p *Project) Addresses() map[string]interface{} {
+	addresses := make(map[string]map[string]string)
+	set := func(network string, name string, address string) {
+		if addresses[network] == nil {
+			addresses[network] = make(map[string]string)
+		}
+		addresses[network]["0x"+name] = withPrefix(address)
+	}
+
+	for _, entries := range []map[string]contract{p.Dependencies, p.Contracts} {
+		for name, c := range entries {
+			for network, address := range c.Aliases {
+				set(network, name, address)
+			}
+		}
+	}
+	for network, accounts := range p.Deployments {
+		for accountName, contracts := range accounts {
+			acct, ok := p.Accounts[accountName]
+			if !ok || acct.Address == "" {
+				continue
+			}
+			for _, d := range contracts {
+				set(network, d.Name, acct.Address)
+			}
+		}
+	}
+
+	result := make(map[string]interface{})
+	for network, entries := range addresses {
+		networkAddresses := make(map[string]interface{})
+		for alias, address := range entries {
+			networkAddresses[alias] = address
+		}
+		result[network] = networkAddresses
+	}
+	return result
+}
+
+// Sources returns the directories holding scripts and transactions, following
+// the layout created by flow init (cadence/scripts, cadence/transactions) with
+// scripts and transactions at the project root as fallback
+func (p *Project) Sources() []string {
+	var dirs []string
+	for _, dir := range []string{"cadence/scripts", "cadence/transactions", "scripts", "transactions"} {
+		path := filepath.Join(p.Dir, dir)
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			dirs = append(dirs, path)
+		}
+	}
+	return dirs
+}
+
+// Alias maps a generated function to the file used by the Flow CLI
+type Alias struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	File    string `json:"file"`
+	Command string `json:"command"`
+}
+
+// Catalog holds the Flow CLI aliases of a project and its contract addresses
+type Catalog struct {
+	Aliases   []Alias                `json:"aliases"`
+	Addresses map[string]interface{} `json:"addresses"`
+}
+
+// Catalog analyzes the script and transaction directories of the project and
+// returns the Flow CLI command of every generated function. nameFunc converts
+// a file name into the generated function name.
+func (p *Project) Catalog(nameFunc func(filename string) string) (*Catalog, error) {
+	catalog := &Catalog{
+		Aliases:   make([]Alias, 0),
+		Addresses: p.Addresses(),
+	}
+
+	for _, dir := range p.Sources() {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || filepath.Ext(path) != ".cdc" {
+				return nil
+			}
+
+			result, err := analyzer.New().AnalyzeFile(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to analyze %s: %v\n", path, err)
+				return nil
+			}
+
+			rel, err := filepath.Rel(p.Dir, path)
+			if err != nil {
+				return fmt.Errorf("failed to resolve path: %w", err)
+			}
+			rel = "./" + filepath.ToSlash(rel)
+
+			var args []string
+			for _, param := range result.Parameters {
+				args = append(args, fmt.Sprintf("<%s:%s>", param.Name, param.TypeStr))
+			}
+			command := "flow scripts execute " + rel
+			if result.Type == "transaction" {
+				command = "flow transactions send " + rel
+			}
+			if len(args) > 0 {
+				command += " " + strings.Join(args, " ")
+			}
+
+			catalog.Aliases = append(catalog.Aliases, Alias{
+				Name:    nameFunc(result.FileName),
+				Type:    result.Type,
+				File:    rel,
+				Command: command,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk %s: %w", dir, err)
+		}
+	}
+
+	sort.Slice(catalog.Aliases, func(i, j int) bool {
+		return catalog.Aliases[i].Name < catalog.Aliases[j].Name
+	})
+	return catalog, nil
+}
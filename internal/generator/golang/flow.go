@@ -0,0 +1,169 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// flowTransportCode implements the Transport with the access API client of
+// flow-go-sdk
+const flowTransportCode = `
+// FlowSigner is an account key signing transactions
+type FlowSigner struct {
+	Address  flow.Address
+	KeyIndex uint32
+	Signer   crypto.Signer
+}
+
+// FlowTransport runs scripts and transactions with a flow-go-sdk access
+// client. Signer proposes, pays for and authorizes every transaction. The
+// imports of the code are resolved with the contract addresses of Network.
+type FlowTransport struct {
+	Client       access.Client
+	Network      string
+	Signer       FlowSigner
+	ComputeLimit uint64
+}
+
+var _ Transport = (*FlowTransport)(nil)
+
+// NewFlowClient creates a Client running scripts and transactions with
+// client on network, e.g. testnet, signing transactions with signer
+func NewFlowClient(client access.Client, network string, signer FlowSigner) *Client {
+	return NewClient(&FlowTransport{Client: client, Network: network, Signer: signer, ComputeLimit: 9999})
+}
+
+var (
+	// aliasImportPattern matches the address of an import declaration, e.g.
+	// from 0xFungibleToken
+	aliasImportPattern = regexp.MustCompile(` + "`" + `(\bfrom\s+)(0x\w+)` + "`" + `)
+	// stringImportPattern matches an import by contract name, e.g.
+	// import "FungibleToken"
+	stringImportPattern = regexp.MustCompile(` + "`" + `\bimport\s+"(\w+)"` + "`" + `)
+	// hexAddressPattern matches an address that needs no resolution
+	hexAddressPattern = regexp.MustCompile(` + "`" + `^0x[0-9a-fA-F]{1,16}$` + "`" + `)
+)
+
+// resolveImports replaces the import aliases of code, e.g. 0xFungibleToken,
+// and the imports by contract name with the contract addresses of network,
+// since flow-go-sdk sends the code as is
+func resolveImports(code string, network string) (string, error) {
+	addresses := contractAddresses[network]
+	var missing []string
+	code = aliasImportPattern.ReplaceAllStringFunc(code, func(match string) string {
+		groups := aliasImportPattern.FindStringSubmatch(match)
+		if address, ok := addresses[groups[2]]; ok {
+			return groups[1] + address
+		}
+		if !hexAddressPattern.MatchString(groups[2]) {
+			missing = append(missing, groups[2])
+		}
+		return match
+	})
+	code = stringImportPattern.ReplaceAllStringFunc(code, func(match string) string {
+		name := stringImportPattern.FindStringSubmatch(match)[1]
+		if address, ok := addresses["0x"+name]; ok {
+			return "import " + name + " from " + address
+		}
+		missing = append(missing, name)
+		return match
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("no %q address of %s", network, strings.Join(missing, ", "))
+	}
+	return code, nil
+}
+
+// ExecuteScript executes script at the latest sealed block
+func (t *FlowTransport) ExecuteScript(ctx context.Context, script Interaction) ([]byte, error) {
+	arguments := make([]cadence.Value, 0, len(script.Arguments))
+	for _, data := range script.Arguments {
+		argument, err := jsoncdc.Decode(nil, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode argument: %w", err)
+		}
+		arguments = append(arguments, argument)
+	}
+	code, err := resolveImports(script.Code, t.Network)
+	if err != nil {
+		return nil, err
+	}
+	value, err := t.Client.ExecuteScriptAtLatestBlock(ctx, []byte(code), arguments)
+	if err != nil {
+		return nil, err
+	}
+	return jsoncdc.Encode(value)
+}
+
+// SendTransaction signs transaction with Signer and sends it
+func (t *FlowTransport) SendTransaction(ctx context.Context, transaction Interaction) (string, error) {
+	code, err := resolveImports(transaction.Code, t.Network)
+	if err != nil {
+		return "", err
+	}
+	block, err := t.Client.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to get latest block: %w", err)
+	}
+	account, err := t.Client.GetAccount(ctx, t.Signer.Address)
+	if err != nil {
+		return "", fmt.Errorf("failed to get signer account: %w", err)
+	}
+	if int(t.Signer.KeyIndex) >= len(account.Keys) {
+		return "", fmt.Errorf("signer account has no key %d", t.Signer.KeyIndex)
+	}
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(code)).
+		SetReferenceBlockID(block.ID).
+		SetComputeLimit(t.ComputeLimit).
+		SetProposalKey(t.Signer.Address, t.Signer.KeyIndex, account.Keys[t.Signer.KeyIndex].SequenceNumber).
+		SetPayer(t.Signer.Address)
+	for i := 0; i < transaction.Authorizers; i++ {
+		tx.AddAuthorizer(t.Signer.Address)
+	}
+	for _, argument := range transaction.Arguments {
+		tx.AddRawArgument(argument)
+	}
+	if err := tx.SignEnvelope(t.Signer.Address, t.Signer.KeyIndex, t.Signer.Signer); err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	if err := t.Client.SendTransaction(ctx, *tx); err != nil {
+		return "", err
+	}
+	return tx.ID().Hex(), nil
+}
+`
+
+// SetFlowSDK enables the Transport interface, the Client using it and the
+// FlowTransport running it with flow-go-sdk
+func (g *Generator) SetFlowSDK(flowSDK bool) {
+	g.FlowSDK = flowSDK
+}
+
+// writeContractAddresses writes the contract addresses of the report by
+// network and import alias, sorted, as the contractAddresses map
+func (g *Generator) writeContractAddresses(buffer *bytes.Buffer) {
+	buffer.WriteString("\n// contractAddresses are the contract addresses by network and import alias\n")
+	buffer.WriteString("var contractAddresses = map[string]map[string]string{\n")
+	contracts := g.Report.ContractAddresses()
+	var networks []string
+	for network := range contracts {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	for _, network := range networks {
+		var aliases []string
+		for alias := range contracts[network] {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+		fmt.Fprintf(buffer, "\t%q: {\n", network)
+		for _, alias := range aliases {
+			fmt.Fprintf(buffer, "\t\t%q: %q,\n", alias, contracts[network][alias])
+		}
+		buffer.WriteString("\t},\n")
+	}
+	buffer.WriteString("}\n")
+}
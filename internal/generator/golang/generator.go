@@ -0,0 +1,394 @@
+package golang
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// Generator handles Go code generation
+type Generator struct {
+	Report      analyzer.Report
+	PackageName string
+	Mock        bool
+	FlowSDK     bool
+	Names       map[string]string // Function names by file name, overriding the derived names
+}
+
+// New creates a new Go code generator
+func New(report analyzer.Report) *Generator {
+	return &Generator{
+		Report:      report,
+		PackageName: "cadencegen",
+	}
+}
+
+// SetPackageName sets the package name of the generated code
+func (g *Generator) SetPackageName(name string) {
+	g.PackageName = name
+}
+
+// typeMapping maps Cadence types to Go types
+var typeMapping = map[string]string{
+	"String":    "string",
+	"Character": "string",
+	"Address":   "string",
+	"Bool":      "bool",
+	"UFix64":    "string",
+	"Fix64":     "string",
+	"Int":       "*big.Int",
+	"UInt":      "*big.Int",
+	"Int8":      "int8",
+	"Int16":     "int16",
+	"Int32":     "int32",
+	"Int64":     "int64",
+	"Int128":    "*big.Int",
+	"Int256":    "*big.Int",
+	"UInt8":     "uint8",
+	"UInt16":    "uint16",
+	"UInt32":    "uint32",
+	"UInt64":    "uint64",
+	"UInt128":   "*big.Int",
+	"UInt256":   "*big.Int",
+	"Word8":     "uint8",
+	"Word16":    "uint16",
+	"Word32":    "uint32",
+	"Word64":    "uint64",
+	"AnyStruct": "interface{}",
+}
+
+// GoStruct represents a struct in the generated Go code
+type GoStruct struct {
+	Name        string
+	CadenceName string
+	Fields      []GoField
+}
+
+// GoField represents a field in a generated Go struct
+type GoField struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+// GoFunction represents the codec helpers of a script or transaction
+type GoFunction struct {
+	Name        string
+	SourceName  string
+	Type        string
+	Parameters  []GoParameter
+	ReturnType  string
+	Code        string // Cadence code, executed by the generated Client
+	Authorizers int    // Number of prepare parameters of a transaction
+	Source      string // Path of the originating .cdc file
+	Hash        string // Hex SHA-256 of the Cadence code
+	Version     string // Version of cadence-codegen that produced the report
+}
+
+// GoParameter represents a parameter of a generated Go function
+type GoParameter struct {
+	Name    string
+	Type    string
+	TypeStr string // Original Cadence type string
+}
+
+const fileTemplate = `// Code generated by cadence-codegen. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+{{- if or .Mock .FlowSDK}}
+	"context"
+{{- end}}
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+{{- if .FlowSDK}}
+	"regexp"
+{{- end}}
+	"sort"
+	"strconv"
+	"strings"
+{{- if .Mock}}
+	"sync"
+{{- end}}
+{{- if .FlowSDK}}
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flow "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/crypto"
+{{- end}}
+)
+{{range .Structs}}
+// {{.Name}} is generated from the Cadence struct {{.CadenceName}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+}
+{{end}}
+{{- range .Functions}}
+// Encode{{.Name}}Arguments encodes the arguments of the {{.SourceName}} {{.Type}} as JSON-Cadence
+{{template "provenance" .}}
+func Encode{{.Name}}Arguments({{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}} {{$param.Type}}{{end}}) ([][]byte, error) {
+	return encodeArguments(
+		{{- range .Parameters}}
+		argument{cadenceType: {{printf "%q" .TypeStr}}, value: {{.Name}}},
+		{{- end}}
+	)
+}
+{{if .ReturnType}}
+// Decode{{.Name}}Result decodes the JSON-Cadence result of the {{.SourceName}} script
+{{template "provenance" .}}
+func Decode{{.Name}}Result(data []byte) ({{.ReturnType}}, error) {
+	var result {{.ReturnType}}
+	if err := DecodeValue(data, &result); err != nil {
+		return result, fmt.Errorf("failed to decode {{.SourceName}} result: %w", err)
+	}
+	return result, nil
+}
+{{end}}
+{{- end}}
+{{- define "provenance"}}//
+// Source: {{.Source}}
+{{- if .Hash}}
+// SHA-256: {{.Hash}}
+{{- end}}
+// Generated by cadence-codegen{{if .Version}} {{.Version}}{{end}}
+{{- end}}`
+
+// exportName converts an identifier into an exported Go identifier
+func exportName(name string) string {
+	if name == "" {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// FunctionName returns the name of the generated function for a Cadence file
+func FunctionName(filename string) string {
+	return formatFunctionName(filename)
+}
+
+// formatFunctionName formats the filename into an exported Go function name
+func formatFunctionName(filename string) string {
+	name := strings.TrimSuffix(filename, ".cdc")
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-'
+	})
+	for i := range parts {
+		parts[i] = exportName(strings.ToLower(parts[i]))
+	}
+	return strings.Join(parts, "")
+}
+
+// paramName makes a Cadence parameter name safe to use as a Go identifier
+func paramName(name string) string {
+	switch name {
+	case "break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough",
+		"for", "func", "go", "goto", "if", "import", "interface", "map", "package", "range",
+		"return", "select", "struct", "switch", "type", "var":
+		return name + "Arg"
+	}
+	return name
+}
+
+// splitDictionaryType splits the inner part of a dictionary type at the top level colon
+func splitDictionaryType(inner string) (string, string, bool) {
+	depth := 0
+	for i, r := range inner {
+		switch r {
+		case '[', '{', '<', '(':
+			depth++
+		case ']', '}', '>', ')':
+			depth--
+		case ':':
+			if depth == 0 {
+				return strings.TrimSpace(inner[:i]), strings.TrimSpace(inner[i+1:]), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// convertCadenceTypeToGo converts a Cadence type to its Go equivalent
+func (g *Generator) convertCadenceTypeToGo(cadenceType string) string {
+	cadenceType = strings.TrimSpace(cadenceType)
+
+	// Optional types become pointers, except types that are already nilable
+	if strings.HasSuffix(cadenceType, "?") {
+		goType := g.convertCadenceTypeToGo(strings.TrimSuffix(cadenceType, "?"))
+		if strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") ||
+			strings.HasPrefix(goType, "map[") || goType == "interface{}" {
+			return goType
+		}
+		return "*" + goType
+	}
+
+	if strings.HasPrefix(cadenceType, "[") && strings.HasSuffix(cadenceType, "]") {
+		elementType := strings.TrimSuffix(strings.TrimPrefix(cadenceType, "["), "]")
+		if idx := strings.Index(elementType, ";"); idx >= 0 {
+			elementType = elementType[:idx]
+		}
+		return "[]" + g.convertCadenceTypeToGo(elementType)
+	}
+
+	if strings.HasPrefix(cadenceType, "{") && strings.HasSuffix(cadenceType, "}") {
+		keyType, valueType, ok := splitDictionaryType(strings.TrimSuffix(strings.TrimPrefix(cadenceType, "{"), "}"))
+		if !ok {
+			return "interface{}"
+		}
+		goKeyType := g.convertCadenceTypeToGo(keyType)
+		if strings.HasPrefix(goKeyType, "*") {
+			// Pointers make poor map keys, use the decimal string instead
+			goKeyType = "string"
+		}
+		return fmt.Sprintf("map[%s]%s", goKeyType, g.convertCadenceTypeToGo(valueType))
+	}
+
+	if goType, ok := typeMapping[cadenceType]; ok {
+		return goType
+	}
+
+	structName := strings.ReplaceAll(cadenceType, ".", "")
+	if _, ok := g.Report.Structs[structName]; ok {
+		return exportName(structName)
+	}
+
+	return "interface{}"
+}
+
+// decodeCode decodes the base64 encoded Cadence code of a report entry
+func decodeCode(encoded string) string {
+	code, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return ""
+	}
+	return string(code)
+}
+
+// buildStructs converts the report structs into Go structs sorted by name
+func (g *Generator) buildStructs() []GoStruct {
+	var names []string
+	for name := range g.Report.Structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var structs []GoStruct
+	for _, name := range names {
+		composite := g.Report.Structs[name]
+		goStruct := GoStruct{
+			Name:        exportName(name),
+			CadenceName: composite.Name,
+		}
+		for _, field := range composite.Fields {
+			typeStr := field.TypeStr
+			if field.Optional && !strings.HasSuffix(typeStr, "?") {
+				typeStr += "?"
+			}
+			goStruct.Fields = append(goStruct.Fields, GoField{
+				Name: exportName(field.Name),
+				Type: g.convertCadenceTypeToGo(typeStr),
+				Tag:  fmt.Sprintf("`cadence:\"%s,%s\" json:\"%s\"`", field.Name, typeStr, field.Name),
+			})
+		}
+		structs = append(structs, goStruct)
+	}
+	return structs
+}
+
+// buildFunctions converts the report scripts and transactions into Go functions sorted by name
+func (g *Generator) buildFunctions() []GoFunction {
+	var functions []GoFunction
+	add := func(results map[string]analyzer.AnalysisResult, kind string) {
+		for filename, result := range results {
+			function := GoFunction{
+				Name:        g.functionName(filename),
+				SourceName:  strings.TrimSuffix(filename, ".cdc"),
+				Type:        kind,
+				Code:        decodeCode(result.Base64),
+				Source:      result.SourcePath(),
+				Hash:        result.CodeHash(),
+				Version:     g.Report.CodegenVersion,
+				Authorizers: len(result.Signers),
+			}
+			for _, param := range result.Parameters {
+				function.Parameters = append(function.Parameters, GoParameter{
+					Name:    paramName(param.Name),
+					Type:    g.convertCadenceTypeToGo(param.TypeStr),
+					TypeStr: param.TypeStr,
+				})
+			}
+			if kind == "script" && result.ReturnType != "" {
+				function.ReturnType = g.convertCadenceTypeToGo(result.ReturnType)
+			}
+			functions = append(functions, function)
+		}
+	}
+	add(g.Report.Transactions, "transaction")
+	add(g.Report.Scripts, "script")
+
+	sort.Slice(functions, func(i, j int) bool {
+		return functions[i].Name < functions[j].Name
+	})
+	return functions
+}
+
+// Generate generates Go code for all structs, transactions and scripts
+func (g *Generator) Generate() (string, error) {
+	tmpl, err := template.New("go").Parse(fileTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	functions := g.buildFunctions()
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		PackageName string
+		Mock        bool
+		FlowSDK     bool
+		Structs     []GoStruct
+		Functions   []GoFunction
+	}{
+		PackageName: g.PackageName,
+		Mock:        g.Mock,
+		FlowSDK:     g.FlowSDK,
+		Structs:     g.buildStructs(),
+		Functions:   functions,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	buffer.WriteString(runtimeCode)
+
+	if g.Mock || g.FlowSDK {
+		if err := g.writeTransport(&buffer, functions); err != nil {
+			return "", err
+		}
+	}
+	if g.FlowSDK {
+		buffer.WriteString(flowTransportCode)
+		g.writeContractAddresses(&buffer)
+	}
+
+	// Format the code so the output is gofmt clean
+	formatted, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated Go code: %w", err)
+	}
+
+	return string(formatted), nil
+}
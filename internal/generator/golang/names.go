@@ -0,0 +1,21 @@
+package golang
+
+import "github.com/outblock/cadence-codegen/internal/names"
+
+// functionName returns the function name of the script or transaction
+// filename, as recorded in Names or else derived from the file name
+func (g *Generator) functionName(filename string) string {
+	return names.Function(g.Names, filename, formatFunctionName)
+}
+
+// FunctionNames returns the function name of every script and transaction by
+// file name
+func (g *Generator) FunctionNames() map[string]string {
+	return names.Functions(g.Report, g.Names, formatFunctionName)
+}
+
+// SetNames sets the function names of scripts and transactions by file
+// name, e.g. from a names.json of a previous run, overriding the derived names
+func (g *Generator) SetNames(names map[string]string) {
+	g.Names = names
+}
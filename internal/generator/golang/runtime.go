@@ -0,0 +1,433 @@
+package golang
+
+// runtimeCode is the JSON-Cadence codec emitted once into every generated Go file.
+// It only depends on the standard library, so services can build arguments and
+// decode results without importing the Cadence runtime.
+const runtimeCode = `
+// JSONCadence is a value in the JSON-Cadence interchange format
+type JSONCadence struct {
+	Type  string          ` + "`json:\"type\"`" + `
+	Value json.RawMessage ` + "`json:\"value,omitempty\"`" + `
+}
+
+// StructTypeIDs maps generated struct names to their fully qualified Cadence
+// type IDs (e.g. A.0x1654653399040a61.FlowToken.Vault), used when structs are
+// encoded as arguments. Structs without an entry are encoded with their name.
+var StructTypeIDs = map[string]string{}
+
+type jsonCadenceField struct {
+	Name  string      ` + "`json:\"name\"`" + `
+	Value JSONCadence ` + "`json:\"value\"`" + `
+}
+
+type jsonCadenceComposite struct {
+	ID     string             ` + "`json:\"id\"`" + `
+	Fields []jsonCadenceField ` + "`json:\"fields\"`" + `
+}
+
+type jsonCadenceEntry struct {
+	Key   JSONCadence ` + "`json:\"key\"`" + `
+	Value JSONCadence ` + "`json:\"value\"`" + `
+}
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// EncodeValue encodes a Go value as JSON-Cadence for the given Cadence type
+func EncodeValue(cadenceType string, value interface{}) ([]byte, error) {
+	encoded, err := encodeValue(cadenceType, reflect.ValueOf(value))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encoded)
+}
+
+// DecodeValue decodes a JSON-Cadence value into the Go value pointed to by out
+func DecodeValue(data []byte, out interface{}) error {
+	var value JSONCadence
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to parse JSON-Cadence: %w", err)
+	}
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("decode target must be a non-nil pointer")
+	}
+	return decodeValue(value, target.Elem())
+}
+
+type argument struct {
+	cadenceType string
+	value       interface{}
+}
+
+func encodeArguments(arguments ...argument) ([][]byte, error) {
+	encoded := make([][]byte, 0, len(arguments))
+	for _, arg := range arguments {
+		data, err := EncodeValue(arg.cadenceType, arg.value)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, data)
+	}
+	return encoded, nil
+}
+
+func rawJSON(value interface{}) json.RawMessage {
+	data, _ := json.Marshal(value)
+	return data
+}
+
+// splitDictionaryType splits "K: V" at the top level colon
+func splitDictionaryType(inner string) (string, string, error) {
+	depth := 0
+	for i, r := range inner {
+		switch r {
+		case '[', '{', '<', '(':
+			depth++
+		case ']', '}', '>', ')':
+			depth--
+		case ':':
+			if depth == 0 {
+				return strings.TrimSpace(inner[:i]), strings.TrimSpace(inner[i+1:]), nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("invalid dictionary type {%s}", inner)
+}
+
+func encodeValue(cadenceType string, v reflect.Value) (JSONCadence, error) {
+	cadenceType = strings.TrimSpace(cadenceType)
+
+	if strings.HasSuffix(cadenceType, "?") {
+		if !v.IsValid() || ((v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface ||
+			v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil()) {
+			return JSONCadence{Type: "Optional", Value: rawJSON(nil)}, nil
+		}
+		if v.Kind() == reflect.Interface || (v.Kind() == reflect.Ptr && v.Type().Elem() != bigIntType) {
+			v = v.Elem()
+		}
+		inner, err := encodeValue(strings.TrimSuffix(cadenceType, "?"), v)
+		if err != nil {
+			return JSONCadence{}, err
+		}
+		return JSONCadence{Type: "Optional", Value: rawJSON(inner)}, nil
+	}
+
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return JSONCadence{}, fmt.Errorf("missing value for %s", cadenceType)
+	}
+
+	if strings.HasPrefix(cadenceType, "[") && strings.HasSuffix(cadenceType, "]") {
+		elementType := strings.TrimSuffix(strings.TrimPrefix(cadenceType, "["), "]")
+		if idx := strings.Index(elementType, ";"); idx >= 0 {
+			elementType = elementType[:idx]
+		}
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return JSONCadence{}, fmt.Errorf("expected slice for %s, got %s", cadenceType, v.Type())
+		}
+		elements := make([]JSONCadence, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			element, err := encodeValue(elementType, v.Index(i))
+			if err != nil {
+				return JSONCadence{}, err
+			}
+			elements = append(elements, element)
+		}
+		return JSONCadence{Type: "Array", Value: rawJSON(elements)}, nil
+	}
+
+	if strings.HasPrefix(cadenceType, "{") && strings.HasSuffix(cadenceType, "}") {
+		keyType, valueType, err := splitDictionaryType(strings.TrimSuffix(strings.TrimPrefix(cadenceType, "{"), "}"))
+		if err != nil {
+			return JSONCadence{}, err
+		}
+		if v.Kind() != reflect.Map {
+			return JSONCadence{}, fmt.Errorf("expected map for %s, got %s", cadenceType, v.Type())
+		}
+		entries := make([]jsonCadenceEntry, 0, v.Len())
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			encodedKey, err := encodeValue(keyType, key)
+			if err != nil {
+				return JSONCadence{}, err
+			}
+			encodedValue, err := encodeValue(valueType, v.MapIndex(key))
+			if err != nil {
+				return JSONCadence{}, err
+			}
+			entries = append(entries, jsonCadenceEntry{Key: encodedKey, Value: encodedValue})
+		}
+		return JSONCadence{Type: "Dictionary", Value: rawJSON(entries)}, nil
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return JSONCadence{}, fmt.Errorf("nil value for %s", cadenceType)
+		}
+		if v.Type().Elem() == bigIntType {
+			return JSONCadence{Type: cadenceType, Value: rawJSON(v.Interface().(*big.Int).String())}, nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return JSONCadence{Type: cadenceType, Value: rawJSON(v.Bool())}, nil
+	case reflect.String:
+		if cadenceType == "Address" && !strings.HasPrefix(v.String(), "0x") {
+			return JSONCadence{Type: cadenceType, Value: rawJSON("0x" + v.String())}, nil
+		}
+		return JSONCadence{Type: cadenceType, Value: rawJSON(v.String())}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return JSONCadence{Type: cadenceType, Value: rawJSON(strconv.FormatInt(v.Int(), 10))}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return JSONCadence{Type: cadenceType, Value: rawJSON(strconv.FormatUint(v.Uint(), 10))}, nil
+	case reflect.Struct:
+		if v.Type() == bigIntType {
+			bigInt := v.Interface().(big.Int)
+			return JSONCadence{Type: cadenceType, Value: rawJSON(bigInt.String())}, nil
+		}
+		typeID, ok := StructTypeIDs[v.Type().Name()]
+		if !ok {
+			typeID = cadenceType
+		}
+		composite := jsonCadenceComposite{ID: typeID, Fields: []jsonCadenceField{}}
+		for i := 0; i < v.NumField(); i++ {
+			tag := v.Type().Field(i).Tag.Get("cadence")
+			parts := strings.SplitN(tag, ",", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			field, err := encodeValue(parts[1], v.Field(i))
+			if err != nil {
+				return JSONCadence{}, fmt.Errorf("field %s: %w", parts[0], err)
+			}
+			composite.Fields = append(composite.Fields, jsonCadenceField{Name: parts[0], Value: field})
+		}
+		return JSONCadence{Type: "Struct", Value: rawJSON(composite)}, nil
+	}
+
+	return JSONCadence{}, fmt.Errorf("cannot encode %s as %s", v.Type(), cadenceType)
+}
+
+func decodeValue(value JSONCadence, out reflect.Value) error {
+	switch value.Type {
+	case "Optional":
+		if len(value.Value) == 0 || string(value.Value) == "null" {
+			out.Set(reflect.Zero(out.Type()))
+			return nil
+		}
+		var inner JSONCadence
+		if err := json.Unmarshal(value.Value, &inner); err != nil {
+			return err
+		}
+		return decodeValue(inner, out)
+	case "Void":
+		out.Set(reflect.Zero(out.Type()))
+		return nil
+	}
+
+	if out.Kind() == reflect.Interface {
+		decoded, err := decodeInterface(value)
+		if err != nil {
+			return err
+		}
+		if decoded != nil {
+			out.Set(reflect.ValueOf(decoded))
+		}
+		return nil
+	}
+
+	if out.Kind() == reflect.Ptr {
+		if out.IsNil() {
+			out.Set(reflect.New(out.Type().Elem()))
+		}
+		return decodeValue(value, out.Elem())
+	}
+
+	switch value.Type {
+	case "Array":
+		var elements []JSONCadence
+		if err := json.Unmarshal(value.Value, &elements); err != nil {
+			return err
+		}
+		if out.Kind() != reflect.Slice {
+			return fmt.Errorf("cannot decode Array into %s", out.Type())
+		}
+		slice := reflect.MakeSlice(out.Type(), len(elements), len(elements))
+		for i, element := range elements {
+			if err := decodeValue(element, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		out.Set(slice)
+		return nil
+	case "Dictionary":
+		var entries []jsonCadenceEntry
+		if err := json.Unmarshal(value.Value, &entries); err != nil {
+			return err
+		}
+		if out.Kind() != reflect.Map {
+			return fmt.Errorf("cannot decode Dictionary into %s", out.Type())
+		}
+		dictionary := reflect.MakeMapWithSize(out.Type(), len(entries))
+		for _, entry := range entries {
+			key := reflect.New(out.Type().Key()).Elem()
+			if err := decodeValue(entry.Key, key); err != nil {
+				return err
+			}
+			element := reflect.New(out.Type().Elem()).Elem()
+			if err := decodeValue(entry.Value, element); err != nil {
+				return err
+			}
+			dictionary.SetMapIndex(key, element)
+		}
+		out.Set(dictionary)
+		return nil
+	case "Struct", "Resource", "Event", "Contract", "Enum":
+		var composite jsonCadenceComposite
+		if err := json.Unmarshal(value.Value, &composite); err != nil {
+			return err
+		}
+		if out.Kind() != reflect.Struct {
+			return fmt.Errorf("cannot decode %s into %s", value.Type, out.Type())
+		}
+		fields := make(map[string]JSONCadence, len(composite.Fields))
+		for _, field := range composite.Fields {
+			fields[field.Name] = field.Value
+		}
+		for i := 0; i < out.NumField(); i++ {
+			name := strings.SplitN(out.Type().Field(i).Tag.Get("cadence"), ",", 2)[0]
+			field, ok := fields[name]
+			if name == "" || !ok {
+				continue
+			}
+			if err := decodeValue(field, out.Field(i)); err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+		}
+		return nil
+	}
+
+	var scalar interface{}
+	if err := json.Unmarshal(value.Value, &scalar); err != nil {
+		return err
+	}
+
+	switch out.Kind() {
+	case reflect.Bool:
+		b, ok := scalar.(bool)
+		if !ok {
+			return fmt.Errorf("cannot decode %s into bool", value.Type)
+		}
+		out.SetBool(b)
+		return nil
+	case reflect.String:
+		s, ok := scalar.(string)
+		if !ok {
+			return fmt.Errorf("cannot decode %s into string", value.Type)
+		}
+		out.SetString(s)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(fmt.Sprint(scalar), 10, out.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot decode %s: %w", value.Type, err)
+		}
+		out.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(fmt.Sprint(scalar), 10, out.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot decode %s: %w", value.Type, err)
+		}
+		out.SetUint(u)
+		return nil
+	case reflect.Struct:
+		if out.Type() == bigIntType {
+			bigInt, ok := new(big.Int).SetString(fmt.Sprint(scalar), 10)
+			if !ok {
+				return fmt.Errorf("cannot decode %s into big.Int", value.Type)
+			}
+			out.Set(reflect.ValueOf(*bigInt))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot decode %s into %s", value.Type, out.Type())
+}
+
+// decodeInterface decodes a JSON-Cadence value into plain Go values
+func decodeInterface(value JSONCadence) (interface{}, error) {
+	switch value.Type {
+	case "Optional":
+		if len(value.Value) == 0 || string(value.Value) == "null" {
+			return nil, nil
+		}
+		var inner JSONCadence
+		if err := json.Unmarshal(value.Value, &inner); err != nil {
+			return nil, err
+		}
+		return decodeInterface(inner)
+	case "Void":
+		return nil, nil
+	case "Array":
+		var elements []JSONCadence
+		if err := json.Unmarshal(value.Value, &elements); err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, 0, len(elements))
+		for _, element := range elements {
+			decoded, err := decodeInterface(element)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, decoded)
+		}
+		return result, nil
+	case "Dictionary":
+		var entries []jsonCadenceEntry
+		if err := json.Unmarshal(value.Value, &entries); err != nil {
+			return nil, err
+		}
+		result := make(map[string]interface{}, len(entries))
+		for _, entry := range entries {
+			key, err := decodeInterface(entry.Key)
+			if err != nil {
+				return nil, err
+			}
+			decoded, err := decodeInterface(entry.Value)
+			if err != nil {
+				return nil, err
+			}
+			result[fmt.Sprint(key)] = decoded
+		}
+		return result, nil
+	case "Struct", "Resource", "Event", "Contract", "Enum":
+		var composite jsonCadenceComposite
+		if err := json.Unmarshal(value.Value, &composite); err != nil {
+			return nil, err
+		}
+		result := make(map[string]interface{}, len(composite.Fields))
+		for _, field := range composite.Fields {
+			decoded, err := decodeInterface(field.Value)
+			if err != nil {
+				return nil, err
+			}
+			result[field.Name] = decoded
+		}
+		return result, nil
+	}
+
+	var scalar interface{}
+	if err := json.Unmarshal(value.Value, &scalar); err != nil {
+		return nil, err
+	}
+	return scalar, nil
+}
+`
@@ -0,0 +1,305 @@
+package golang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/outblock/cadence-codegen/internal/fixtures"
+)
+
+// transportTemplate declares the Transport interface, the Client running the
+// generated scripts and transactions over it and, with Mock, the in-memory
+// FakeTransport
+const transportTemplate = `
+// Interaction is a script or transaction executed by a Transport
+type Interaction struct {
+	Name        string   // Name of the generated function, e.g. GetBalance
+	Code        string   // Cadence code
+	Arguments   [][]byte // JSON-Cadence encoded arguments
+	Authorizers int      // Number of accounts authorizing a transaction
+}
+
+// Transport executes scripts and sends transactions, e.g. through the Flow
+// access API. Transactions are signed by the transport.
+type Transport interface {
+	ExecuteScript(ctx context.Context, script Interaction) ([]byte, error)
+	SendTransaction(ctx context.Context, transaction Interaction) (string, error)
+}
+
+// Client runs the generated scripts and transactions over a Transport
+type Client struct {
+	Transport Transport
+}
+
+// NewClient creates a Client using transport
+func NewClient(transport Transport) *Client {
+	return &Client{Transport: transport}
+}
+{{range .Functions}}
+{{- if eq .Type "script"}}
+// {{.Name}} executes the {{.SourceName}} script
+func (c *Client) {{.Name}}(ctx context.Context{{range .Parameters}}, {{.Name}} {{.Type}}{{end}}) ({{if .ReturnType}}{{.ReturnType}}, {{end}}error) {
+	{{- if .ReturnType}}
+	var result {{.ReturnType}}
+	{{- end}}
+	arguments, err := Encode{{.Name}}Arguments({{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{end}})
+	if err != nil {
+		return {{if .ReturnType}}result, {{end}}err
+	}
+	{{if .ReturnType}}data{{else}}_{{end}}, err {{if .ReturnType}}:{{end}}= c.Transport.ExecuteScript(ctx, Interaction{Name: "{{.Name}}", Code: {{printf "%q" .Code}}, Arguments: arguments})
+	if err != nil {
+		return {{if .ReturnType}}result, {{end}}fmt.Errorf("failed to execute {{.SourceName}}: %w", err)
+	}
+	{{- if .ReturnType}}
+	return Decode{{.Name}}Result(data)
+	{{- else}}
+	return nil
+	{{- end}}
+}
+{{- else}}
+// {{.Name}} sends the {{.SourceName}} transaction and returns its ID
+func (c *Client) {{.Name}}(ctx context.Context{{range .Parameters}}, {{.Name}} {{.Type}}{{end}}) (string, error) {
+	arguments, err := Encode{{.Name}}Arguments({{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{end}})
+	if err != nil {
+		return "", err
+	}
+	id, err := c.Transport.SendTransaction(ctx, Interaction{Name: "{{.Name}}", Code: {{printf "%q" .Code}}, Arguments: arguments, Authorizers: {{.Authorizers}}})
+	if err != nil {
+		return "", fmt.Errorf("failed to send {{.SourceName}}: %w", err)
+	}
+	return id, nil
+}
+{{- end}}
+{{end}}
+{{- if .Mock}}
+// FakeTransactionID is the ID of transactions sent through a FakeTransport
+const FakeTransactionID = "{{.TransactionID}}"
+
+// sampleFixtures are JSON-Cadence sample results of the scripts by name
+var sampleFixtures = map[string]string{
+{{- range $name, $fixture := .Fixtures}}
+	"{{$name}}": {{printf "%q" $fixture}},
+{{- end}}
+}
+
+// FakeTransport is an in-memory Transport for unit tests of code using a
+// Client. Scripts return the JSON-Cadence fixture registered for their name,
+// a sample value of their return type by default, and interactions are
+// recorded instead of reaching a network.
+type FakeTransport struct {
+	mu           sync.Mutex
+	fixtures     map[string][]byte
+	errors       map[string]error
+	scripts      []Interaction
+	transactions []Interaction
+}
+
+var _ Transport = (*FakeTransport)(nil)
+
+// NewFakeTransport creates a FakeTransport returning the sample fixtures
+func NewFakeTransport() *FakeTransport {
+	f := &FakeTransport{
+		fixtures: make(map[string][]byte, len(sampleFixtures)),
+		errors:   make(map[string]error),
+	}
+	for name, fixture := range sampleFixtures {
+		f.fixtures[name] = []byte(fixture)
+	}
+	return f
+}
+
+// SetFixture sets the JSON-Cadence result of the script name
+func (f *FakeTransport) SetFixture(name string, result []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fixtures[name] = result
+}
+
+// SetResult sets the result of the script name to value, encoded as the Cadence type cadenceType
+func (f *FakeTransport) SetResult(name string, cadenceType string, value interface{}) error {
+	result, err := EncodeValue(cadenceType, value)
+	if err != nil {
+		return err
+	}
+	f.SetFixture(name, result)
+	return nil
+}
+
+// SetError makes the script or transaction name fail with err, nil clears it
+func (f *FakeTransport) SetError(name string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		delete(f.errors, name)
+		return
+	}
+	f.errors[name] = err
+}
+
+// ExecuteScript records script and returns its fixture
+func (f *FakeTransport) ExecuteScript(ctx context.Context, script Interaction) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.scripts = append(f.scripts, script)
+	if err := f.errors[script.Name]; err != nil {
+		return nil, err
+	}
+	fixture, ok := f.fixtures[script.Name]
+	if !ok {
+		return nil, fmt.Errorf("no fixture for script %s", script.Name)
+	}
+	return fixture, nil
+}
+
+// SendTransaction records transaction and returns FakeTransactionID
+func (f *FakeTransport) SendTransaction(ctx context.Context, transaction Interaction) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.transactions = append(f.transactions, transaction)
+	if err := f.errors[transaction.Name]; err != nil {
+		return "", err
+	}
+	return FakeTransactionID, nil
+}
+
+// Scripts returns the executed scripts in order
+func (f *FakeTransport) Scripts() []Interaction {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Interaction(nil), f.scripts...)
+}
+
+// Transactions returns the sent transactions in order
+func (f *FakeTransport) Transactions() []Interaction {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Interaction(nil), f.transactions...)
+}
+{{- end}}
+`
+
+// sampleJSONCadence returns a JSON-Cadence sample value of a Cadence type,
+// tracking visited structs to stop on recursive types
+func (g *Generator) sampleJSONCadence(cadenceType string, visiting map[string]bool) map[string]interface{} {
+	cadenceType = strings.TrimSpace(cadenceType)
+	null := map[string]interface{}{"type": "Optional", "value": nil}
+
+	if strings.HasSuffix(cadenceType, "?") {
+		return map[string]interface{}{"type": "Optional", "value": g.sampleJSONCadence(strings.TrimSuffix(cadenceType, "?"), visiting)}
+	}
+
+	if strings.HasPrefix(cadenceType, "[") && strings.HasSuffix(cadenceType, "]") {
+		elementType := strings.TrimSuffix(strings.TrimPrefix(cadenceType, "["), "]")
+		if idx := strings.Index(elementType, ";"); idx >= 0 {
+			elementType = elementType[:idx]
+		}
+		return map[string]interface{}{"type": "Array", "value": []interface{}{g.sampleJSONCadence(elementType, visiting)}}
+	}
+
+	if strings.HasPrefix(cadenceType, "{") && strings.HasSuffix(cadenceType, "}") {
+		keyType, valueType, ok := splitDictionaryType(strings.TrimSuffix(strings.TrimPrefix(cadenceType, "{"), "}"))
+		if !ok {
+			return null
+		}
+		entry := map[string]interface{}{
+			"key":   g.sampleJSONCadence(keyType, visiting),
+			"value": g.sampleJSONCadence(valueType, visiting),
+		}
+		return map[string]interface{}{"type": "Dictionary", "value": []interface{}{entry}}
+	}
+
+	switch cadenceType {
+	case "String", "Character":
+		return map[string]interface{}{"type": cadenceType, "value": "test"}
+	case "Address":
+		return map[string]interface{}{"type": "Address", "value": fixtures.SampleAddress}
+	case "Bool":
+		return map[string]interface{}{"type": "Bool", "value": true}
+	case "UFix64", "Fix64":
+		return map[string]interface{}{"type": cadenceType, "value": "1.00000000"}
+	case "Void":
+		return map[string]interface{}{"type": "Void"}
+	}
+	if goType, ok := typeMapping[cadenceType]; ok && goType != "interface{}" {
+		return map[string]interface{}{"type": cadenceType, "value": "1"}
+	}
+
+	structName := strings.ReplaceAll(cadenceType, ".", "")
+	if composite, ok := g.Report.Structs[structName]; ok && !visiting[structName] {
+		visiting[structName] = true
+		defer delete(visiting, structName)
+
+		fields := []interface{}{}
+		for _, field := range composite.Fields {
+			typeStr := field.TypeStr
+			if field.Optional && !strings.HasSuffix(typeStr, "?") {
+				typeStr += "?"
+			}
+			fields = append(fields, map[string]interface{}{
+				"name":  field.Name,
+				"value": g.sampleJSONCadence(typeStr, visiting),
+			})
+		}
+		return map[string]interface{}{"type": "Struct", "value": map[string]interface{}{"id": composite.Name, "fields": fields}}
+	}
+
+	return null
+}
+
+// sampleFixtures returns the JSON-Cadence sample results of the scripts by
+// function name
+func (g *Generator) sampleFixtures() (map[string]string, error) {
+	samples := make(map[string]string)
+	for filename, result := range g.Report.Scripts {
+		if result.ReturnType == "" {
+			continue
+		}
+		data, err := json.Marshal(g.sampleJSONCadence(result.ReturnType, make(map[string]bool)))
+		if err != nil {
+			return nil, err
+		}
+		samples[g.functionName(filename)] = string(data)
+	}
+	return samples, nil
+}
+
+// writeTransport writes the Transport and Client code of functions, and the
+// FakeTransport with Mock
+func (g *Generator) writeTransport(buffer *bytes.Buffer, functions []GoFunction) error {
+	var samples map[string]string
+	if g.Mock {
+		var err error
+		samples, err = g.sampleFixtures()
+		if err != nil {
+			return fmt.Errorf("failed to marshal fixtures: %w", err)
+		}
+	}
+	tmpl, err := template.New("transport").Parse(transportTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse transport template: %w", err)
+	}
+	err = tmpl.Execute(buffer, struct {
+		Mock          bool
+		Functions     []GoFunction
+		Fixtures      map[string]string
+		TransactionID string
+	}{
+		Mock:          g.Mock,
+		Functions:     functions,
+		Fixtures:      samples,
+		TransactionID: fixtures.SampleTransactionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute transport template: %w", err)
+	}
+	return nil
+}
+
+// SetMock enables the Transport interface, the Client using it and the
+// in-memory FakeTransport returning fixtures
+func (g *Generator) SetMock(mock bool) {
+	g.Mock = mock
+}
@@ -0,0 +1,423 @@
+package grpc
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// Generator handles gRPC service definition generation
+type Generator struct {
+	Report       analyzer.Report
+	ProtoPackage string
+	GoPackage    string
+	ServerName   string
+}
+
+// New creates a new gRPC generator
+func New(report analyzer.Report) *Generator {
+	return &Generator{
+		Report:       report,
+		ProtoPackage: "cadencegen",
+		GoPackage:    "cadencegen/pb",
+		ServerName:   "server",
+	}
+}
+
+// SetProtoPackage sets the package of the generated .proto file
+func (g *Generator) SetProtoPackage(name string) {
+	g.ProtoPackage = name
+}
+
+// SetGoPackage sets the go_package option, the import path of the code generated by protoc-gen-go
+func (g *Generator) SetGoPackage(path string) {
+	g.GoPackage = path
+}
+
+// SetServerName sets the package name of the generated Go server skeleton
+func (g *Generator) SetServerName(name string) {
+	g.ServerName = name
+}
+
+// typeMapping maps Cadence types to protobuf scalar types. Integers wider than
+// 64 bits and fixed point numbers are decimal strings.
+var typeMapping = map[string]string{
+	"String":    "string",
+	"Character": "string",
+	"Address":   "string",
+	"Bool":      "bool",
+	"UFix64":    "string",
+	"Fix64":     "string",
+	"Int":       "string",
+	"UInt":      "string",
+	"Int8":      "int32",
+	"Int16":     "int32",
+	"Int32":     "int32",
+	"Int64":     "int64",
+	"Int128":    "string",
+	"Int256":    "string",
+	"UInt8":     "uint32",
+	"UInt16":    "uint32",
+	"UInt32":    "uint32",
+	"UInt64":    "uint64",
+	"UInt128":   "string",
+	"UInt256":   "string",
+	"Word8":     "uint32",
+	"Word16":    "uint32",
+	"Word32":    "uint32",
+	"Word64":    "uint64",
+}
+
+// ProtoMessage represents a message of the generated .proto file
+type ProtoMessage struct {
+	Name    string
+	Comment string
+	Fields  []ProtoField
+}
+
+// ProtoField represents a field of a generated message
+type ProtoField struct {
+	Name    string
+	Type    string
+	Number  int
+	Comment string
+}
+
+// ProtoRPC represents a script or transaction as an RPC
+type ProtoRPC struct {
+	Name       string
+	SourceName string
+	Type       string
+	Request    ProtoMessage
+	Response   ProtoMessage
+}
+
+const protoTemplate = `// Code generated by cadence-codegen. DO NOT EDIT.
+
+syntax = "proto3";
+
+package {{.ProtoPackage}};
+
+option go_package = "{{.GoPackage}}";
+
+// CadenceService exposes the Cadence scripts and transactions
+service CadenceService {
+{{- range .RPCs}}
+  // {{.Name}} {{if eq .Type "script"}}executes the {{.SourceName}} script{{else}}sends the {{.SourceName}} transaction{{end}}
+  rpc {{.Name}}({{.Request.Name}}) returns ({{.Response.Name}});
+{{- end}}
+}
+{{- range .RPCs}}
+{{template "message" .Request}}
+{{template "message" .Response}}
+{{- end}}
+{{- range .Messages}}
+{{template "message" .}}
+{{- end}}
+{{define "message"}}
+// {{.Comment}}
+message {{.Name}} {
+{{- range .Fields}}
+  {{.Type}} {{.Name}} = {{.Number}};{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+{{- end}}`
+
+const serverTemplate = `// Code generated by cadence-codegen. Implement the TODOs to execute requests.
+
+package {{.ServerName}}
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "{{.GoPackage}}"
+)
+
+// Server implements the CadenceService gRPC service
+type Server struct {
+	pb.UnimplementedCadenceServiceServer
+}
+
+// NewServer creates a new CadenceService server
+func NewServer() *Server {
+	return &Server{}
+}
+{{range .RPCs}}
+// {{.Name}} {{if eq .Type "script"}}executes the {{.SourceName}} script{{else}}sends the {{.SourceName}} transaction{{end}}
+func (s *Server) {{.Name}}(ctx context.Context, req *pb.{{.Request.Name}}) (*pb.{{.Response.Name}}, error) {
+	// TODO: {{if eq .Type "script"}}execute the script{{else}}send the transaction{{end}} with the arguments{{if not .Request.Fields}} (none){{end}}
+	{{- range .Request.Fields}}
+	//   req.Get{{goName .Name}}() {{.Type}}
+	{{- end}}
+	return nil, status.Error(codes.Unimplemented, "{{.Name}} is not implemented")
+}
+{{end}}`
+
+// exportName converts an identifier into an exported identifier
+func exportName(name string) string {
+	if name == "" {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// formatRPCName formats the filename into an RPC name
+func formatRPCName(filename string) string {
+	name := strings.TrimSuffix(filename, ".cdc")
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-'
+	})
+	for i := range parts {
+		parts[i] = exportName(strings.ToLower(parts[i]))
+	}
+	return strings.Join(parts, "")
+}
+
+// fieldName converts a Cadence field name into a snake_case protobuf field name
+func fieldName(name string) string {
+	var builder strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				builder.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// goName returns the Go name protoc-gen-go generates for a snake_case field
+func goName(name string) string {
+	parts := strings.Split(name, "_")
+	for i := range parts {
+		parts[i] = exportName(parts[i])
+	}
+	return strings.Join(parts, "")
+}
+
+// splitDictionaryType splits the inner part of a dictionary type at the top level colon
+func splitDictionaryType(inner string) (string, string, bool) {
+	depth := 0
+	for i, r := range inner {
+		switch r {
+		case '[', '{', '<', '(':
+			depth++
+		case ']', '}', '>', ')':
+			depth--
+		case ':':
+			if depth == 0 {
+				return strings.TrimSpace(inner[:i]), strings.TrimSpace(inner[i+1:]), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// jsonField is the field type of values protobuf cannot represent, such as
+// nested lists, which are passed as JSON-Cadence
+const jsonField = "string"
+
+// convertCadenceTypeToProto converts a Cadence type into a protobuf field type.
+// The second result reports whether the type had to fall back to JSON-Cadence.
+func (g *Generator) convertCadenceTypeToProto(cadenceType string) (string, bool) {
+	cadenceType = strings.TrimSpace(cadenceType)
+
+	if strings.HasSuffix(cadenceType, "?") {
+		protoType, isJSON := g.convertCadenceTypeToProto(strings.TrimSuffix(cadenceType, "?"))
+		if strings.HasPrefix(protoType, "repeated ") || strings.HasPrefix(protoType, "map<") {
+			return protoType, isJSON
+		}
+		if _, ok := g.Report.Structs[protoType]; ok {
+			// Message fields are already nullable
+			return protoType, isJSON
+		}
+		return "optional " + protoType, isJSON
+	}
+
+	if strings.HasPrefix(cadenceType, "[") && strings.HasSuffix(cadenceType, "]") {
+		elementType := strings.TrimSuffix(strings.TrimPrefix(cadenceType, "["), "]")
+		if idx := strings.Index(elementType, ";"); idx >= 0 {
+			elementType = elementType[:idx]
+		}
+		protoType, isJSON := g.convertCadenceTypeToProto(elementType)
+		if isJSON || strings.Contains(protoType, " ") || strings.HasPrefix(protoType, "map<") {
+			return jsonField, true
+		}
+		return "repeated " + protoType, false
+	}
+
+	if strings.HasPrefix(cadenceType, "{") && strings.HasSuffix(cadenceType, "}") {
+		keyType, valueType, ok := splitDictionaryType(strings.TrimSuffix(strings.TrimPrefix(cadenceType, "{"), "}"))
+		if !ok {
+			return jsonField, true
+		}
+		protoKey, keyJSON := g.convertCadenceTypeToProto(keyType)
+		protoValue, valueJSON := g.convertCadenceTypeToProto(valueType)
+		if keyJSON || valueJSON || protoKey == "bool" || strings.Contains(protoKey, " ") ||
+			strings.Contains(protoValue, " ") || strings.HasPrefix(protoValue, "map<") {
+			return jsonField, true
+		}
+		if _, ok := g.Report.Structs[protoKey]; ok {
+			return jsonField, true
+		}
+		return fmt.Sprintf("map<%s, %s>", protoKey, protoValue), false
+	}
+
+	if protoType, ok := typeMapping[cadenceType]; ok {
+		return protoType, false
+	}
+
+	structName := strings.ReplaceAll(cadenceType, ".", "")
+	if _, ok := g.Report.Structs[structName]; ok {
+		return structName, false
+	}
+
+	return jsonField, true
+}
+
+// field converts a Cadence value into a message field
+func (g *Generator) field(name string, typeStr string, number int) ProtoField {
+	protoType, isJSON := g.convertCadenceTypeToProto(typeStr)
+	field := ProtoField{
+		Name:   fieldName(name),
+		Type:   protoType,
+		Number: number,
+	}
+	if isJSON {
+		field.Comment = fmt.Sprintf("JSON-Cadence encoded %s", typeStr)
+	}
+	return field
+}
+
+// buildMessages converts the report structs into messages sorted by name
+func (g *Generator) buildMessages() []ProtoMessage {
+	var names []string
+	for name := range g.Report.Structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var messages []ProtoMessage
+	for _, name := range names {
+		composite := g.Report.Structs[name]
+		message := ProtoMessage{
+			Name:    name,
+			Comment: fmt.Sprintf("%s is generated from the Cadence struct %s", name, composite.Name),
+		}
+		for i, field := range composite.Fields {
+			typeStr := field.TypeStr
+			if field.Optional && !strings.HasSuffix(typeStr, "?") {
+				typeStr += "?"
+			}
+			message.Fields = append(message.Fields, g.field(field.Name, typeStr, i+1))
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// buildRPCs converts the report scripts and transactions into RPCs sorted by name
+func (g *Generator) buildRPCs() []ProtoRPC {
+	var rpcs []ProtoRPC
+	add := func(results map[string]analyzer.AnalysisResult, kind string) {
+		for filename, result := range results {
+			name := formatRPCName(filename)
+			rpc := ProtoRPC{
+				Name:       name,
+				SourceName: strings.TrimSuffix(filename, ".cdc"),
+				Type:       kind,
+				Request: ProtoMessage{
+					Name:    name + "Request",
+					Comment: fmt.Sprintf("%sRequest holds the arguments of the %s %s", name, strings.TrimSuffix(filename, ".cdc"), kind),
+				},
+				Response: ProtoMessage{
+					Name: name + "Response",
+				},
+			}
+			for i, param := range result.Parameters {
+				rpc.Request.Fields = append(rpc.Request.Fields, g.field(param.Name, param.TypeStr, i+1))
+			}
+			if kind == "script" {
+				rpc.Response.Comment = fmt.Sprintf("%sResponse holds the result of the %s script", name, rpc.SourceName)
+				if result.ReturnType != "" {
+					rpc.Response.Fields = append(rpc.Response.Fields, g.field("result", result.ReturnType, 1))
+				}
+			} else {
+				rpc.Response.Comment = fmt.Sprintf("%sResponse holds the ID of the sent %s transaction", name, rpc.SourceName)
+				rpc.Response.Fields = append(rpc.Response.Fields, ProtoField{Name: "transaction_id", Type: "string", Number: 1})
+			}
+			rpcs = append(rpcs, rpc)
+		}
+	}
+	add(g.Report.Transactions, "transaction")
+	add(g.Report.Scripts, "script")
+
+	sort.Slice(rpcs, func(i, j int) bool {
+		return rpcs[i].Name < rpcs[j].Name
+	})
+	return rpcs
+}
+
+// templateData returns the data shared by the proto and server templates
+func (g *Generator) templateData() interface{} {
+	return struct {
+		ProtoPackage string
+		GoPackage    string
+		ServerName   string
+		RPCs         []ProtoRPC
+		Messages     []ProtoMessage
+	}{
+		ProtoPackage: g.ProtoPackage,
+		GoPackage:    g.GoPackage,
+		ServerName:   g.ServerName,
+		RPCs:         g.buildRPCs(),
+		Messages:     g.buildMessages(),
+	}
+}
+
+// GenerateProto generates the .proto service definition
+func (g *Generator) GenerateProto() (string, error) {
+	tmpl, err := template.New("proto").Parse(protoTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse proto template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, g.templateData()); err != nil {
+		return "", fmt.Errorf("failed to execute proto template: %w", err)
+	}
+	return strings.TrimSpace(buffer.String()) + "\n", nil
+}
+
+// GenerateServer generates a Go server skeleton implementing the service
+// with the code generated by protoc-gen-go and protoc-gen-go-grpc
+func (g *Generator) GenerateServer() (string, error) {
+	tmpl, err := template.New("server").Funcs(template.FuncMap{"goName": goName}).Parse(serverTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse server template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, g.templateData()); err != nil {
+		return "", fmt.Errorf("failed to execute server template: %w", err)
+	}
+
+	// Format the code so the output is gofmt clean
+	formatted, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated Go code: %w", err)
+	}
+	return string(formatted), nil
+}
@@ -0,0 +1,103 @@
+package kotlin
+
+import "fmt"
+
+// Supported serialization libraries of the generated data classes
+const (
+	SerializationNone    = "none"
+	SerializationKotlinx = "kotlinx"
+	SerializationMoshi   = "moshi"
+)
+
+// Supported coroutine APIs of the generated functions
+const (
+	CoroutinesSuspend = "suspend"
+	CoroutinesFlow    = "flow"
+)
+
+// serializationSupport declares the serializers the kotlinx.serialization
+// flavor needs for the types of the data classes: numbers beyond 64 bits and
+// fixed point numbers as strings, and untyped JSON-Cadence fields from the
+// serializers module
+const serializationSupport = `
+/** Serializes BigInteger values as decimal strings */
+object CadenceBigIntegerSerializer : KSerializer<BigInteger> {
+    override val descriptor: SerialDescriptor = PrimitiveSerialDescriptor("java.math.BigInteger", PrimitiveKind.STRING)
+
+    override fun serialize(encoder: Encoder, value: BigInteger) = encoder.encodeString(value.toString())
+
+    override fun deserialize(decoder: Decoder): BigInteger = BigInteger(decoder.decodeString())
+}
+
+/** Serializes BigDecimal values as plain decimal strings */
+object CadenceBigDecimalSerializer : KSerializer<BigDecimal> {
+    override val descriptor: SerialDescriptor = PrimitiveSerialDescriptor("java.math.BigDecimal", PrimitiveKind.STRING)
+
+    override fun serialize(encoder: Encoder, value: BigDecimal) = encoder.encodeString(value.toPlainString())
+
+    override fun deserialize(decoder: Decoder): BigDecimal = BigDecimal(decoder.decodeString())
+}
+`
+
+// moshiSupport declares the Moshi adapters of the types of the data classes
+// without built-in adapters
+const moshiSupport = `
+/**
+ * Moshi adapters encoding BigInteger and BigDecimal values as decimal strings, add them with
+ * Moshi.Builder().add(CadenceMoshiAdapters)
+ */
+object CadenceMoshiAdapters {
+    @ToJson
+    fun bigIntegerToJson(value: BigInteger): String = value.toString()
+
+    @FromJson
+    fun bigIntegerFromJson(value: String): BigInteger = BigInteger(value)
+
+    @ToJson
+    fun bigDecimalToJson(value: BigDecimal): String = value.toPlainString()
+
+    @FromJson
+    fun bigDecimalFromJson(value: String): BigDecimal = BigDecimal(value)
+}
+`
+
+// SetSerialization sets the serialization library the data classes are
+// annotated for (none/kotlinx/moshi), defaults to none
+func (g *Generator) SetSerialization(serialization string) {
+	g.Serialization = serialization
+}
+
+// SetCoroutines sets the coroutine API of the generated functions
+// (suspend/flow), defaults to suspend
+func (g *Generator) SetCoroutines(coroutines string) {
+	g.Coroutines = coroutines
+}
+
+// SetCompose sets whether a composable function producing the State of its
+// result is generated per script, for Jetpack Compose screens
+func (g *Generator) SetCompose(compose bool) {
+	g.Compose = compose
+}
+
+// flavors returns the serialization library and coroutine API selected for
+// the generator
+func (g *Generator) flavors() (string, string, error) {
+	serialization, coroutines := g.Serialization, g.Coroutines
+	if serialization == "" {
+		serialization = SerializationNone
+	}
+	if coroutines == "" {
+		coroutines = CoroutinesSuspend
+	}
+	switch serialization {
+	case SerializationNone, SerializationKotlinx, SerializationMoshi:
+	default:
+		return "", "", fmt.Errorf("unsupported serialization: %s", serialization)
+	}
+	switch coroutines {
+	case CoroutinesSuspend, CoroutinesFlow:
+	default:
+		return "", "", fmt.Errorf("unsupported coroutines: %s", coroutines)
+	}
+	return serialization, coroutines, nil
+}
@@ -0,0 +1,654 @@
+package kotlin
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// Generator handles Kotlin code generation
+type Generator struct {
+	Report        analyzer.Report
+	PackageName   string
+	Names         map[string]string // Function names by file name, overriding the derived names
+	Serialization string            // Serialization library of the data classes, see SetSerialization
+	Coroutines    string            // Coroutine API of the functions, see SetCoroutines
+	Compose       bool              // Whether to generate Compose state helpers, see SetCompose
+}
+
+// New creates a new Kotlin code generator
+func New(report analyzer.Report) *Generator {
+	return &Generator{
+		Report:      report,
+		PackageName: "cadencegen",
+	}
+}
+
+// SetPackageName sets the package name of the generated code
+func (g *Generator) SetPackageName(name string) {
+	g.PackageName = name
+}
+
+// kotlinScalar is the Kotlin type of a Cadence scalar type and the format
+// strings encoding a value into a JSON-Cadence field and decoding it
+type kotlinScalar struct {
+	Type   string
+	Encode string
+	Decode string
+}
+
+// scalarMapping maps Cadence scalar types to Kotlin types
+var scalarMapping = map[string]kotlinScalar{
+	"String":  {"String", "StringField(%s)", "CadenceDecoding.string(%s)"},
+	"Address": {"String", "AddressField(%s)", "CadenceDecoding.string(%s)"},
+	"Bool":    {"Boolean", "BooleanField(%s)", "CadenceDecoding.bool(%s)"},
+	"Int8":    {"Int", `NumberField("Int8", %s.toString())`, "CadenceDecoding.number(%s).toInt()"},
+	"Int16":   {"Int", `NumberField("Int16", %s.toString())`, "CadenceDecoding.number(%s).toInt()"},
+	"Int32":   {"Int", `NumberField("Int32", %s.toString())`, "CadenceDecoding.number(%s).toInt()"},
+	"Int64":   {"Long", `NumberField("Int64", %s.toString())`, "CadenceDecoding.number(%s).toLong()"},
+	"UInt8":   {"Int", `NumberField("UInt8", %s.toString())`, "CadenceDecoding.number(%s).toInt()"},
+	"UInt16":  {"Int", `NumberField("UInt16", %s.toString())`, "CadenceDecoding.number(%s).toInt()"},
+	"UInt32":  {"Long", `NumberField("UInt32", %s.toString())`, "CadenceDecoding.number(%s).toLong()"},
+	"Word8":   {"Int", `NumberField("Word8", %s.toString())`, "CadenceDecoding.number(%s).toInt()"},
+	"Word16":  {"Int", `NumberField("Word16", %s.toString())`, "CadenceDecoding.number(%s).toInt()"},
+	"Word32":  {"Long", `NumberField("Word32", %s.toString())`, "CadenceDecoding.number(%s).toLong()"},
+	"Int":     {"BigInteger", `NumberField("Int", %s.toString())`, "CadenceDecoding.number(%s).toBigInteger()"},
+	"UInt":    {"BigInteger", `NumberField("UInt", %s.toString())`, "CadenceDecoding.number(%s).toBigInteger()"},
+	"UInt64":  {"BigInteger", `NumberField("UInt64", %s.toString())`, "CadenceDecoding.number(%s).toBigInteger()"},
+	"Word64":  {"BigInteger", `NumberField("Word64", %s.toString())`, "CadenceDecoding.number(%s).toBigInteger()"},
+	"Int128":  {"BigInteger", `NumberField("Int128", %s.toString())`, "CadenceDecoding.number(%s).toBigInteger()"},
+	"Int256":  {"BigInteger", `NumberField("Int256", %s.toString())`, "CadenceDecoding.number(%s).toBigInteger()"},
+	"UInt128": {"BigInteger", `NumberField("UInt128", %s.toString())`, "CadenceDecoding.number(%s).toBigInteger()"},
+	"UInt256": {"BigInteger", `NumberField("UInt256", %s.toString())`, "CadenceDecoding.number(%s).toBigInteger()"},
+	"UFix64":  {"BigDecimal", `NumberField("UFix64", %s.setScale(8).toPlainString())`, "CadenceDecoding.number(%s).toBigDecimal()"},
+	"Fix64":   {"BigDecimal", `NumberField("Fix64", %s.setScale(8).toPlainString())`, "CadenceDecoding.number(%s).toBigDecimal()"},
+}
+
+// KotlinStruct represents a data class in the generated Kotlin code
+type KotlinStruct struct {
+	Name        string
+	CadenceName string
+	Fields      []KotlinField
+}
+
+// KotlinField represents a property of a generated data class
+type KotlinField struct {
+	Name        string
+	CadenceName string
+	Type        string
+	Decode      string // Expression decoding the property from the struct field
+}
+
+// KotlinFunction represents a suspend function running a script or transaction
+type KotlinFunction struct {
+	Name       string
+	SourceName string
+	Type       string
+	Parameters []KotlinParameter
+	ReturnType string
+	Decode     string   // Expression decoding the script result from the field named result
+	Authorized bool     // Whether the transaction has a prepare block taking the signer
+	Signers    []string // Authorizers of a transaction with more than one, in prepare order
+	Base64     string
+	Source     string // Path of the originating .cdc file
+	Hash       string // Hex SHA-256 of the Cadence code
+	Deprecated string
+	Proposer   string // Signer holding the proposer role, if any
+	Payer      string // Signer holding the payer role, if any
+}
+
+// KotlinNetwork is the contract addresses of a network by import alias
+type KotlinNetwork struct {
+	Network   string
+	Contracts []KotlinContract
+}
+
+// KotlinContract is the address of an import alias, e.g. 0xFungibleToken
+type KotlinContract struct {
+	Alias   string
+	Address string
+}
+
+// KotlinParameter represents a parameter of a generated function
+type KotlinParameter struct {
+	Name   string
+	Type   string
+	Encode string // Expression encoding the parameter into a JSON-Cadence field
+}
+
+const fileTemplate = `// Code generated by cadence-codegen. DO NOT EDIT.
+{{- if eq .Serialization "kotlinx"}}
+@file:UseSerializers(CadenceBigIntegerSerializer::class, CadenceBigDecimalSerializer::class)
+@file:UseContextualSerialization(Field::class)
+{{- end}}
+
+package {{.PackageName}}
+{{if .Compose}}
+import androidx.compose.runtime.Composable
+import androidx.compose.runtime.State
+import androidx.compose.runtime.produceState
+{{- end}}
+{{- if eq .Serialization "moshi"}}
+import com.squareup.moshi.FromJson
+import com.squareup.moshi.JsonClass
+import com.squareup.moshi.ToJson
+{{- end}}
+import java.math.BigDecimal
+import java.math.BigInteger
+import java.util.Base64
+import kotlinx.coroutines.Dispatchers
+import kotlinx.coroutines.delay
+{{- if .Flow}}
+import kotlinx.coroutines.flow.Flow
+{{- if .Compose}}
+import kotlinx.coroutines.flow.first
+{{- end}}
+import kotlinx.coroutines.flow.flow
+{{- end}}
+import kotlinx.coroutines.withContext
+{{- if eq .Serialization "kotlinx"}}
+import kotlinx.serialization.KSerializer
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.UseContextualSerialization
+import kotlinx.serialization.UseSerializers
+import kotlinx.serialization.descriptors.PrimitiveKind
+import kotlinx.serialization.descriptors.PrimitiveSerialDescriptor
+import kotlinx.serialization.descriptors.SerialDescriptor
+import kotlinx.serialization.encoding.Decoder
+import kotlinx.serialization.encoding.Encoder
+{{- end}}
+{{- if .Compose}}
+import kotlin.coroutines.cancellation.CancellationException
+{{- end}}
+import org.onflow.flow.sdk.*
+import org.onflow.flow.sdk.cadence.*
+
+/** Error of a generated call, e.g. a failed access API request or a reverted transaction */
+class CadenceGenException(message: String, cause: Throwable? = null) : Exception(message, cause)
+
+/** Account key signing transactions */
+data class CadenceSigner(val address: FlowAddress, val keyIndex: Int, val signer: Signer)
+{{- if .SerializationSupport}}
+{{.SerializationSupport}}
+{{- end}}
+{{- range .Structs}}
+
+/** Generated from the Cadence struct {{.CadenceName}} */
+{{- if eq $.Serialization "kotlinx"}}
+@Serializable
+{{- else if eq $.Serialization "moshi"}}
+@JsonClass(generateAdapter = true)
+{{- end}}
+data class {{.Name}}(
+{{- range $index, $field := .Fields}}{{if $index}},{{end}}
+    val {{$field.Name}}: {{$field.Type}}
+{{- end}}
+) {
+    companion object {
+        /** Decodes the JSON-Cadence struct field */
+        fun decode(field: Field<*>): {{.Name}} = {{.Name}}(
+{{- range $index, $field := .Fields}}{{if $index}},{{end}}
+            {{$field.Name}} = {{$field.Decode}}
+{{- end}}
+        )
+    }
+}
+{{- end}}
+
+/**
+{{- if .Flow}}
+ * Generated from Cadence files, runs scripts and transactions on the access API in cold flows
+ * emitting their result once. The imports of the code are resolved with the contract addresses
+ * of network, e.g. testnet.
+{{- else}}
+ * Generated from Cadence files, runs scripts and transactions on the access API. The imports of
+ * the code are resolved with the contract addresses of network, e.g. testnet.
+{{- end}}
+ */
+class CadenceGen(private val accessApi: FlowAccessApi, private val network: String, private val gasLimit: Long = 9999) {
+{{- range .Functions}}
+
+    /**
+     * Runs the {{.SourceName}} {{.Type}}
+     *
+     * Source: {{.Source}}
+{{- if .Hash}}
+     * SHA-256: {{.Hash}}
+{{- end}}
+     * Generated by cadence-codegen{{if $.Version}} {{$.Version}}{{end}}
+     */
+{{- if .Deprecated}}
+    @Deprecated("{{.Deprecated}}")
+{{- end}}
+{{- if eq .Type "script"}}
+    {{if $.Flow}}fun{{else}}suspend fun{{end}} {{.Name}}({{template "parameters" .}}): {{if $.Flow}}Flow<{{end}}{{if .ReturnType}}{{.ReturnType}}{{else}}Unit{{end}}{{if $.Flow}}>{{end}} =
+        {{if $.Flow}}flow { emit({{end}}query(code("{{.Base64}}"), listOf({{template "arguments" .}})) { result -> {{if .ReturnType}}{{.Decode}}{{else}}Unit{{end}} }{{if $.Flow}}) }{{end}}
+{{- else if .Signers}}
+    {{if $.Flow}}fun{{else}}suspend fun{{end}} {{.Name}}({{range .Parameters}}{{.Name}}: {{.Type}}, {{end}}{{if not .Proposer}}proposer: CadenceSigner, {{end}}{{if not .Payer}}payer: CadenceSigner, {{end}}{{range $index, $signer := .Signers}}{{if $index}}, {{end}}{{$signer}}: CadenceSigner{{end}}): {{if $.Flow}}Flow<FlowId>{{else}}FlowId{{end}} =
+        {{if $.Flow}}flow { emit({{end}}send(code("{{.Base64}}"), listOf({{template "arguments" .}}), {{or .Proposer "proposer"}}, {{or .Payer "payer"}}, listOf({{range $index, $signer := .Signers}}{{if $index}}, {{end}}{{$signer}}{{end}})){{if $.Flow}}) }{{end}}
+{{- else}}
+    {{if $.Flow}}fun{{else}}suspend fun{{end}} {{.Name}}({{range .Parameters}}{{.Name}}: {{.Type}}, {{end}}signer: CadenceSigner): {{if $.Flow}}Flow<FlowId>{{else}}FlowId{{end}} =
+        {{if $.Flow}}flow { emit({{end}}send(code("{{.Base64}}"), listOf({{template "arguments" .}}), signer, signer, {{if .Authorized}}listOf(signer){{else}}emptyList(){{end}}){{if $.Flow}}) }{{end}}
+{{- end}}
+{{- end}}
+
+    /** Polls the result of a transaction until it is sealed, throwing if it failed */
+    suspend fun waitForSeal(id: FlowId, pollMillis: Long = 1000): FlowTransactionResult {
+        while (true) {
+            val result = withContext(Dispatchers.IO) { accessApi.getTransactionResultById(id).unwrap() }
+            if (result.status == FlowTransactionStatus.SEALED) {
+                if (result.errorMessage.isNotEmpty()) {
+                    throw CadenceGenException(result.errorMessage)
+                }
+                return result
+            }
+            delay(pollMillis)
+        }
+    }
+
+    private fun code(base64: String): String = resolveImports(String(Base64.getDecoder().decode(base64)))
+
+    /** Replaces the import aliases and imports by contract name of code with addresses, since flow-jvm-sdk sends code as is */
+    private fun resolveImports(code: String): String {
+        val addresses = contractAddresses[network].orEmpty()
+        val missing = mutableListOf<String>()
+        val aliased = aliasImportPattern.replace(code) { match ->
+            val alias = match.groupValues[2]
+            addresses[alias]?.let { match.groupValues[1] + it } ?: match.value.also {
+                if (!hexAddressPattern.matches(alias)) missing += alias
+            }
+        }
+        val resolved = stringImportPattern.replace(aliased) { match ->
+            val name = match.groupValues[1]
+            addresses["0x" + name]?.let { "import " + name + " from " + it } ?: match.value.also { missing += name }
+        }
+        if (missing.isNotEmpty()) {
+            throw CadenceGenException("no " + network + " address of " + missing.joinToString())
+        }
+        return resolved
+    }
+
+    private suspend fun <T> query(code: String, arguments: List<Field<*>>, decode: (Field<*>) -> T): T = withContext(Dispatchers.IO) {
+        val response = accessApi.executeScriptAtLatestBlock(FlowScript(code), arguments.map { FlowArgument(it).byteStringValue }).unwrap()
+        try {
+            decode(response.jsonCadence)
+        } catch (e: Exception) {
+            throw CadenceGenException("failed to decode script result: ${e.message}", e)
+        }
+    }
+
+    private suspend fun send(code: String, arguments: List<Field<*>>, proposer: CadenceSigner, payer: CadenceSigner, authorizers: List<CadenceSigner>): FlowId = withContext(Dispatchers.IO) {
+        val proposalKey = accessApi.getAccountAtLatestBlock(proposer.address).unwrap().keys[proposer.keyIndex]
+        var transaction = FlowTransaction(
+            script = FlowScript(code),
+            arguments = arguments.map { FlowArgument(it) },
+            referenceBlockId = accessApi.getLatestBlockHeader(true).unwrap().id,
+            gasLimit = gasLimit,
+            proposalKey = FlowTransactionProposalKey(proposer.address, proposer.keyIndex, proposalKey.sequenceNumber.toLong()),
+            payerAddress = payer.address,
+            authorizers = authorizers.map { it.address }
+        )
+        // The proposer and authorizers sign the payload, the payer signs the envelope
+        (listOf(proposer) + authorizers)
+            .filter { it.address != payer.address }
+            .distinctBy { it.address to it.keyIndex }
+            .forEach { transaction = transaction.addPayloadSignature(it.address, it.keyIndex, it.signer) }
+        transaction = transaction.addEnvelopeSignature(payer.address, payer.keyIndex, payer.signer)
+        accessApi.sendTransaction(transaction).unwrap()
+    }
+
+    private fun <T> FlowAccessApi.AccessApiCallResponse<T>.unwrap(): T = when (this) {
+        is FlowAccessApi.AccessApiCallResponse.Success -> data
+        is FlowAccessApi.AccessApiCallResponse.Error -> throw CadenceGenException(message, throwable)
+    }
+
+    companion object {
+        /** Contract addresses by network and import alias, e.g. 0xFungibleToken */
+        val contractAddresses: Map<String, Map<String, String>> = mapOf(
+{{- range .Networks}}
+            {{printf "%q" .Network}} to mapOf(
+{{- range .Contracts}}
+                {{printf "%q" .Alias}} to {{printf "%q" .Address}},
+{{- end}}
+            ),
+{{- end}}
+        )
+
+        private val aliasImportPattern = Regex("(\\bfrom\\s+)(0x\\w+)")
+        private val stringImportPattern = Regex("\\bimport\\s+\"(\\w+)\"")
+        private val hexAddressPattern = Regex("0x[0-9a-fA-F]{1,16}")
+    }
+}
+
+/** Decodes JSON-Cadence fields into Kotlin values */
+internal object CadenceDecoding {
+    fun string(field: Field<*>): String = field.value as String
+
+    fun bool(field: Field<*>): Boolean = field.value as Boolean
+
+    fun number(field: Field<*>): String = field.value as String
+
+    fun <T> optional(field: Field<*>, decode: (Field<*>) -> T): T? = (field as OptionalField).value?.let(decode)
+
+    fun <T> array(field: Field<*>, decode: (Field<*>) -> T): List<T> = (field as ArrayField).value!!.map(decode)
+
+    fun <K, V> dictionary(field: Field<*>, key: (Field<*>) -> K, value: (Field<*>) -> V): Map<K, V> =
+        (field as DictionaryField).value!!.associate { key(it.key) to value(it.value) }
+
+    fun field(field: Field<*>, name: String): Field<*> =
+        (field.value as CompositeValue).fields.firstOrNull { it.name == name }?.value
+            ?: throw IllegalArgumentException("missing struct field $name")
+}
+{{- if .Compose}}
+
+/** Result of a script consumed by a Compose screen, see the State functions of CadenceGen */
+sealed interface CadenceState<out T> {
+    /** The script is running */
+    object Loading : CadenceState<Nothing>
+
+    /** The script returned value */
+    data class Success<out T>(val value: T) : CadenceState<T>
+
+    /** The script failed with error */
+    data class Error(val error: Throwable) : CadenceState<Nothing>
+}
+
+/**
+ * Runs load into a CadenceState for produceState, e.g.
+ * produceState<CadenceState<BigDecimal>>(CadenceState.Loading, address) { value = cadenceState { cadence.getBalance(address) } }
+ */
+suspend fun <T> cadenceState(load: suspend () -> T): CadenceState<T> =
+    try {
+        CadenceState.Success(load())
+    } catch (e: CancellationException) {
+        throw e
+    } catch (e: Exception) {
+        CadenceState.Error(e)
+    }
+{{- range .Functions}}
+{{- if eq .Type "script"}}
+
+/** Runs the {{.SourceName}} script in a Compose state, loading again when the client or the arguments change */
+{{- if .Deprecated}}
+@Deprecated("{{.Deprecated}}")
+{{- end}}
+@Composable
+fun CadenceGen.{{.Name}}State({{template "parameters" .}}): State<CadenceState<{{if .ReturnType}}{{.ReturnType}}{{else}}Unit{{end}}>> =
+    produceState<CadenceState<{{if .ReturnType}}{{.ReturnType}}{{else}}Unit{{end}}>>(CadenceState.Loading, this{{range .Parameters}}, {{.Name}}{{end}}) {
+        this.value = CadenceState.Loading
+        this.value = cadenceState { {{.Name}}({{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{end}}){{if $.Flow}}.first(){{end}} }
+    }
+{{- end}}
+{{- end}}
+{{- end}}
+{{- define "parameters"}}{{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}: {{$param.Type}}{{end}}{{end}}
+{{- define "arguments"}}{{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Encode}}{{end}}{{end}}
+`
+
+// kotlinStringEscaper escapes text for Kotlin string literals
+var kotlinStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// kotlinKeywords are the hard keywords of Kotlin, escaped with backticks when
+// used as identifiers
+var kotlinKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true, "else": true,
+	"false": true, "for": true, "fun": true, "if": true, "in": true, "interface": true,
+	"is": true, "null": true, "object": true, "package": true, "return": true, "super": true,
+	"this": true, "throw": true, "true": true, "try": true, "typealias": true, "typeof": true,
+	"val": true, "var": true, "when": true, "while": true,
+}
+
+// identifier makes a Cadence name safe to use as a Kotlin identifier
+func identifier(name string) string {
+	if kotlinKeywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+// FunctionName returns the name of the generated function for a Cadence file
+func FunctionName(filename string) string {
+	return formatFunctionName(filename)
+}
+
+// formatFunctionName formats the filename into a lower camel case Kotlin function name
+func formatFunctionName(filename string) string {
+	name := strings.TrimSuffix(filename, ".cdc")
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-'
+	})
+	for i := range parts {
+		parts[i] = strings.ToLower(parts[i])
+		if i > 0 && parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// splitDictionaryType splits the inner part of a dictionary type at the top level colon
+func splitDictionaryType(inner string) (string, string, bool) {
+	depth := 0
+	for i, r := range inner {
+		switch r {
+		case '[', '{', '<', '(':
+			depth++
+		case ']', '}', '>', ')':
+			depth--
+		case ':':
+			if depth == 0 {
+				return strings.TrimSpace(inner[:i]), strings.TrimSpace(inner[i+1:]), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// structName returns the data class of a Cadence type, or false if it is not
+// a struct of the report
+func (g *Generator) structName(cadenceType string) (string, bool) {
+	name := strings.ReplaceAll(cadenceType, ".", "")
+	_, ok := g.Report.Structs[name]
+	return name, ok
+}
+
+// convert returns the Kotlin type of a Cadence type, the expression encoding
+// value into a JSON-Cadence field and the expression decoding field. Structs
+// are decoded into data classes; as arguments they and other types without a
+// mapping are passed as JSON-Cadence fields. depth numbers lambda parameters.
+func (g *Generator) convert(cadenceType string, value string, field string, argument bool, depth int) (string, string, string) {
+	cadenceType = strings.TrimSpace(cadenceType)
+	element := fmt.Sprintf("v%d", depth)
+
+	if strings.HasSuffix(cadenceType, "?") {
+		inner, encode, decode := g.convert(strings.TrimSuffix(cadenceType, "?"), element, element, argument, depth+1)
+		if !strings.HasSuffix(inner, "?") {
+			inner += "?"
+		}
+		return inner,
+			fmt.Sprintf("OptionalField(%s?.let { %s -> %s })", value, element, encode),
+			fmt.Sprintf("CadenceDecoding.optional(%s) { %s -> %s }", field, element, decode)
+	}
+
+	if strings.HasPrefix(cadenceType, "[") && strings.HasSuffix(cadenceType, "]") {
+		elementType := strings.TrimSuffix(strings.TrimPrefix(cadenceType, "["), "]")
+		if idx := strings.Index(elementType, ";"); idx >= 0 {
+			elementType = elementType[:idx]
+		}
+		inner, encode, decode := g.convert(elementType, element, element, argument, depth+1)
+		return fmt.Sprintf("List<%s>", inner),
+			fmt.Sprintf("ArrayField(%s.map { %s -> %s }.toTypedArray())", value, element, encode),
+			fmt.Sprintf("CadenceDecoding.array(%s) { %s -> %s }", field, element, decode)
+	}
+
+	if strings.HasPrefix(cadenceType, "{") && strings.HasSuffix(cadenceType, "}") {
+		if keyType, valueType, ok := splitDictionaryType(strings.TrimSuffix(strings.TrimPrefix(cadenceType, "{"), "}")); ok {
+			key := fmt.Sprintf("k%d", depth)
+			kotlinKey, encodeKey, decodeKey := g.convert(keyType, key, key, argument, depth+1)
+			kotlinValue, encodeValue, decodeValue := g.convert(valueType, element, element, argument, depth+1)
+			return fmt.Sprintf("Map<%s, %s>", kotlinKey, kotlinValue),
+				fmt.Sprintf("DictionaryField(%s.map { (%s, %s) -> DictionaryFieldEntry(%s, %s) }.toTypedArray())", value, key, element, encodeKey, encodeValue),
+				fmt.Sprintf("CadenceDecoding.dictionary(%s, { %s -> %s }, { %s -> %s })", field, key, decodeKey, element, decodeValue)
+		}
+	}
+
+	if scalar, ok := scalarMapping[cadenceType]; ok {
+		return scalar.Type, fmt.Sprintf(scalar.Encode, value), fmt.Sprintf(scalar.Decode, field)
+	}
+	if name, ok := g.structName(cadenceType); ok && !argument {
+		return name, value, fmt.Sprintf("%s.decode(%s)", name, field)
+	}
+	return "Field<*>", value, field
+}
+
+// buildStructs converts the report structs into data classes sorted by name
+func (g *Generator) buildStructs() []KotlinStruct {
+	var names []string
+	for name := range g.Report.Structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var structs []KotlinStruct
+	for _, name := range names {
+		composite := g.Report.Structs[name]
+		kotlinStruct := KotlinStruct{Name: name, CadenceName: composite.Name}
+		for _, field := range composite.Fields {
+			typeStr := field.TypeStr
+			if field.Optional && !strings.HasSuffix(typeStr, "?") {
+				typeStr += "?"
+			}
+			kotlinType, _, decode := g.convert(typeStr, "", fmt.Sprintf("CadenceDecoding.field(field, %q)", field.Name), false, 0)
+			kotlinStruct.Fields = append(kotlinStruct.Fields, KotlinField{
+				Name:        identifier(field.Name),
+				CadenceName: field.Name,
+				Type:        kotlinType,
+				Decode:      decode,
+			})
+		}
+		structs = append(structs, kotlinStruct)
+	}
+	return structs
+}
+
+// buildFunctions converts the report scripts and transactions into functions sorted by name
+func (g *Generator) buildFunctions() []KotlinFunction {
+	var functions []KotlinFunction
+	add := func(results map[string]analyzer.AnalysisResult, kind string) {
+		for filename, result := range results {
+			function := KotlinFunction{
+				Name:       g.functionName(filename),
+				SourceName: strings.TrimSuffix(filename, ".cdc"),
+				Type:       kind,
+				Base64:     result.Base64,
+				Source:     result.SourcePath(),
+				Hash:       result.CodeHash(),
+				Deprecated: kotlinStringEscaper.Replace(result.Deprecated),
+				Authorized: len(result.Signers) > 0,
+			}
+			for _, param := range result.Parameters {
+				name := identifier(param.Name)
+				kotlinType, encode, _ := g.convert(param.TypeStr, name, "", true, 0)
+				function.Parameters = append(function.Parameters, KotlinParameter{
+					Name:   name,
+					Type:   kotlinType,
+					Encode: encode,
+				})
+			}
+			if kind == "script" && result.ReturnType != "" {
+				function.ReturnType, _, function.Decode = g.convert(result.ReturnType, "", "result", false, 0)
+			}
+			if len(result.Signers) > 1 {
+				for _, signer := range result.Signers {
+					function.Signers = append(function.Signers, identifier(result.SignerLabel(signer.Name)))
+				}
+				if signer, ok := result.Roles[analyzer.RoleProposer]; ok {
+					function.Proposer = identifier(result.SignerLabel(signer))
+				}
+				if signer, ok := result.Roles[analyzer.RolePayer]; ok {
+					function.Payer = identifier(result.SignerLabel(signer))
+				}
+			}
+			functions = append(functions, function)
+		}
+	}
+	add(g.Report.Transactions, "transaction")
+	add(g.Report.Scripts, "script")
+
+	sort.Slice(functions, func(i, j int) bool {
+		return functions[i].Name < functions[j].Name
+	})
+	return functions
+}
+
+// buildNetworks converts the report addresses into networks sorted by name
+// with contracts sorted by alias
+func (g *Generator) buildNetworks() []KotlinNetwork {
+	var networks []KotlinNetwork
+	for network, addresses := range g.Report.ContractAddresses() {
+		kotlinNetwork := KotlinNetwork{Network: network}
+		for alias, address := range addresses {
+			kotlinNetwork.Contracts = append(kotlinNetwork.Contracts, KotlinContract{Alias: alias, Address: address})
+		}
+		sort.Slice(kotlinNetwork.Contracts, func(i, j int) bool {
+			return kotlinNetwork.Contracts[i].Alias < kotlinNetwork.Contracts[j].Alias
+		})
+		networks = append(networks, kotlinNetwork)
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Network < networks[j].Network })
+	return networks
+}
+
+// Generate generates a Kotlin file with data classes for all structs and a
+// CadenceGen class with a suspend function per transaction and script, or a
+// function returning a Flow with the flow coroutines, and with Compose a
+// composable State function per script
+func (g *Generator) Generate() (string, error) {
+	serialization, coroutines, err := g.flavors()
+	if err != nil {
+		return "", err
+	}
+	support := ""
+	switch serialization {
+	case SerializationKotlinx:
+		support = serializationSupport
+	case SerializationMoshi:
+		support = moshiSupport
+	}
+
+	tmpl, err := template.New("kotlin").Parse(fileTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		PackageName          string
+		Version              string
+		Serialization        string
+		SerializationSupport string
+		Flow                 bool
+		Compose              bool
+		Structs              []KotlinStruct
+		Functions            []KotlinFunction
+		Networks             []KotlinNetwork
+	}{
+		PackageName:          g.PackageName,
+		Version:              g.Report.CodegenVersion,
+		Serialization:        serialization,
+		SerializationSupport: strings.TrimSuffix(support, "\n"),
+		Flow:                 coroutines == CoroutinesFlow,
+		Compose:              g.Compose,
+		Structs:              g.buildStructs(),
+		Functions:            g.buildFunctions(),
+		Networks:             g.buildNetworks(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	return buffer.String(), nil
+}
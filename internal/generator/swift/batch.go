@@ -0,0 +1,53 @@
+package swift
+
+import (
+	"fmt"
+	"strings"
+)
+
+// batchArity is the largest number of queries of the typed tuple batch
+// overloads, larger batches use the array overload
+const batchArity = 6
+
+// generateBatch generates CadenceGen.batch overloads executing queries
+// concurrently, returning a typed tuple for up to batchArity queries and an
+// array of one result type for any number of them
+func generateBatch(access string) string {
+	var buffer strings.Builder
+	buffer.WriteString("\nextension CadenceGen {")
+	for arity := 2; arity <= batchArity; arity++ {
+		var generics, types, params, lets, results []string
+		for i := 0; i < arity; i++ {
+			name := string(rune('A' + i))
+			target := strings.ToLower(name)
+			generics = append(generics, name+": Decodable")
+			types = append(types, name)
+			params = append(params, "_ "+target+": CadenceTargetType")
+			lets = append(lets, fmt.Sprintf("        async let %sResult: %s = %s.query(timeout: timeout)\n", target, name, target))
+			results = append(results, target+"Result")
+		}
+		buffer.WriteString(fmt.Sprintf("\n    /// Executes %d queries concurrently, failing with the first error\n", arity))
+		buffer.WriteString(fmt.Sprintf("    %sstatic func batch<%s>(%s, timeout: TimeInterval? = nil) async throws -> (%s) {\n",
+			access, strings.Join(generics, ", "), strings.Join(params, ", "), strings.Join(types, ", ")))
+		buffer.WriteString(strings.Join(lets, ""))
+		buffer.WriteString(fmt.Sprintf("        return try await (%s)\n    }\n", strings.Join(results, ", ")))
+	}
+	buffer.WriteString(`
+    /// Executes queries of the same result type concurrently, returning their
+    /// results in order
+    ` + access + `static func batch<T: Decodable>(_ targets: [CadenceTargetType], timeout: TimeInterval? = nil) async throws -> [T] {
+        try await withThrowingTaskGroup(of: (Int, T).self) { group in
+            for (index, target) in targets.enumerated() {
+                group.addTask { (index, try await target.query(timeout: timeout)) }
+            }
+            var results = [T?](repeating: nil, count: targets.count)
+            for try await (index, result) in group {
+                results[index] = result
+            }
+            return results.compactMap { $0 }
+        }
+    }
+}
+`)
+	return buffer.String()
+}
@@ -0,0 +1,195 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// errorTemplate declares the typed errors of generated calls and the query and
+// sendTx helpers throwing them
+const errorTemplate = `
+/// Failures of generated queries and transactions
+{{.Access}}enum CadenceGenError: Error {
+    /// The embedded Cadence code is not valid base64
+    case invalidBase64
+    /// The access node rejected or failed to execute the interaction
+    case scriptError(message: String)
+    /// The result could not be decoded into the return type
+    case decodeFailure(type: String, underlying: Error)
+    /// The access node could not be reached
+    case networkError(underlying: Error)
+    /// The call did not finish within its timeout
+    case timeout(seconds: TimeInterval)
+    {{- if .Integrity}}
+    /// The embedded Cadence code does not match its hash
+    case integrityMismatch(name: String)
+    {{- end}}
+}
+{{- if .Integrity}}
+
+/// Interactions carrying the hex SHA-256 of their trimmed Cadence code,
+/// checked before the code is executed
+{{.Access}}protocol CadenceIntegrityChecked {
+    var cadenceIntegrity: String { get }
+}
+{{- end}}
+
+extension CadenceTargetType {
+    /// Executes the script, throwing CadenceGenError. The call throws
+    /// CancellationError when the calling task is cancelled, e.g. by the task
+    /// modifier of a disappearing view.
+    {{.Access}}func query<T: Decodable>(timeout: TimeInterval? = nil) async throws -> T {
+        try validateCadence()
+        return try await perform(decoding: T.self) {
+            try await withCadenceDeadline(timeout) { {{if .Failover}}try await CadenceAccessNodes.failover { try await flow.query(self) }{{else}}try await flow.query(self){{end}} }
+        }
+    }
+
+    /// Sends the transaction, throwing CadenceGenError. Cancelling the task or
+    /// reaching the timeout stops waiting for the access node; a transaction
+    /// already submitted may still be executed.
+    {{- if .Failover}} Transactions do not fail over to
+    /// other access nodes, as signing may outlast the access node timeout and
+    /// a transaction could be submitted twice.
+    {{- end}}
+    {{.Access}}func sendTx(signers: [FlowSigner], timeout: TimeInterval? = nil) async throws -> Flow.ID {
+        try await sendTx(signers: signers, timeout: timeout) {}
+    }
+
+    /// Sends the transaction with the build options of builder, e.g. its payer
+    {{.Access}}func sendTx(signers: [FlowSigner], timeout: TimeInterval? = nil, @Flow.TransactionBuilder builder: @escaping () -> [Flow.TransactionBuild]) async throws -> Flow.ID {
+        try validateCadence()
+        return try await perform(decoding: Flow.ID.self) {
+            try await withCadenceDeadline(timeout) { try await flow.sendTx(self, singers: signers, builder) }
+        }
+    }
+
+    /// Name of the enum case, e.g. getAddr
+    {{.Access}}var interactionName: String {
+        Mirror(reflecting: self).children.first?.label ?? String(describing: self)
+    }
+    {{- if .Logging}}
+
+    /// Runs operation, reporting it to CadenceLogging.logger
+    private func perform<T>(decoding type: Any.Type, _ operation: () async throws -> T) async throws -> T {
+        try await CadenceLogging.measure(self) { try await mapFailures(decoding: type, operation) }
+    }
+    {{- end}}
+
+    /// Runs operation, mapping its failures to CadenceGenError{{if .Retry}} and retrying
+    /// them according to CadenceRetryPolicy.shared{{end}}
+    private func {{if .Logging}}mapFailures{{else}}perform{{end}}<T>(decoding type: Any.Type, _ operation: () async throws -> T) async throws -> T {
+        {{- if .Retry}}
+        var attempt = 1
+        while true {
+            do {
+                return try await operation()
+            } catch let error as CancellationError {
+                throw error
+            } catch {
+                let failure = CadenceGenError(error, decoding: type)
+                guard let policy = CadenceRetryPolicy.shared, attempt < policy.maxAttempts, policy.retryable(failure, self.type) else {
+                    throw failure
+                }
+                try await Task.sleep(nanoseconds: UInt64(policy.backoff(attempt) * 1_000_000_000))
+                attempt += 1
+            }
+        }
+        {{- else}}
+        do {
+            return try await operation()
+        } catch let error as CancellationError {
+            throw error
+        } catch {
+            throw CadenceGenError(error, decoding: type)
+        }
+        {{- end}}
+    }
+
+    private func validateCadence() throws {
+        {{- if .Integrity}}
+        guard let data = Data(base64Encoded: cadenceBase64) else {
+            throw CadenceGenError.invalidBase64
+        }
+        if let checked = self as? CadenceIntegrityChecked {
+            let code = String(decoding: data, as: UTF8.self).trimmingCharacters(in: .whitespacesAndNewlines)
+            let hash = SHA256.hash(data: Data(code.utf8)).map { String(format: "%02x", $0) }.joined()
+            guard hash == checked.cadenceIntegrity else {
+                throw CadenceGenError.integrityMismatch(name: interactionName)
+            }
+        }
+        {{- else}}
+        guard Data(base64Encoded: cadenceBase64) != nil else {
+            throw CadenceGenError.invalidBase64
+        }
+        {{- end}}
+    }
+}
+
+/// Runs operation until it finishes, the calling task is cancelled or timeout
+/// seconds have passed
+private func withCadenceDeadline<T>(_ timeout: TimeInterval?, _ operation: @escaping () async throws -> T) async throws -> T {
+    try Task.checkCancellation()
+    return try await withThrowingTaskGroup(of: T.self) { group in
+        group.addTask { try await operation() }
+        group.addTask {
+            // Without a timeout this only ends by cancellation
+            try await Task.sleep(nanoseconds: timeout.map { UInt64($0 * 1_000_000_000) } ?? UInt64.max)
+            throw CadenceGenError.timeout(seconds: timeout ?? .infinity)
+        }
+        defer { group.cancelAll() }
+        return try await group.next()!
+    }
+}
+
+extension CadenceGenError {
+    init(_ error: Error, decoding type: Any.Type) {
+        switch error {
+        case let error as CadenceGenError:
+            self = error
+        case is DecodingError:
+            self = .decodeFailure(type: String(describing: type), underlying: error)
+        case is URLError:
+            self = .networkError(underlying: error)
+        default:
+            self = .scriptError(message: error.localizedDescription)
+        }
+    }
+}
+`
+
+// generateErrors renders the typed error model with the access modifier
+// access, e.g. "public ", retrying and logging calls if enabled, checking the
+// integrity of the embedded code if enabled and failing over between the
+// access nodes of the report
+func (g *Generator) generateErrors(access string) (string, error) {
+	tmpl, err := template.New("errors").Parse(errorTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse error template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Access    string
+		Retry     bool
+		Logging   bool
+		Failover  bool
+		Integrity bool
+	}{
+		Access:    access,
+		Retry:     g.Retry,
+		Logging:   g.Logging,
+		Failover:  len(g.Report.AccessNodes) > 0,
+		Integrity: g.Integrity,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute error template: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// SetIntegrity enables the integrity check of the embedded code of a case
+// before it is executed
+func (g *Generator) SetIntegrity(integrity bool) {
+	g.Integrity = integrity
+}
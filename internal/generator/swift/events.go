@@ -0,0 +1,104 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// SwiftEvent represents a Cadence event decoded into a Swift struct
+type SwiftEvent struct {
+	Name       string // Swift struct name, e.g. FlowTokenTokensDeposited
+	Identifier string // Qualified Cadence name, e.g. FlowToken.TokensDeposited
+	Fields     []SwiftField
+}
+
+const eventTemplate = `
+/// A generated Cadence event payload
+{{.Access}}protocol CadenceEvent: Decodable {
+    /// Contract and event name, e.g. FlowToken.TokensDeposited
+    static var eventName: String { get }
+}
+
+extension Flow.Event {
+    /// Decodes the payload if this is an E event, returning nil for other events
+    {{.Access}}func decode<E: CadenceEvent>(_ type: E.Type) throws -> E? {
+        guard self.type.hasSuffix("." + E.eventName) else {
+            return nil
+        }
+        do {
+            let argument = try JSONDecoder().decode(Flow.Argument.self, from: payload.data)
+            return try argument.decode(E.self)
+        } catch {
+            throw CadenceGenError.decodeFailure(type: E.eventName, underlying: error)
+        }
+    }
+}
+
+extension Flow.TransactionResult {
+    /// The emitted E events, in emission order
+    {{.Access}}func events<E: CadenceEvent>(_ type: E.Type) throws -> [E] {
+        try events.compactMap { try $0.decode(type) }
+    }
+}
+{{- range .Events}}
+
+/// Payload of {{.Identifier}}
+{{$.Access}}struct {{.Name}}: CadenceEvent {
+    {{$.Access}}static let eventName = "{{.Identifier}}"
+    {{- range .Fields}}
+    {{$.Access}}let {{.Name}}: {{.Type}}{{if .Optional}}?{{end}}
+    {{- end}}
+}
+{{- end}}
+`
+
+// events converts the events of the report into Swift structs, sorted by name
+func (g *Generator) events() []SwiftEvent {
+	var events []SwiftEvent
+	for identifier, event := range g.Report.Events {
+		swiftEvent := SwiftEvent{
+			Name:       event.Contract + event.Name,
+			Identifier: identifier,
+		}
+		for _, field := range event.Fields {
+			swiftEvent.Fields = append(swiftEvent.Fields, SwiftField{
+				Name:     field.Name,
+				Type:     convertCadenceTypeToSwift(strings.TrimSuffix(field.TypeStr, "?")),
+				Optional: field.Optional,
+			})
+		}
+		events = append(events, swiftEvent)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Name < events[j].Name })
+	return events
+}
+
+// generateEvents generates a struct per event with helpers decoding them from
+// transaction results, with the access modifier access. It returns nothing if
+// the report has no events.
+func (g *Generator) generateEvents(access string) (string, error) {
+	events := g.events()
+	if len(events) == 0 {
+		return "", nil
+	}
+
+	tmpl, err := template.New("events").Parse(eventTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse event template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Events []SwiftEvent
+		Access string
+	}{
+		Events: events,
+		Access: access,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute event template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,98 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+)
+
+// accessNodeList is the prioritized access nodes of a network
+type accessNodeList struct {
+	Network string
+	Nodes   []string
+}
+
+// failoverTemplate declares the access nodes of each network and runs calls
+// on them in order of priority
+const failoverTemplate = `
+/// Access nodes of each network in order of priority, from the accessNodes
+/// section of the report
+{{.Access}}enum CadenceAccessNodes {
+    {{.Access}}static var nodes: [String: [URL]] = [
+        {{- range .Networks}}
+        "{{.Network}}": [{{range $index, $node := .Nodes}}{{if $index}}, {{end}}URL(string: "{{$node}}")!{{end}}],
+        {{- end}}
+    ]
+    /// Seconds after which an access node is considered unavailable
+    {{.Access}}static var timeout: TimeInterval = 10
+
+    /// Runs the script operation on the access nodes of the configured network
+    /// in order, moving to the next one on server errors and timeouts.
+    /// Transactions are sent to the access node in use instead.
+    static func failover<T>(_ operation: @escaping () async throws -> T) async throws -> T {
+        let chainID = flow.chainID
+        guard let urls = nodes[chainID.name], let last = urls.last, urls.count > 1 else {
+            return try await operation()
+        }
+        for url in urls.dropLast() {
+            use(url, on: chainID)
+            do {
+                return try await withCadenceDeadline(timeout, operation)
+            } catch let error as CancellationError {
+                throw error
+            } catch where isAccessNodeFailure(error) {
+                continue
+            }
+        }
+        use(last, on: chainID)
+        return try await withCadenceDeadline(timeout, operation)
+    }
+
+    /// Whether an operation failed because of its access node, e.g. a 5xx
+    /// response or a timeout
+    {{.Access}}static func isAccessNodeFailure(_ error: Error) -> Bool {
+        switch error {
+        case CadenceGenError.timeout, is URLError:
+            return true
+        default:
+            return String(describing: error).range(of: #"\b5\d\d\b"#, options: .regularExpression) != nil
+        }
+    }
+
+    private static func use(_ url: URL, on chainID: Flow.ChainID) {
+        flow.configure(chainID: chainID, accessAPI: Flow.FlowHTTPAPI(chainID: .custom(name: chainID.name, transport: .HTTP(url))))
+    }
+}
+`
+
+// generateFailover renders CadenceAccessNodes with the access nodes of the
+// report and the access modifier access. It returns nothing if the report
+// configures no access nodes.
+func (g *Generator) generateFailover(access string) (string, error) {
+	if len(g.Report.AccessNodes) == 0 {
+		return "", nil
+	}
+	var networks []accessNodeList
+	for network, nodes := range g.Report.AccessNodes {
+		networks = append(networks, accessNodeList{Network: network, Nodes: nodes})
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Network < networks[j].Network })
+
+	tmpl, err := template.New("failover").Parse(failoverTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse failover template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Access   string
+		Networks []accessNodeList
+	}{
+		Access:   access,
+		Networks: networks,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute failover template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,111 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// fixtureSamples are the sample literals of the mapped Swift types
+var fixtureSamples = map[string]string{
+	"Int":          "1",
+	"UInt":         "1",
+	"UInt8":        "1",
+	"UInt16":       "1",
+	"UInt32":       "1",
+	"UInt64":       "1",
+	"Int8":         "1",
+	"Int16":        "1",
+	"Int32":        "1",
+	"Int64":        "1",
+	"BigInt":       "1",
+	"BigUInt":      "1",
+	"Bool":         "true",
+	"Flow.Address": `Flow.Address(hex: "0x0000000000000001")`,
+	"Decimal":      "1.0",
+}
+
+// fixtureName returns the PreviewFixtures property of a struct, e.g.
+// NFTCollection to nftCollection
+func fixtureName(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+	return strings.ToLower(string(runes[:upper])) + string(runes[upper:])
+}
+
+// fixtureValue returns a sample Swift expression of swiftType named after
+// field, or false if the type has no sample
+func fixtureValue(swiftType, field string, structs map[string]bool) (string, bool) {
+	if sample, ok := fixtureSamples[swiftType]; ok {
+		return sample, true
+	}
+	switch {
+	case swiftType == "String":
+		return fmt.Sprintf("%q", field), true
+	case swiftType == "AnyDecodable":
+		return fmt.Sprintf("AnyDecodable(%q)", field), true
+	case structs[swiftType]:
+		return "PreviewFixtures." + fixtureName(swiftType), true
+	case strings.HasPrefix(swiftType, "[") && strings.HasSuffix(swiftType, "]"):
+		element, ok := fixtureValue(strings.TrimSuffix(strings.TrimPrefix(swiftType, "["), "]"), field, structs)
+		return "[" + element + "]", ok
+	case strings.HasPrefix(swiftType, "Dictionary<") && strings.HasSuffix(swiftType, ">"):
+		parts := strings.SplitN(strings.TrimSuffix(strings.TrimPrefix(swiftType, "Dictionary<"), ">"), ", ", 2)
+		if len(parts) != 2 {
+			return "", false
+		}
+		key, keyOK := fixtureValue(parts[0], field, structs)
+		value, valueOK := fixtureValue(parts[1], field, structs)
+		return "[" + key + ": " + value + "]", keyOK && valueOK
+	}
+	return "", false
+}
+
+// generateFixtures generates PreviewFixtures with a sample instance of every
+// struct, for SwiftUI previews and snapshot tests. Optional fields without a
+// sample are nil; structs with other fields without a sample are skipped.
+func generateFixtures(structs []SwiftStruct) string {
+	sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
+	names := make(map[string]bool)
+	for _, s := range structs {
+		names[s.Name] = true
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("\n#if DEBUG\n/// Sample instances of the generated structs for SwiftUI previews and snapshot tests\nenum PreviewFixtures {")
+	for _, s := range structs {
+		var args []string
+		complete := true
+		for _, field := range s.Fields {
+			value, ok := fixtureValue(field.Type, field.Name, names)
+			if !ok {
+				if !field.Optional {
+					complete = false
+					break
+				}
+				value = "nil"
+			}
+			args = append(args, field.Name+": "+value)
+		}
+		if !complete {
+			buffer.WriteString(fmt.Sprintf("\n    // %s has fields without sample values\n", s.Name))
+			continue
+		}
+		buffer.WriteString(fmt.Sprintf("\n    static let %s = %s(%s)\n", fixtureName(s.Name), s.Name, strings.Join(args, ", ")))
+	}
+	buffer.WriteString("}\n#endif\n")
+	return buffer.String()
+}
+
+// SetPreviewFixtures enables the PreviewFixtures sample instances
+func (g *Generator) SetPreviewFixtures(previewFixtures bool) {
+	g.PreviewFixtures = previewFixtures
+}
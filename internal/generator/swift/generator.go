@@ -11,9 +11,18 @@ import (
 
 // Generator handles Swift code generation
 type Generator struct {
-	Report  analyzer.Report
-	Files   map[string]string
-	BaseDir string
+	Report          analyzer.Report
+	Files           map[string]string
+	BaseDir         string
+	WalletKit       bool
+	Telemetry       bool
+	PreviewFixtures bool
+	ObjC            bool
+	Server          bool
+	Retry           bool
+	Logging         bool
+	Integrity       bool
+	Names           map[string]string // Case names by file name, overriding the derived names
 }
 
 // New creates a new Swift code generator
@@ -54,6 +63,9 @@ var typeMapping = map[string]string{
 	"AnyStruct": "AnyDecodable",
 }
 
+// swiftStringEscaper escapes text for Swift string literals
+var swiftStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
 // SwiftCase represents a case in the generated enum
 type SwiftCase struct {
 	Name       string
@@ -61,6 +73,13 @@ type SwiftCase struct {
 	ReturnType string
 	Base64     string
 	Type       string
+	Signers    []SwiftSigner        // Authorizers of a transaction, in prepare order
+	Deprecated string               // Escaped deprecation message of the // codegen:deprecated pragma
+	Source     string               // Path of the originating .cdc file
+	Hash       string               // Hex SHA-256 of the trimmed Cadence code
+	Pagination *analyzer.Pagination // Offset and limit parameters of a paginated script
+	Proposer   string               // Label of the signer holding the proposer role, if any
+	Payer      string               // Label of the signer holding the payer role, if any
 }
 
 // SwiftParameter represents a parameter in Swift
@@ -86,9 +105,9 @@ type SwiftField struct {
 
 const structTemplate = `
 /// Generated Cadence struct
-struct {{.Name}}: Decodable {
+{{.Access}}struct {{.Name}}: Decodable {
     {{- range .Fields}}
-    let {{.Name}}: {{.Type}}{{if .Optional}}?{{end}}
+    {{$.Access}}let {{.Name}}: {{.Type}}{{if .Optional}}?{{end}}
     {{- end}}
 }
 `
@@ -96,23 +115,56 @@ struct {{.Name}}: Decodable {
 const enumTemplate = `
 /// Generated from Cadence files{{if .Tag}} in {{.Tag}} folder{{end}}
 {{if .Tag}}extension CadenceGen {
-    enum {{.Tag}}: CadenceTargetType, MirrorAssociated {
-{{else}}enum CadenceGen: CadenceTargetType, MirrorAssociated {
+    {{.Access}}enum {{.Tag}}: CadenceTargetType, MirrorAssociated{{if .Integrity}}, CadenceIntegrityChecked{{end}} {
+{{else}}{{.Access}}enum CadenceGen: CadenceTargetType, MirrorAssociated{{if .Integrity}}, CadenceIntegrityChecked{{end}} {
 {{end}}
     {{- range .Cases}}
+    /// Source: {{.Source}}
+    {{- if .Hash}}
+    /// SHA-256: {{.Hash}}
+    {{- end}}
+    /// Generated by cadence-codegen{{if $.Version}} {{$.Version}}{{end}}
+    {{- if .Deprecated}}
+    @available(*, deprecated, message: "{{.Deprecated}}")
+    {{- end}}
     case {{.Name}}({{- range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}: {{$param.Type}}{{if $param.Optional}}?{{end}}{{- end}})
     {{- end}}
     
-    var cadenceBase64: String {
+    {{- if $.Signed}}
+
+    /// Base64 encoded Cadence code of the cases, covered by the signed manifest
+    {{$.Access}}static let cadenceCodes: [String: String] = [{{if not .Cases}}:{{end}}
+        {{- range .Cases}}
+        "{{.Name}}": "{{.Base64}}",
+        {{- end}}
+    ]
+    {{- end}}
+    
+    {{$.Access}}var cadenceBase64: String {
         switch self {
         {{- range .Cases}}
         case .{{.Name}}:
+            {{- if $.Signed}}
+            return Self.cadenceCodes["{{.Name}}"]!
+            {{- else}}
             return "{{.Base64}}"
+            {{- end}}
         {{- end}}
         }
     }
+    {{- if $.Integrity}}
+
+    {{$.Access}}var cadenceIntegrity: String {
+        switch self {
+        {{- range .Cases}}
+        case .{{.Name}}:
+            return "{{.Hash}}"
+        {{- end}}
+        }
+    }
+    {{- end}}
     
-    var type: CadenceType {
+    {{$.Access}}var type: CadenceType {
         switch self {
         {{- range .Cases}}
         case .{{.Name}}:
@@ -121,11 +173,11 @@ const enumTemplate = `
         }
     }
     
-    var arguments: [Flow.Argument] {
+    {{$.Access}}var arguments: [Flow.Argument] {
         associatedValues.compactMap { $0.value.toFlowValue() }.toArguments()
     }
     
-    var returnType: Decodable.Type {
+    {{$.Access}}var returnType: Decodable.Type {
         if type == .transaction {
             return Flow.ID.self
         }
@@ -143,6 +195,11 @@ const enumTemplate = `
     }
 }{{if .Tag}} }{{end}}`
 
+// FunctionName returns the name of the generated function for a Cadence file
+func FunctionName(filename string) string {
+	return formatFunctionName(filename)
+}
+
 // formatFunctionName formats the filename into a valid Swift function name
 func formatFunctionName(filename string) string {
 	// Remove .cdc extension
@@ -216,18 +273,15 @@ func convertCadenceTypeToSwift(cadenceType string) string {
 	return swiftType
 }
 
-// Generate generates Swift code for all transactions and scripts
-func (g *Generator) Generate() (string, error) {
-	var buffer bytes.Buffer
+// build converts the report into Swift structs, the untagged cases and the
+// cases grouped by tag
+func (g *Generator) build() ([]SwiftStruct, []SwiftCase, map[string][]SwiftCase) {
 	var cases []SwiftCase
 	var structs []SwiftStruct
 
 	// Map to store cases by tag
 	taggedCases := make(map[string][]SwiftCase)
 
-	// Add header
-	buffer.WriteString("import Flow\nimport BigInt\nimport Foundation\n")
-
 	// Generate structs from composite types
 	for name, composite := range g.Report.Structs {
 		swiftStruct := SwiftStruct{
@@ -249,27 +303,30 @@ func (g *Generator) Generate() (string, error) {
 		structs = append(structs, swiftStruct)
 	}
 
-	// Generate struct code
-	structTmpl, err := template.New("struct").Parse(structTemplate)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse struct template: %w", err)
-	}
-
-	for _, s := range structs {
-		err = structTmpl.Execute(&buffer, s)
-		if err != nil {
-			return "", fmt.Errorf("failed to execute struct template: %w", err)
-		}
-		buffer.WriteString("\n")
-	}
-
 	// Generate cases for transactions
 	for filename, result := range g.Report.Transactions {
 		swiftCase := SwiftCase{
-			Name:       formatFunctionName(filename),
+			Name:       g.functionName(filename),
 			Parameters: make([]SwiftParameter, 0),
 			Base64:     result.Base64,
 			Type:       "transaction",
+			Deprecated: swiftStringEscaper.Replace(result.Deprecated),
+			Source:     result.SourcePath(),
+			Hash:       result.CodeHash(),
+		}
+
+		for _, signer := range result.Signers {
+			swiftCase.Signers = append(swiftCase.Signers, SwiftSigner{
+				Name:         signer.Name,
+				Entitlements: parseEntitlements(signer.TypeStr),
+				Label:        result.SignerLabel(signer.Name),
+			})
+		}
+		if signer, ok := result.Roles[analyzer.RoleProposer]; ok {
+			swiftCase.Proposer = result.SignerLabel(signer)
+		}
+		if signer, ok := result.Roles[analyzer.RolePayer]; ok {
+			swiftCase.Payer = result.SignerLabel(signer)
 		}
 
 		for _, param := range result.Parameters {
@@ -292,10 +349,14 @@ func (g *Generator) Generate() (string, error) {
 	// Generate cases for scripts
 	for filename, result := range g.Report.Scripts {
 		swiftCase := SwiftCase{
-			Name:       formatFunctionName(filename),
+			Name:       g.functionName(filename),
 			Parameters: make([]SwiftParameter, 0),
 			Base64:     result.Base64,
 			Type:       "query",
+			Deprecated: swiftStringEscaper.Replace(result.Deprecated),
+			Source:     result.SourcePath(),
+			Hash:       result.CodeHash(),
+			Pagination: result.Pagination,
 		}
 
 		if result.ReturnType != "" {
@@ -320,37 +381,184 @@ func (g *Generator) Generate() (string, error) {
 		}
 	}
 
-	// Generate enum with all cases
+	return structs, cases, taggedCases
+}
+
+// writeStructs writes the given structs with the access modifier access, e.g. "public "
+func writeStructs(buffer *bytes.Buffer, structs []SwiftStruct, access string) error {
+	structTmpl, err := template.New("struct").Parse(structTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse struct template: %w", err)
+	}
+
+	for _, s := range structs {
+		err = structTmpl.Execute(buffer, struct {
+			SwiftStruct
+			Access string
+		}{
+			SwiftStruct: s,
+			Access:      access,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to execute struct template: %w", err)
+		}
+		buffer.WriteString("\n")
+	}
+	return nil
+}
+
+// writeEnum writes the CadenceGen enum of the untagged cases, or the nested
+// enum of a tag, with the access modifier access
+func (g *Generator) writeEnum(buffer *bytes.Buffer, cases []SwiftCase, tag string, access string) error {
 	tmpl, err := template.New("enum").Parse(enumTemplate)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
+		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	// First generate the base CadenceGen enum
-	err = tmpl.Execute(&buffer, struct {
-		Cases []SwiftCase
-		Tag   string
+	err = tmpl.Execute(buffer, struct {
+		Cases     []SwiftCase
+		Tag       string
+		Access    string
+		Version   string
+		Signed    bool
+		Integrity bool
 	}{
-		Cases: cases,
-		Tag:   "",
+		Cases:     cases,
+		Tag:       tag,
+		Access:    access,
+		Version:   g.Report.CodegenVersion,
+		Signed:    g.Report.Manifest != nil,
+		Integrity: g.Integrity,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+// Generate generates Swift code for all transactions and scripts
+func (g *Generator) Generate() (string, error) {
+	var buffer bytes.Buffer
+	if g.Server && g.WalletKit {
+		return "", fmt.Errorf("Flow Wallet Kit adapters are not supported in the server-side flavor")
+	}
+	structs, cases, taggedCases := g.build()
+
+	// Add header
+	buffer.WriteString(g.header())
+
+	// Generate struct code
+	if err := writeStructs(&buffer, structs, ""); err != nil {
+		return "", err
+	}
+
+	// First generate the base CadenceGen enum
+	if err := g.writeEnum(&buffer, cases, "", ""); err != nil {
+		return "", err
+	}
+	if err := writeSendFunctions(&buffer, cases, "", ""); err != nil {
+		return "", err
+	}
+	if err := writePagination(&buffer, cases, "", ""); err != nil {
+		return "", err
 	}
 
 	// Then generate tagged cases in separate extensions
 	for tag, tagCases := range taggedCases {
 		buffer.WriteString("\n")
-		err = tmpl.Execute(&buffer, struct {
-			Cases []SwiftCase
-			Tag   string
-		}{
-			Cases: tagCases,
-			Tag:   tag,
-		})
+		if err := g.writeEnum(&buffer, tagCases, tag, ""); err != nil {
+			return "", err
+		}
+		if err := writeSendFunctions(&buffer, tagCases, tag, ""); err != nil {
+			return "", err
+		}
+		if err := writePagination(&buffer, tagCases, tag, ""); err != nil {
+			return "", err
+		}
+	}
+
+	// Add the typed errors thrown by generated calls
+	errors, err := g.generateErrors("")
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(errors)
+	if g.Retry {
+		retry, err := generateRetry("")
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString(retry)
+	}
+	if g.Logging {
+		logging, err := generateLogging("")
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString(logging)
+	}
+	failover, err := g.generateFailover("")
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(failover)
+
+	roles, err := generateSignerRoles("")
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(roles)
+	buffer.WriteString(generateBatch(""))
+	setup, err := g.generateSetup("", false)
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(setup)
+
+	// Add the structs of the events declared in contracts
+	events, err := g.generateEvents("")
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(events)
+
+	// Add the MetadataViews views if scripts resolve them
+	metadata, err := g.generateMetadataViews("")
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(metadata)
+
+	// Add the signed manifest of the embedded code if the report is signed
+	manifest, err := g.generateManifest("", false)
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(manifest)
+
+	if g.Telemetry {
+		buffer.WriteString(telemetryCode)
+	}
+
+	if g.PreviewFixtures {
+		buffer.WriteString(generateFixtures(structs))
+	}
+
+	if g.ObjC {
+		objc, err := generateObjC(cases, taggedCases)
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString(objc)
+	}
+
+	// Finally generate the optional Flow Wallet Kit adapters
+	if g.WalletKit {
+		walletKit, err := g.generateWalletKit()
 		if err != nil {
-			return "", fmt.Errorf("failed to execute template: %w", err)
+			return "", err
 		}
+		buffer.WriteString(walletKit)
 	}
 
 	return buffer.String(), nil
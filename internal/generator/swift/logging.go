@@ -0,0 +1,67 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// loggingTemplate declares the logger receiving generated calls
+const loggingTemplate = `
+/// Receives generated queries and transactions, e.g. to forward them to
+/// os.Logger or the logging backend of a service
+{{.}}protocol CadenceLogger {
+    /// Called before the call is executed
+    func onRequest(name: String, type: CadenceType)
+    /// Called after the call succeeded, with its duration in seconds
+    func onResponse(name: String, type: CadenceType, duration: TimeInterval)
+    /// Called after the call failed, with its duration in seconds
+    func onError(name: String, type: CadenceType, duration: TimeInterval, error: Error)
+}
+
+{{.}}extension CadenceLogger {
+    func onRequest(name: String, type: CadenceType) {}
+    func onResponse(name: String, type: CadenceType, duration: TimeInterval) {}
+    func onError(name: String, type: CadenceType, duration: TimeInterval, error: Error) {}
+}
+
+{{.}}enum CadenceLogging {
+    /// Logger of every generated call, nil disables logging
+    {{.}}static var logger: CadenceLogger?
+
+    static func measure<T>(_ target: CadenceTargetType, _ operation: () async throws -> T) async throws -> T {
+        guard let logger = logger else {
+            return try await operation()
+        }
+        let name = target.interactionName
+        let start = Date()
+        logger.onRequest(name: name, type: target.type)
+        do {
+            let result = try await operation()
+            logger.onResponse(name: name, type: target.type, duration: Date().timeIntervalSince(start))
+            return result
+        } catch {
+            logger.onError(name: name, type: target.type, duration: Date().timeIntervalSince(start), error: error)
+            throw error
+        }
+    }
+}
+`
+
+// generateLogging renders CadenceLogger with the access modifier access
+func generateLogging(access string) (string, error) {
+	tmpl, err := template.New("logging").Parse(loggingTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse logging template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, access); err != nil {
+		return "", fmt.Errorf("failed to execute logging template: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// SetLogging enables the logger hooks of generated calls
+func (g *Generator) SetLogging(logging bool) {
+	g.Logging = logging
+}
@@ -0,0 +1,120 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// cryptoImport imports SHA256 and Curve25519 from CryptoKit on Apple
+// platforms and from swift-crypto elsewhere
+const cryptoImport = `#if canImport(CryptoKit)
+import CryptoKit
+#else
+import Crypto
+#endif
+`
+
+// manifestEntry is a signed script or transaction of the manifest
+type manifestEntry struct {
+	Key  string // Manifest key, e.g. scripts/get_balance.cdc
+	Enum string // Enum holding its code, e.g. CadenceGen.Token
+	Name string // Case of the script or transaction
+}
+
+// manifestTemplate embeds the signed manifest of the report and verifies
+// the embedded code against it
+const manifestTemplate = `
+
+/// Signed manifest of the embedded Cadence code
+{{.Access}}enum CadenceManifest {
+    /// Base64 Ed25519 public key the report was signed with
+    {{.Access}}static let publicKey = "{{.PublicKey}}"
+    /// Base64 Ed25519 signature of the manifest payload
+    {{.Access}}static let signature = "{{.Signature}}"
+
+    /// Manifest key and embedded code of each signed script and transaction
+    static var entries: [(key: String, base64: String?)] {
+        [
+            {{- range .Entries}}
+            ("{{.Key}}", {{.Enum}}.cadenceCodes["{{.Name}}"]),
+            {{- end}}
+        ]
+    }
+
+    /// Verifies that the embedded Cadence code is unchanged since the report
+    /// was signed. Pass the base64 Ed25519 public key pinned by the app rather
+    /// than CadenceManifest.publicKey, which ships in the same binary as the code.
+    {{.Access}}static func verify(publicKey: String) -> Bool {
+        guard let keyData = Data(base64Encoded: publicKey),
+              let key = try? Curve25519.Signing.PublicKey(rawRepresentation: keyData),
+              let signatureData = Data(base64Encoded: signature) else {
+            return false
+        }
+        var payload = ""
+        for entry in entries {
+            guard let base64 = entry.base64,
+                  let data = Data(base64Encoded: base64),
+                  let code = String(data: data, encoding: .utf8) else {
+                return false
+            }
+            let digest = SHA256.hash(data: Data(code.trimmingCharacters(in: .whitespacesAndNewlines).utf8))
+            payload += entry.key + " " + digest.map { String(format: "%02x", $0) }.joined() + "\n"
+        }
+        return key.isValidSignature(signatureData, for: Data(payload.utf8))
+    }
+}
+`
+
+// generateManifest renders the signed manifest of the report with the access
+// modifier access. It returns nothing if the report is not signed, or if
+// untagged is set and a signed script or transaction is tagged, and an error
+// if the manifest does not match the code of the report.
+func (g *Generator) generateManifest(access string, untagged bool) (string, error) {
+	manifest := g.Report.Manifest
+	if manifest == nil {
+		return "", nil
+	}
+	if err := g.Report.VerifyManifest(); err != nil {
+		return "", fmt.Errorf("failed to verify report manifest: %w", err)
+	}
+
+	var entries []manifestEntry
+	for _, key := range manifest.Keys() {
+		kind, fileName, _ := strings.Cut(key, "/")
+		result := g.Report.Scripts[fileName]
+		if kind == "transactions" {
+			result = g.Report.Transactions[fileName]
+		}
+		if untagged && result.Tag != "" {
+			return "", nil
+		}
+		entry := manifestEntry{Key: key, Enum: "CadenceGen", Name: g.functionName(fileName)}
+		if result.Tag != "" {
+			entry.Enum += "." + result.Tag
+		}
+		entries = append(entries, entry)
+	}
+
+	tmpl, err := template.New("manifest").Parse(manifestTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse manifest template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		PublicKey string
+		Signature string
+		Entries   []manifestEntry
+		Access    string
+	}{
+		PublicKey: manifest.PublicKey,
+		Signature: manifest.Signature,
+		Entries:   entries,
+		Access:    access,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute manifest template: %w", err)
+	}
+	return buffer.String(), nil
+}
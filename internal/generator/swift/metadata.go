@@ -0,0 +1,219 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// metadataView is a MetadataViews type, declared unless the report already
+// contains a struct of the same flattened name
+type metadataView struct {
+	Name string
+	Code string
+}
+
+// metadataViews are the templates of the MetadataViews types, executed with
+// the access modifier
+var metadataViews = []metadataView{
+	{"MetadataViewsFile", `
+/// A MetadataViews.HTTPFile or MetadataViews.IPFSFile
+{{.}}enum MetadataViewsFile: Decodable {
+    case http(url: String)
+    case ipfs(cid: String, path: String?)
+
+    private enum CodingKeys: String, CodingKey {
+        case url, cid, path
+    }
+
+    {{.}}init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let url = try container.decodeIfPresent(String.self, forKey: .url) {
+            self = .http(url: url)
+        } else {
+            self = .ipfs(cid: try container.decode(String.self, forKey: .cid), path: try container.decodeIfPresent(String.self, forKey: .path))
+        }
+    }
+
+    /// The URL of the file, resolving IPFS files through gateway
+    {{.}}func url(gateway: String = "https://ipfs.io/ipfs/") -> URL? {
+        switch self {
+        case let .http(url):
+            return URL(string: url)
+        case let .ipfs(cid, path):
+            return URL(string: gateway + cid + (path.map { "/" + $0 } ?? ""))
+        }
+    }
+}
+`},
+	{"MetadataViewsDisplay", `
+/// MetadataViews.Display
+{{.}}struct MetadataViewsDisplay: MetadataView {
+    {{.}}static let viewName = "Display"
+    {{.}}let name: String
+    {{.}}let description: String
+    {{.}}let thumbnail: MetadataViewsFile
+}
+`},
+	{"MetadataViewsMedia", `
+/// MetadataViews.Media
+{{.}}struct MetadataViewsMedia: Decodable {
+    {{.}}let file: MetadataViewsFile
+    {{.}}let mediaType: String
+}
+`},
+	{"MetadataViewsMedias", `
+/// MetadataViews.Medias
+{{.}}struct MetadataViewsMedias: MetadataView {
+    {{.}}static let viewName = "Medias"
+    {{.}}let items: [MetadataViewsMedia]
+}
+`},
+	{"MetadataViewsRoyalty", `
+/// MetadataViews.Royalty, cut is a fraction of the sale price, e.g. 0.05
+{{.}}struct MetadataViewsRoyalty: Decodable {
+    {{.}}let receiver: AnyDecodable
+    {{.}}let cut: Decimal
+    {{.}}let description: String
+}
+`},
+	{"MetadataViewsRoyalties", `
+/// MetadataViews.Royalties
+{{.}}struct MetadataViewsRoyalties: MetadataView {
+    {{.}}static let viewName = "Royalties"
+    {{.}}let cutInfos: [MetadataViewsRoyalty]
+
+    /// The sum of the royalty cuts as a fraction of the sale price
+    {{.}}var totalCut: Decimal {
+        cutInfos.reduce(0) { $0 + $1.cut }
+    }
+}
+`},
+	{"MetadataViewsEdition", `
+/// MetadataViews.Edition
+{{.}}struct MetadataViewsEdition: Decodable {
+    {{.}}let name: String?
+    {{.}}let number: UInt64
+    {{.}}let max: UInt64?
+}
+`},
+	{"MetadataViewsEditions", `
+/// MetadataViews.Editions
+{{.}}struct MetadataViewsEditions: MetadataView {
+    {{.}}static let viewName = "Editions"
+    {{.}}let infoList: [MetadataViewsEdition]
+}
+`},
+	{"MetadataViewsSerial", `
+/// MetadataViews.Serial
+{{.}}struct MetadataViewsSerial: MetadataView {
+    {{.}}static let viewName = "Serial"
+    {{.}}let number: UInt64
+}
+`},
+	{"MetadataViewsExternalURL", `
+/// MetadataViews.ExternalURL
+{{.}}struct MetadataViewsExternalURL: MetadataView {
+    {{.}}static let viewName = "ExternalURL"
+    {{.}}let url: String
+}
+`},
+	{"MetadataViewsLicense", `
+/// MetadataViews.License, an SPDX license identifier
+{{.}}struct MetadataViewsLicense: MetadataView {
+    {{.}}static let viewName = "License"
+    {{.}}let spdxIdentifier: String
+}
+`},
+	{"MetadataViewsRarity", `
+/// MetadataViews.Rarity
+{{.}}struct MetadataViewsRarity: Decodable {
+    {{.}}let score: Decimal?
+    {{.}}let max: Decimal?
+    {{.}}let description: String?
+}
+`},
+	{"MetadataViewsTrait", `
+/// MetadataViews.Trait
+{{.}}struct MetadataViewsTrait: Decodable {
+    {{.}}let name: String
+    {{.}}let value: AnyDecodable
+    {{.}}let displayType: String?
+    {{.}}let rarity: MetadataViewsRarity?
+}
+`},
+	{"MetadataViewsTraits", `
+/// MetadataViews.Traits
+{{.}}struct MetadataViewsTraits: MetadataView {
+    {{.}}static let viewName = "Traits"
+    {{.}}let traits: [MetadataViewsTrait]
+}
+`},
+	{"MetadataViewsNFTCollectionDisplay", `
+/// MetadataViews.NFTCollectionDisplay
+{{.}}struct MetadataViewsNFTCollectionDisplay: MetadataView {
+    {{.}}static let viewName = "NFTCollectionDisplay"
+    {{.}}let name: String
+    {{.}}let description: String
+    {{.}}let externalURL: MetadataViewsExternalURL
+    {{.}}let squareImage: MetadataViewsMedia
+    {{.}}let bannerImage: MetadataViewsMedia
+    {{.}}let socials: Dictionary<String, MetadataViewsExternalURL>
+}
+`},
+}
+
+// metadataResolverTemplate resolves typed views from the dictionaries of
+// views returned by scripts
+const metadataResolverTemplate = `
+/// A MetadataViews view resolvable from a dictionary of views
+{{.}}protocol MetadataView: Decodable {
+    /// Name of the view in MetadataViews, e.g. Display
+    static var viewName: String { get }
+}
+
+extension Dictionary where Key == String, Value == AnyDecodable {
+    /// Decodes the V view of a dictionary of views keyed by view name or type
+    /// identifier, e.g. A.1d7e57aa55817448.MetadataViews.Display, returning
+    /// nil if it is missing
+    {{.}}func metadataView<V: MetadataView>(_ type: V.Type) throws -> V? {
+        guard let view = first(where: { $0.key == V.viewName || $0.key.hasSuffix(".MetadataViews." + V.viewName) })?.value.value,
+              !(view is NSNull) else {
+            return nil
+        }
+        do {
+            let data = try JSONSerialization.data(withJSONObject: view)
+            return try JSONDecoder().decode(V.self, from: data)
+        } catch {
+            throw CadenceGenError.decodeFailure(type: "MetadataViews." + V.viewName, underlying: error)
+        }
+    }
+}
+`
+
+// generateMetadataViews renders the MetadataViews types and their resolver
+// with the access modifier access if a script imports MetadataViews. Types
+// the report already declares are left out.
+func (g *Generator) generateMetadataViews(access string) (string, error) {
+	if !g.Report.ScriptsImport("MetadataViews") {
+		return "", nil
+	}
+	var code strings.Builder
+	code.WriteString(metadataResolverTemplate)
+	for _, view := range metadataViews {
+		if _, ok := g.Report.Structs[view.Name]; !ok {
+			code.WriteString(view.Code)
+		}
+	}
+
+	tmpl, err := template.New("metadata").Parse(code.String())
+	if err != nil {
+		return "", fmt.Errorf("failed to parse metadata views template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, access); err != nil {
+		return "", fmt.Errorf("failed to execute metadata views template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,186 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// objcScalars are the Swift types representable in Objective-C as they are
+var objcScalars = map[string]bool{
+	"String": true,
+	"Bool":   true,
+	"Int":    true,
+	"UInt":   true,
+	"Int8":   true,
+	"Int16":  true,
+	"Int32":  true,
+	"Int64":  true,
+	"UInt8":  true,
+	"UInt16": true,
+	"UInt32": true,
+	"UInt64": true,
+}
+
+// objcParameter is a parameter of an Objective-C wrapper method with the
+// expression converting it to the Swift type of the case
+type objcParameter struct {
+	Name       string
+	Type       string
+	Conversion string
+}
+
+// objcCase is an enum case wrapped by an Objective-C method
+type objcCase struct {
+	Name       string
+	Type       string
+	Deprecated string
+	Parameters []objcParameter
+}
+
+// objcClass wraps the cases of one generated enum
+type objcClass struct {
+	Name    string
+	Enum    string
+	Cases   []objcCase
+	Skipped []string
+}
+
+const objcTemplate = `
+#if canImport(ObjectiveC)
+// Objective-C wrappers return script results as Foundation values (NSDictionary,
+// NSArray, NSString, NSNumber) and transaction IDs as hex strings.
+
+/// Signers passed from Objective-C must conform to FlowSigner
+enum CadenceObjCError: Error {
+    case invalidSigner
+}
+
+private func flowSigners(_ signers: [NSObject]) throws -> [FlowSigner] {
+    try signers.map { signer in
+        guard let flowSigner = signer as? FlowSigner else {
+            throw CadenceObjCError.invalidSigner
+        }
+        return flowSigner
+    }
+}
+{{- range $class := .}}
+
+/// Objective-C wrappers of {{.Enum}}
+@objcMembers
+final class {{.Name}}: NSObject {
+    {{- range .Cases}}
+    {{- if .Deprecated}}
+    @available(*, deprecated, message: "{{.Deprecated}}")
+    {{- end}}
+    {{- if eq .Type "transaction"}}
+    static func {{.Name}}({{range .Parameters}}{{.Name}}: {{.Type}}, {{end}}signers: [NSObject]) async throws -> String {
+        try await {{$class.Enum}}.{{.Name}}({{template "arguments" .}}).sendTx(signers: flowSigners(signers)).hex
+    }
+    {{- else}}
+    static func {{.Name}}({{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{.Name}}: {{.Type}}{{end}}) async throws -> Any {
+        let result: AnyDecodable = try await {{$class.Enum}}.{{.Name}}({{template "arguments" .}}).query()
+        return result.value
+    }
+    {{- end}}
+    {{- end}}
+    {{- range .Skipped}}
+    // {{.}} has parameters not representable in Objective-C
+    {{- end}}
+}
+{{- end}}
+#endif
+{{- define "arguments"}}{{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}: {{$param.Conversion}}{{end}}{{end}}
+`
+
+// objcParameterType returns the Objective-C compatible type of a Swift
+// parameter and the expression converting name back, or false if the type
+// cannot be bridged
+func objcParameterType(swiftType string, optional bool, name string) (string, string, bool) {
+	suffix, unwrap := "", ""
+	if optional {
+		suffix, unwrap = "?", "?"
+	}
+	switch {
+	case objcScalars[swiftType] && optional && swiftType != "String":
+		return "NSNumber?", fmt.Sprintf("%s.map { %s(truncating: $0) }", name, swiftType), true
+	case objcScalars[swiftType]:
+		return swiftType + suffix, name, true
+	case swiftType == "Decimal":
+		return "NSDecimalNumber" + suffix, name + unwrap + ".decimalValue", true
+	case swiftType == "Flow.Address" && optional:
+		return "String?", name + ".map { Flow.Address(hex: $0) }", true
+	case swiftType == "Flow.Address":
+		return "String", "Flow.Address(hex: " + name + ")", true
+	case swiftType == "[Flow.Address]":
+		return "[String]" + suffix, name + unwrap + ".map { Flow.Address(hex: $0) }", true
+	case strings.HasPrefix(swiftType, "[") && objcScalars[strings.Trim(swiftType, "[]")]:
+		return swiftType + suffix, name, true
+	}
+	return "", "", false
+}
+
+// generateObjC generates @objc wrapper classes over the generated enums, one
+// per enum, e.g. CadenceGenObjC and CadenceGenEVMObjC. Cases with parameters
+// that cannot be bridged are listed but not wrapped.
+func generateObjC(cases []SwiftCase, taggedCases map[string][]SwiftCase) (string, error) {
+	tags := []string{""}
+	for tag := range taggedCases {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags[1:])
+
+	var classes []objcClass
+	for _, tag := range tags {
+		class := objcClass{Name: "CadenceGen" + tag + "ObjC", Enum: "CadenceGen"}
+		tagCases := cases
+		if tag != "" {
+			class.Enum += "." + tag
+			tagCases = taggedCases[tag]
+		}
+		sorted := append([]SwiftCase(nil), tagCases...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+		for _, c := range sorted {
+			wrapped := objcCase{Name: c.Name, Type: c.Type, Deprecated: c.Deprecated}
+			bridged := true
+			for _, param := range c.Parameters {
+				objcType, conversion, ok := objcParameterType(param.Type, param.Optional, param.Name)
+				if !ok {
+					bridged = false
+					break
+				}
+				wrapped.Parameters = append(wrapped.Parameters, objcParameter{
+					Name:       param.Name,
+					Type:       objcType,
+					Conversion: conversion,
+				})
+			}
+			if !bridged {
+				class.Skipped = append(class.Skipped, c.Name)
+				continue
+			}
+			class.Cases = append(class.Cases, wrapped)
+		}
+		if len(class.Cases) > 0 || len(class.Skipped) > 0 {
+			classes = append(classes, class)
+		}
+	}
+
+	tmpl, err := template.New("objc").Parse(objcTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Objective-C template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, classes); err != nil {
+		return "", fmt.Errorf("failed to execute Objective-C template: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// SetObjC enables the generation of Objective-C compatible wrapper classes
+func (g *Generator) SetObjC(objc bool) {
+	g.ObjC = objc
+}
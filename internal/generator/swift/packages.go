@@ -0,0 +1,242 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// CoreTarget is the SwiftPM target holding the CadenceGen enum, its untagged
+// cases and the structs shared between tags
+const CoreTarget = "CadenceGenCore"
+
+const packageTemplate = `// swift-tools-version:5.7
+// Generated by cadence-codegen
+import PackageDescription
+
+let package = Package(
+    name: "{{.Name}}",
+    platforms: [{{if .Server}}.macOS(.v12){{else}}.iOS(.v13), .macOS(.v10_15){{end}}],
+    products: [
+        {{- range .Targets}}
+        .library(name: "{{.}}", targets: ["{{.}}"]),
+        {{- end}}
+    ],
+    dependencies: [
+        .package(url: "https://github.com/outblock/flow-swift.git", from: "0.3.0"),
+        .package(url: "https://github.com/attaswift/BigInt.git", from: "5.0.0"),
+    ],
+    targets: [
+        {{- range $index, $target := .Targets}}
+        .target(name: "{{$target}}", dependencies: [{{if $index}}"{{$.Core}}", {{end}}.product(name: "Flow", package: "flow-swift"), "BigInt"]),
+        {{- end}}
+    ]
+)
+`
+
+// tagTarget returns the SwiftPM target of a tag, e.g. CadenceGenStaking
+func tagTarget(tag string) string {
+	return "CadenceGen" + tag
+}
+
+// caseTypes returns the Swift types in the signatures of the given cases
+func caseTypes(cases []SwiftCase) string {
+	var types []string
+	for _, c := range cases {
+		types = append(types, c.ReturnType)
+		for _, param := range c.Parameters {
+			types = append(types, param.Type)
+		}
+	}
+	return strings.Join(types, " ")
+}
+
+// referencedStructs returns the names of the structs used in types, including
+// the structs used by their fields
+func referencedStructs(structs []SwiftStruct, types string) map[string]bool {
+	byName := make(map[string]SwiftStruct)
+	for _, s := range structs {
+		byName[s.Name] = s
+	}
+
+	used := make(map[string]bool)
+	pending := []string{types}
+	for len(pending) > 0 {
+		text := pending[0]
+		pending = pending[1:]
+		for name, s := range byName {
+			if used[name] || !regexp.MustCompile(`\b`+regexp.QuoteMeta(name)+`\b`).MatchString(text) {
+				continue
+			}
+			used[name] = true
+			var fieldTypes []string
+			for _, field := range s.Fields {
+				fieldTypes = append(fieldTypes, field.Type)
+			}
+			pending = append(pending, strings.Join(fieldTypes, " "))
+		}
+	}
+	return used
+}
+
+// GeneratePackage generates a Swift package named name with one target per
+// tag, so apps link only the interaction groups they use. The CadenceGenCore
+// target holds the CadenceGen enum with the untagged cases and the structs used
+// by several tags; every tag target holds its nested enum and the structs only
+// it uses. Declarations are public. The returned files are keyed by their path
+// in the package.
+func (g *Generator) GeneratePackage(name string) (map[string]string, error) {
+	if g.Telemetry || g.WalletKit || g.PreviewFixtures || g.ObjC {
+		return nil, fmt.Errorf("telemetry, Flow Wallet Kit adapters, preview fixtures and Objective-C wrappers are not supported in Swift packages")
+	}
+
+	structs, cases, taggedCases := g.build()
+	sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
+	var tags []string
+	for tag := range taggedCases {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	// Assign each struct to the only tag using it, or to the core target
+	owners := make(map[string][]string)
+	for name := range referencedStructs(structs, caseTypes(cases)) {
+		owners[name] = append(owners[name], "")
+	}
+	for _, tag := range tags {
+		for name := range referencedStructs(structs, caseTypes(taggedCases[tag])) {
+			owners[name] = append(owners[name], tag)
+		}
+	}
+	targetStructs := make(map[string][]SwiftStruct)
+	for _, s := range structs {
+		target := CoreTarget
+		if tags := owners[s.Name]; len(tags) == 1 && tags[0] != "" {
+			target = tagTarget(tags[0])
+		}
+		targetStructs[target] = append(targetStructs[target], s)
+	}
+
+	files := make(map[string]string)
+	header := g.header()
+
+	var core bytes.Buffer
+	core.WriteString(header)
+	if err := writeStructs(&core, targetStructs[CoreTarget], "public "); err != nil {
+		return nil, err
+	}
+	if err := g.writeEnum(&core, cases, "", "public "); err != nil {
+		return nil, err
+	}
+	if err := writeSendFunctions(&core, cases, "", "public "); err != nil {
+		return nil, err
+	}
+	if err := writePagination(&core, cases, "", "public "); err != nil {
+		return nil, err
+	}
+	errors, err := g.generateErrors("public ")
+	if err != nil {
+		return nil, err
+	}
+	core.WriteString(errors)
+	if g.Retry {
+		retry, err := generateRetry("public ")
+		if err != nil {
+			return nil, err
+		}
+		core.WriteString(retry)
+	}
+	if g.Logging {
+		logging, err := generateLogging("public ")
+		if err != nil {
+			return nil, err
+		}
+		core.WriteString(logging)
+	}
+	failover, err := g.generateFailover("public ")
+	if err != nil {
+		return nil, err
+	}
+	core.WriteString(failover)
+	roles, err := generateSignerRoles("public ")
+	if err != nil {
+		return nil, err
+	}
+	core.WriteString(roles)
+	core.WriteString(generateBatch("public "))
+	setup, err := g.generateSetup("public ", true)
+	if err != nil {
+		return nil, err
+	}
+	core.WriteString(setup)
+	events, err := g.generateEvents("public ")
+	if err != nil {
+		return nil, err
+	}
+	core.WriteString(events)
+
+	// Add the MetadataViews views if scripts resolve them
+	metadata, err := g.generateMetadataViews("public ")
+	if err != nil {
+		return nil, err
+	}
+	core.WriteString(metadata)
+	signed, err := g.generateManifest("public ", true)
+	if err != nil {
+		return nil, err
+	}
+	core.WriteString(signed)
+	core.WriteString("\n")
+	files[path.Join("Sources", CoreTarget, CoreTarget+".swift")] = core.String()
+
+	targets := []string{CoreTarget}
+	for _, tag := range tags {
+		target := tagTarget(tag)
+		targets = append(targets, target)
+
+		var buffer bytes.Buffer
+		buffer.WriteString(header)
+		buffer.WriteString("import " + CoreTarget + "\n")
+		if err := writeStructs(&buffer, targetStructs[target], "public "); err != nil {
+			return nil, err
+		}
+		if err := g.writeEnum(&buffer, taggedCases[tag], tag, "public "); err != nil {
+			return nil, err
+		}
+		if err := writeSendFunctions(&buffer, taggedCases[tag], tag, "public "); err != nil {
+			return nil, err
+		}
+		if err := writePagination(&buffer, taggedCases[tag], tag, "public "); err != nil {
+			return nil, err
+		}
+		buffer.WriteString("\n")
+		files[path.Join("Sources", target, target+".swift")] = buffer.String()
+	}
+
+	tmpl, err := template.New("package").Parse(packageTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse package template: %w", err)
+	}
+	var manifest bytes.Buffer
+	err = tmpl.Execute(&manifest, struct {
+		Name    string
+		Core    string
+		Targets []string
+		Server  bool
+	}{
+		Name:    name,
+		Core:    CoreTarget,
+		Targets: targets,
+		Server:  g.Server,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute package template: %w", err)
+	}
+	files["Package.swift"] = manifest.String()
+
+	return files, nil
+}
@@ -0,0 +1,98 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const paginationTemplate = `
+
+extension {{.Enum}} {
+    {{- range .Cases}}
+    {{- if .Pagination}}
+    /// Fetches every page of {{.Name}}, requesting pageSize items per page
+    {{$.Access}}static func fetchAll{{pascal .Name}}({{range pageParameters .}}{{.Name}}: {{.Type}}{{if .Optional}}?{{end}}, {{end}}pageSize: Int = 100, timeout: TimeInterval? = nil) async throws -> {{.ReturnType}} {
+        var items: {{.ReturnType}} = []
+        var offset = 0
+        while true {
+            let page: {{.ReturnType}} = try await {{.Name}}({{pageArguments .}}).query(timeout: timeout)
+            items += page
+            if page.count < pageSize {
+                return items
+            }
+            offset += pageSize
+        }
+    }
+    {{- end}}
+    {{- end}}
+}`
+
+// pageParameters returns the parameters of a paginated case without its offset
+// and limit
+func pageParameters(c SwiftCase) []SwiftParameter {
+	var parameters []SwiftParameter
+	for _, param := range c.Parameters {
+		if param.Name != c.Pagination.Offset && param.Name != c.Pagination.Limit {
+			parameters = append(parameters, param)
+		}
+	}
+	return parameters
+}
+
+// pageArguments returns the arguments of a paginated case converting the
+// offset and pageSize variables of its fetchAll helper
+func pageArguments(c SwiftCase) string {
+	var arguments []string
+	for _, param := range c.Parameters {
+		value := param.Name
+		switch param.Name {
+		case c.Pagination.Offset:
+			value = param.Type + "(offset)"
+		case c.Pagination.Limit:
+			value = param.Type + "(pageSize)"
+		}
+		arguments = append(arguments, param.Name+": "+value)
+	}
+	return strings.Join(arguments, ", ")
+}
+
+// writePagination writes the fetchAll helpers of the paginated scripts among
+// cases, looping over pages and concatenating their items
+func writePagination(buffer *bytes.Buffer, cases []SwiftCase, tag string, access string) error {
+	paginated := false
+	for _, c := range cases {
+		paginated = paginated || c.Pagination != nil
+	}
+	if !paginated {
+		return nil
+	}
+
+	tmpl, err := template.New("pagination").Funcs(template.FuncMap{
+		"pascal":         func(name string) string { return strings.ToUpper(name[:1]) + name[1:] },
+		"pageParameters": pageParameters,
+		"pageArguments":  pageArguments,
+	}).Parse(paginationTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse pagination template: %w", err)
+	}
+
+	enum := "CadenceGen"
+	if tag != "" {
+		enum += "." + tag
+	}
+	err = tmpl.Execute(buffer, struct {
+		Enum   string
+		Cases  []SwiftCase
+		Access string
+	}{
+		Enum:   enum,
+		Cases:  cases,
+		Access: access,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute pagination template: %w", err)
+	}
+	return nil
+}
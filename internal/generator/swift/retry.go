@@ -0,0 +1,65 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// retryTemplate declares the retry policy applied to generated calls
+const retryTemplate = `
+/// Retry behavior of generated queries and transactions
+{{.}}struct CadenceRetryPolicy {
+    /// Attempts including the first one
+    {{.}}var maxAttempts: Int
+    /// Delay in seconds before the given retry, starting at 1
+    {{.}}var backoff: (Int) -> TimeInterval
+    /// Whether a failed query or transaction is retried
+    {{.}}var retryable: (CadenceGenError, CadenceType) -> Bool
+
+    {{.}}init(
+        maxAttempts: Int = 3,
+        backoff: @escaping (Int) -> TimeInterval = { 0.5 * pow(2, Double($0 - 1)) },
+        retryable: @escaping (CadenceGenError, CadenceType) -> Bool = CadenceRetryPolicy.isRetryable
+    ) {
+        self.maxAttempts = maxAttempts
+        self.backoff = backoff
+        self.retryable = retryable
+    }
+
+    /// Retries network failures and timeouts of scripts. Transactions are not
+    /// retried by default, as they may have been submitted before failing.
+    {{.}}static func isRetryable(_ error: CadenceGenError, _ type: CadenceType) -> Bool {
+        guard type == .query else {
+            return false
+        }
+        switch error {
+        case .networkError, .timeout:
+            return true
+        default:
+            return false
+        }
+    }
+
+    /// Policy applied to every generated call, nil disables retries
+    {{.}}static var shared: CadenceRetryPolicy? = CadenceRetryPolicy()
+}
+`
+
+// generateRetry renders CadenceRetryPolicy with the access modifier access
+func generateRetry(access string) (string, error) {
+	tmpl, err := template.New("retry").Parse(retryTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse retry template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, access); err != nil {
+		return "", fmt.Errorf("failed to execute retry template: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// SetRetry enables the retry policy of generated calls
+func (g *Generator) SetRetry(retry bool) {
+	g.Retry = retry
+}
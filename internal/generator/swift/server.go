@@ -0,0 +1,26 @@
+package swift
+
+// foundationNetworkingImport imports URLSession and URLError on Linux, where
+// they are not part of Foundation
+const foundationNetworkingImport = `#if canImport(FoundationNetworking)
+import FoundationNetworking
+#endif
+`
+
+// header returns the imports of every generated Swift file
+func (g *Generator) header() string {
+	header := "import Flow\nimport BigInt\nimport Foundation\n"
+	if g.Server {
+		header += foundationNetworkingImport
+	}
+	if g.Report.Manifest != nil || g.Integrity {
+		header += cryptoImport
+	}
+	return header
+}
+
+// SetServer enables the server-side flavor building on macOS and Linux, e.g.
+// for Vapor services, instead of iOS apps
+func (g *Generator) SetServer(server bool) {
+	g.Server = server
+}
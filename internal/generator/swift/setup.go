@@ -0,0 +1,103 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// setupStep is a setup transaction run by ensureAccountSetup
+type setupStep struct {
+	Send       string // Send function of the setup transaction, e.g. CadenceGen.sendSetupCollection
+	Name       string // Case of the setup transaction
+	Check      string // Case of the script checking whether it is needed, if any
+	CheckParam string // Address parameter of the check script
+}
+
+// setupTemplate sends the setup transactions an account still needs, in order
+const setupTemplate = `
+
+extension CadenceGen {
+    /// Sends the setup transactions the account of signer still needs, in
+    /// order, waiting for each to be sealed: {{range $index, $step := .Steps}}{{if $index}}, {{end}}{{$step.Name}}{{end}}.
+    /// Returns the IDs of the sent transactions.
+    {{.Access}}static func ensureAccountSetup(signer: FlowSigner) async throws -> [Flow.ID] {
+        var ids: [Flow.ID] = []
+        func send(_ id: Flow.ID) async throws {
+            _ = try await id.onceSealed()
+            ids.append(id)
+        }
+        {{- range .Steps}}
+        {{- if .Check}}
+        let {{.Name}}Done: Bool = try await {{.Check}}({{.CheckParam}}: signer.address).query()
+        if !{{.Name}}Done {
+            try await send(try await {{.Send}}(signer: signer))
+        }
+        {{- else}}
+        try await send(try await {{.Send}}(signer: signer))
+        {{- end}}
+        {{- end}}
+        return ids
+    }
+}
+`
+
+// generateSetup renders ensureAccountSetup for the setup transactions of
+// the report with the access modifier access. It returns nothing if the
+// report has none, or if untagged is set and a setup transaction or check
+// script is tagged.
+func (g *Generator) generateSetup(access string, untagged bool) (string, error) {
+	setups, err := g.Report.AccountSetups()
+	if err != nil {
+		return "", err
+	}
+	if len(setups) == 0 {
+		return "", nil
+	}
+
+	enum := func(tag string) string {
+		if tag == "" {
+			return "CadenceGen"
+		}
+		return "CadenceGen." + tag
+	}
+	var steps []setupStep
+	for _, setup := range setups {
+		transaction := g.Report.Transactions[setup.Transaction]
+		name := g.functionName(setup.Transaction)
+		step := setupStep{
+			Send: enum(transaction.Tag) + ".send" + strings.ToUpper(name[:1]) + name[1:],
+			Name: name,
+		}
+		if untagged && transaction.Tag != "" {
+			return "", nil
+		}
+		if setup.Check != "" {
+			check := g.Report.Scripts[setup.Check]
+			if untagged && check.Tag != "" {
+				return "", nil
+			}
+			step.Check = enum(check.Tag) + "." + g.functionName(setup.Check)
+			step.CheckParam = setup.CheckParam
+		}
+		steps = append(steps, step)
+	}
+
+	tmpl, err := template.New("setup").Parse(setupTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse setup template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Steps  []setupStep
+		Access string
+	}{
+		Steps:  steps,
+		Access: access,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute setup template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,124 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// signerRolesCode assigns the proposer, payer and authorizers of transactions
+// with several authorizers
+const signerRolesCode = `
+/// Proposer, payer and authorizers of a transaction, in prepare order
+{{.}}struct CadenceSignerRoles {
+    {{.}}let proposer: FlowSigner
+    {{.}}let payer: FlowSigner
+    {{.}}let authorizers: [FlowSigner]
+
+    {{.}}init(proposer: FlowSigner, payer: FlowSigner, authorizers: [FlowSigner]) {
+        self.proposer = proposer
+        self.payer = payer
+        self.authorizers = authorizers
+    }
+
+    /// Every distinct signer, signing once per key
+    var signers: [FlowSigner] {
+        var signers: [FlowSigner] = []
+        for signer in [proposer, payer] + authorizers where !signers.contains(where: { $0.address == signer.address && $0.keyIndex == signer.keyIndex }) {
+            signers.append(signer)
+        }
+        return signers
+    }
+}
+
+extension CadenceTargetType {
+    /// Sends the transaction with explicit proposer, payer and authorizers,
+    /// throwing CadenceGenError
+    {{.}}func sendTx(roles: CadenceSignerRoles, timeout: TimeInterval? = nil) async throws -> Flow.ID {
+        try await sendTx(signers: roles.signers, timeout: timeout) {
+            proposer { Flow.TransactionProposalKey(address: roles.proposer.address, keyIndex: roles.proposer.keyIndex) }
+            payer { roles.payer.address }
+            authorizers { roles.authorizers.map(\.address) }
+        }
+    }
+}
+`
+
+const sendTemplate = `
+
+extension {{.Enum}} {
+    {{- range .Cases}}
+    {{- if eq .Type "transaction"}}
+    {{- if .Deprecated}}
+    @available(*, deprecated, message: "{{.Deprecated}}")
+    {{- end}}
+    {{- if gt (len .Signers) 1}}
+    /// Sends {{.Name}} with the proposer, payer and its authorizers {{range $index, $signer := .Signers}}{{if $index}}, {{end}}{{$signer.Label}}{{end}}
+    {{$.Access}}static func send{{pascal .Name}}({{template "parameters" .}}{{if not .Proposer}}proposer: FlowSigner, {{end}}{{if not .Payer}}payer: FlowSigner, {{end}}{{range .Signers}}{{.Label}}: FlowSigner, {{end}}timeout: TimeInterval? = nil) async throws -> Flow.ID {
+        let roles = CadenceSignerRoles(proposer: {{or .Proposer "proposer"}}, payer: {{or .Payer "payer"}}, authorizers: [{{range $index, $signer := .Signers}}{{if $index}}, {{end}}{{$signer.Label}}{{end}}])
+        return try await {{.Name}}({{template "arguments" .}}).sendTx(roles: roles, timeout: timeout)
+    }
+    {{- else}}
+    /// Sends {{.Name}} with signer as proposer, payer{{if .Signers}} and authorizer{{end}}
+    {{$.Access}}static func send{{pascal .Name}}({{template "parameters" .}}signer: FlowSigner, timeout: TimeInterval? = nil) async throws -> Flow.ID {
+        try await {{.Name}}({{template "arguments" .}}).sendTx(signers: [signer], timeout: timeout)
+    }
+    {{- end}}
+    {{- end}}
+    {{- end}}
+}
+{{- define "parameters"}}{{range .Parameters}}{{.Name}}: {{.Type}}{{if .Optional}}?{{end}}, {{end}}{{end}}
+{{- define "arguments"}}{{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}: {{$param.Name}}{{end}}{{end}}`
+
+// writeSendFunctions writes the send functions of the transactions among
+// cases, taking one signer if the transaction has at most one authorizer and
+// the proposer, payer and every authorizer otherwise
+func writeSendFunctions(buffer *bytes.Buffer, cases []SwiftCase, tag string, access string) error {
+	hasTransactions := false
+	for _, c := range cases {
+		hasTransactions = hasTransactions || c.Type == "transaction"
+	}
+	if !hasTransactions {
+		return nil
+	}
+
+	tmpl, err := template.New("send").Funcs(template.FuncMap{
+		"pascal": func(name string) string { return strings.ToUpper(name[:1]) + name[1:] },
+	}).Parse(sendTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse send template: %w", err)
+	}
+
+	enum := "CadenceGen"
+	if tag != "" {
+		enum += "." + tag
+	}
+	err = tmpl.Execute(buffer, struct {
+		Enum   string
+		Cases  []SwiftCase
+		Access string
+	}{
+		Enum:   enum,
+		Cases:  cases,
+		Access: access,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute send template: %w", err)
+	}
+	return nil
+}
+
+// generateSignerRoles renders CadenceSignerRoles with the access modifier
+// access, e.g. "public "
+func generateSignerRoles(access string) (string, error) {
+	tmpl, err := template.New("roles").Parse(signerRolesCode)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse signer roles template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, access); err != nil {
+		return "", fmt.Errorf("failed to execute signer roles template: %w", err)
+	}
+	return buffer.String(), nil
+}
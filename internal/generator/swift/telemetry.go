@@ -0,0 +1,49 @@
+package swift
+
+// telemetryCode times queries and transactions of all generated enums and
+// reports them to a hook
+const telemetryCode = `
+/// An executed query or transaction reported to the telemetry hook
+struct CadenceInteractionEvent {
+    let name: String
+    let type: CadenceType
+    let duration: TimeInterval
+    let error: Error?
+
+    var success: Bool { error == nil }
+}
+
+enum CadenceTelemetry {
+    /// Called after every instrumented query and transaction, e.g. to feed metrics into analytics
+    static var hook: ((CadenceInteractionEvent) -> Void)?
+
+    static func measure<T>(_ target: CadenceTargetType, _ body: () async throws -> T) async throws -> T {
+        let start = Date()
+        do {
+            let result = try await body()
+            hook?(CadenceInteractionEvent(name: target.interactionName, type: target.type, duration: Date().timeIntervalSince(start), error: nil))
+            return result
+        } catch {
+            hook?(CadenceInteractionEvent(name: target.interactionName, type: target.type, duration: Date().timeIntervalSince(start), error: error))
+            throw error
+        }
+    }
+}
+
+extension CadenceTargetType {
+    /// Executes the script, reporting it to CadenceTelemetry
+    func instrumentedQuery<T: Decodable>(timeout: TimeInterval? = nil) async throws -> T {
+        try await CadenceTelemetry.measure(self) { try await query(timeout: timeout) }
+    }
+
+    /// Sends the transaction, reporting it to CadenceTelemetry
+    func instrumentedSend(signers: [FlowSigner], timeout: TimeInterval? = nil) async throws -> Flow.ID {
+        try await CadenceTelemetry.measure(self) { try await sendTx(signers: signers, timeout: timeout) }
+    }
+}
+`
+
+// SetTelemetry enables telemetry hooks around queries and transactions
+func (g *Generator) SetTelemetry(telemetry bool) {
+	g.Telemetry = telemetry
+}
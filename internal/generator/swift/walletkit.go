@@ -0,0 +1,164 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// SwiftSigner represents a signer required by a transaction
+type SwiftSigner struct {
+	Name         string
+	Entitlements []string
+	Label        string // Parameter name of the signer, suffixed with its roles, e.g. adminAsPayer
+}
+
+// walletKitEnum holds the transactions of one generated enum
+type walletKitEnum struct {
+	Name  string
+	Cases []walletKitCase
+}
+
+// walletKitCase holds the signer requirements of one enum case
+type walletKitCase struct {
+	Name    string
+	Signers []SwiftSigner
+}
+
+const walletKitTemplate = `
+#if canImport(FlowWalletKit)
+import FlowWalletKit
+
+/// A signer required by a generated transaction, in prepare order
+struct CadenceSignerRequirement: Equatable {
+    let name: String
+    let entitlements: [String]
+}
+
+enum CadenceSignerError: Error {
+    case notATransaction
+    case signerCountMismatch(expected: Int, actual: Int)
+}
+
+/// Generated targets that can be signed with Flow Wallet Kit accounts
+protocol CadenceWalletKitTarget: CadenceTargetType {
+    /// Signers required by the transaction, empty for scripts
+    var signerRequirements: [CadenceSignerRequirement] { get }
+}
+
+extension CadenceWalletKitTarget {
+    /// Sends the transaction signed by Flow Wallet Kit accounts, one per signer requirement
+    func send(signers: [FlowSigner], timeout: TimeInterval? = nil) async throws -> Flow.ID {
+        guard type == .transaction else {
+            throw CadenceSignerError.notATransaction
+        }
+        guard signers.count == signerRequirements.count else {
+            throw CadenceSignerError.signerCountMismatch(expected: signerRequirements.count, actual: signers.count)
+        }
+        return try await sendTx(signers: signers, timeout: timeout)
+    }
+}
+{{range .}}
+extension {{.Name}}: CadenceWalletKitTarget {
+    var signerRequirements: [CadenceSignerRequirement] {
+        switch self {
+        {{- range .Cases}}
+        case .{{.Name}}:
+            return [{{range $index, $signer := .Signers}}{{if $index}}, {{end}}CadenceSignerRequirement(name: "{{$signer.Name}}", entitlements: [{{range $i, $e := $signer.Entitlements}}{{if $i}}, {{end}}"{{$e}}"{{end}}])]{{end}}
+        {{- end}}
+        default:
+            return []
+        }
+    }
+}
+{{end}}#endif
+`
+
+// authPattern matches the entitlements of an authorized reference type
+var authPattern = regexp.MustCompile(`auth\s*\(([^)]*)\)`)
+
+// parseEntitlements returns the entitlements of a signer type such as auth(BorrowValue, SaveValue) &Account
+func parseEntitlements(typeStr string) []string {
+	match := authPattern.FindStringSubmatch(typeStr)
+	if match == nil {
+		return []string{}
+	}
+	entitlements := make([]string, 0)
+	for _, entitlement := range strings.FieldsFunc(match[1], func(r rune) bool {
+		return r == ',' || r == '|'
+	}) {
+		if entitlement = strings.TrimSpace(entitlement); entitlement != "" {
+			entitlements = append(entitlements, entitlement)
+		}
+	}
+	return entitlements
+}
+
+// SetWalletKit enables the generation of Flow Wallet Kit signing adapters
+func (g *Generator) SetWalletKit(walletKit bool) {
+	g.WalletKit = walletKit
+}
+
+// generateWalletKit generates the Flow Wallet Kit adapters of all generated enums
+func (g *Generator) generateWalletKit() (string, error) {
+	enums := make(map[string]*walletKitEnum)
+	var names []string
+	add := func(tag string) *walletKitEnum {
+		name := "CadenceGen"
+		if tag != "" {
+			name += "." + tag
+		}
+		if enums[name] == nil {
+			enums[name] = &walletKitEnum{Name: name}
+			names = append(names, name)
+		}
+		return enums[name]
+	}
+
+	// Every generated enum conforms, even if it only holds scripts
+	add("")
+	for _, results := range []map[string]analyzer.AnalysisResult{g.Report.Transactions, g.Report.Scripts} {
+		for _, result := range results {
+			add(result.Tag)
+		}
+	}
+	for filename, result := range g.Report.Transactions {
+		enum := add(result.Tag)
+		walletCase := walletKitCase{
+			Name:    g.functionName(filename),
+			Signers: make([]SwiftSigner, 0),
+		}
+		for _, signer := range result.Signers {
+			walletCase.Signers = append(walletCase.Signers, SwiftSigner{
+				Name:         signer.Name,
+				Entitlements: parseEntitlements(signer.TypeStr),
+			})
+		}
+		enum.Cases = append(enum.Cases, walletCase)
+	}
+
+	sort.Strings(names)
+	var sorted []walletKitEnum
+	for _, name := range names {
+		enum := enums[name]
+		sort.Slice(enum.Cases, func(i, j int) bool {
+			return enum.Cases[i].Name < enum.Cases[j].Name
+		})
+		sorted = append(sorted, *enum)
+	}
+
+	tmpl, err := template.New("walletkit").Parse(walletKitTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse wallet kit template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, sorted); err != nil {
+		return "", fmt.Errorf("failed to execute wallet kit template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,120 @@
+package typescript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/outblock/cadence-codegen/internal/config"
+)
+
+const authTemplate = `/** Generated fcl discovery and WalletConnect configuration */
+import * as fcl from "@onflow/fcl";
+
+export type Network = "mainnet" | "testnet" | "emulator";
+
+/** Access node and discovery endpoints per network */
+export const networks: Record<Network, { accessNode: string; discoveryWallet: string; discoveryAuthn?: string }> = {
+  mainnet: {
+    accessNode: "https://rest-mainnet.onflow.org",
+    discoveryWallet: "https://fcl-discovery.onflow.org/authn",
+    discoveryAuthn: "https://fcl-discovery.onflow.org/api/authn",
+  },
+  testnet: {
+    accessNode: "https://rest-testnet.onflow.org",
+    discoveryWallet: "https://fcl-discovery.onflow.org/testnet/authn",
+    discoveryAuthn: "https://fcl-discovery.onflow.org/api/testnet/authn",
+  },
+  emulator: {
+    accessNode: "http://127.0.0.1:8888",
+    discoveryWallet: "http://localhost:8701/fcl/authn",
+  },
+};
+
+/** App metadata shown by wallets */
+export const appDetail = {{.App}};
+
+/** Contract addresses per network, registered as fcl import aliases */
+const contractAddresses: Record<string, Record<string, string>> = {{.Addresses}};
+
+export interface AuthOptions {
+  /** WalletConnect Cloud project ID, WalletConnect is disabled if empty */
+  walletConnectProjectId?: string;
+  /** Overrides of the fcl configuration */
+  config?: Record<string, any>;
+}
+
+/** Configures fcl discovery, WalletConnect and the contract addresses of a network */
+export function configureAuth(network: Network = "{{.Network}}", options: AuthOptions = {}) {
+  const endpoints = networks[network];
+  const config: Record<string, any> = {
+    "flow.network": network,
+    "accessNode.api": endpoints.accessNode,
+    "discovery.wallet": endpoints.discoveryWallet,
+    "app.detail.title": appDetail.title,
+  };
+  if (endpoints.discoveryAuthn) config["discovery.authn.endpoint"] = endpoints.discoveryAuthn;
+  if (appDetail.icon) config["app.detail.icon"] = appDetail.icon;
+  if (appDetail.description) config["app.detail.description"] = appDetail.description;
+  if (appDetail.url) config["app.detail.url"] = appDetail.url;
+
+  const walletConnectProjectId = options.walletConnectProjectId ?? {{.WalletConnectProjectID}};
+  if (walletConnectProjectId) config["walletconnect.projectId"] = walletConnectProjectId;
+
+  for (const [alias, address] of Object.entries(contractAddresses[network] ?? {})) {
+    config[alias] = address;
+  }
+  fcl.config({ ...config, ...options.config });
+}
+
+/** Opens fcl discovery and resolves with the authenticated user */
+export const logIn = () => fcl.authenticate();
+
+/** Logs the current user out */
+export const logOut = () => fcl.unauthenticate();
+
+/** Subscribes to the current user, returns the unsubscribe function */
+export const onUserChange = (callback: (user: any) => void) => fcl.currentUser.subscribe(callback);
+`
+
+// GenerateAuth generates an auth module configuring fcl discovery and
+// WalletConnect for the network and app metadata of cfg
+func (g *Generator) GenerateAuth(cfg *config.Config) (string, error) {
+	app, err := json.MarshalIndent(cfg.App, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal app metadata: %w", err)
+	}
+	projectID, err := json.Marshal(cfg.WalletConnect.ProjectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal WalletConnect project ID: %w", err)
+	}
+	addresses := []byte("{}")
+	if g.Report.Addresses != nil {
+		addresses, err = json.Marshal(g.Report.Addresses)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal addresses: %w", err)
+		}
+	}
+
+	tmpl, err := template.New("auth").Parse(authTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse auth template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Network                string
+		App                    string
+		Addresses              string
+		WalletConnectProjectID string
+	}{
+		Network:                cfg.Network,
+		App:                    string(app),
+		Addresses:              string(addresses),
+		WalletConnectProjectID: string(projectID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute auth template: %w", err)
+	}
+	return buffer.String(), nil
+}
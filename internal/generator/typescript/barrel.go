@@ -0,0 +1,106 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const barrelTemplate = `/** Generated barrel grouping the Cadence service into tag-scoped sub-services */
+import { CadenceService } from "{{.ImportPath}}";
+
+export * from "{{.ImportPath}}";
+{{- range .Groups}}
+
+/** Functions of the {{.Tag}} folder */
+export class {{.Tag}}Service {
+  constructor(private service: CadenceService) {}
+{{- range .Functions}}
+{{template "method" .}}
+{{- end}}
+}
+{{- end}}
+
+/** The generated functions with tagged ones grouped by folder, e.g. client.staking.getDelegatorInfo() */
+export class CadenceClient {
+{{- range .Groups}}
+  readonly {{.Property}}: {{.Tag}}Service;
+{{- end}}
+
+  constructor(readonly service: CadenceService = new CadenceService()) {
+{{- range .Groups}}
+    this.{{.Property}} = new {{.Tag}}Service(service);
+{{- end}}
+  }
+{{- range .Functions}}
+{{template "method" .}}
+{{- end}}
+}
+{{- define "method"}}
+{{- if .Deprecated}}
+  /** @deprecated {{.Deprecated}} */
+{{- end}}
+  {{.Name}}(...args: Parameters<CadenceService["{{.Name}}"]>): ReturnType<CadenceService["{{.Name}}"]> {
+    return this.service.{{.Name}}(...args);
+  }
+{{- end}}
+`
+
+// barrelGroup is the sub-service of the functions of a tag
+type barrelGroup struct {
+	Tag       string
+	Property  string
+	Functions []TypeScriptFunction
+}
+
+// camelCase lower cases the leading upper case run of a tag, keeping the start
+// of the next word, e.g. EVM to evm and NFTCatalog to nftCatalog
+func camelCase(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+	return strings.ToLower(string(runes[:upper])) + string(runes[upper:])
+}
+
+// GenerateBarrel generates a module re-exporting the generated service with a
+// CadenceClient grouping tagged functions into sub-services, e.g.
+// client.staking.getDelegatorInfo(). importPath is the module path of the
+// generated service relative to the barrel.
+func (g *Generator) GenerateBarrel(importPath string) (string, error) {
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+
+	var groups []barrelGroup
+	for _, tag := range tagNames {
+		groups = append(groups, barrelGroup{
+			Tag:       tag,
+			Property:  camelCase(tag),
+			Functions: taggedFunctions[tag],
+		})
+	}
+
+	tmpl, err := template.New("barrel").Parse(barrelTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse barrel template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		ImportPath string
+		Groups     []barrelGroup
+		Functions  []TypeScriptFunction
+	}{
+		ImportPath: importPath,
+		Groups:     groups,
+		Functions:  functions,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute barrel template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,13 @@
+package typescript
+
+// batchMethod runs several generated queries concurrently with typed tuple
+// results
+const batchMethod = `  /**
+   * Runs queries concurrently, resolving to their results in order, e.g.
+   * const [balance, info] = await service.batch(() => service.getBalance(address), () => service.getInfo(address));
+   */
+  async batch<T extends readonly unknown[]>(...queries: { [K in keyof T]: () => Promise<T[K]> }): Promise<T> {
+    return (await Promise.all((queries as Array<() => Promise<unknown>>).map((query) => query()))) as unknown as T;
+  }
+
+`
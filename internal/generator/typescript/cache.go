@@ -0,0 +1,53 @@
+package typescript
+
+// cacheTypes declares the time to live of cached script results
+const cacheTypes = `/** Time to live in milliseconds of cached script results by function name */
+export type CacheTTL = Partial<Record<CadenceFunctionName, number>>;
+
+`
+
+// cacheFields declares the cached results and their time to live
+const cacheFields = `  private cache = new Map<string, { expires: number; value: Promise<unknown> }>();
+  private cacheTTL: CacheTTL = {};
+`
+
+// cacheMethods configures the cache and looks up script results by function
+// name, network and encoded arguments
+const cacheMethods = `  /** Caches the results of scripts for their time to live, e.g. { getContractMetadata: Infinity } */
+  useCache(ttl: CacheTTL) {
+    this.cacheTTL = { ...this.cacheTTL, ...ttl };
+  }
+
+  /** Removes the cached results of a function, or of all functions */
+  clearCache(name?: CadenceFunctionName) {
+    for (const key of this.cache.keys()) {
+      if (!name || key.startsWith(name + ":")) {
+        this.cache.delete(key);
+      }
+    }
+  }
+
+  private cached<T>(config: CadenceConfig, network: FlowNetwork | undefined, run: () => Promise<T>): Promise<T> {
+    const ttl = this.cacheTTL[config.name];
+    if (!ttl) {
+      return run();
+    }
+    const args = config.args((value: unknown, type: any) => type.asArgument(value), fcl.t);
+    const key = ` + "`" + `${config.name}:${network ?? ""}:${JSON.stringify(args)}` + "`" + `;
+    const now = Date.now();
+    const entry = this.cache.get(key);
+    if (entry && entry.expires > now) {
+      return entry.value as Promise<T>;
+    }
+    const value = run();
+    this.cache.set(key, { expires: now + ttl, value });
+    value.catch(() => this.cache.delete(key));
+    return value;
+  }
+
+`
+
+// SetCache enables the TTL cache of script results
+func (g *Generator) SetCache(cache bool) {
+	g.Cache = cache
+}
@@ -0,0 +1,134 @@
+package typescript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// base64Helper encodes code on access, so that the base64 of every function is
+// not embedded next to its code
+const base64Helper = `/** Base64 encodes UTF-8 code with btoa, without a Buffer polyfill */
+const toBase64 = (code: string) => btoa(Array.from(new TextEncoder().encode(code), (byte) => String.fromCharCode(byte)).join(""));
+
+`
+
+const cadenceTemplate = base64Helper + `/** Raw Cadence code of the generated functions, for custom execution paths, multi-sig flows and audits */
+export const cadence = {
+{{- range .}}
+  {{.Name}}: {
+    code: ` + "`" + `{{.Base64}}` + "`" + `,
+    get base64() {
+      return toBase64(this.code);
+    },
+    filePath: {{json .FilePath}},
+    hash: {{json .Hash}},
+  },
+{{- end}}
+} as const;
+
+`
+
+const slimCadenceTemplate = `/** Blocks of Cadence code, shared by the generated functions that contain them */
+const blocks = [
+{{- range .Blocks}}
+  ` + "`" + `{{.}}` + "`" + `,
+{{- end}}
+];
+
+/** Joins blocks into the code of a function */
+const join = (...indexes: number[]) => indexes.map((index) => blocks[index]).join("\n\n");
+
+` + base64Helper + `/** Raw Cadence code of the generated functions, for custom execution paths, multi-sig flows and audits */
+export const cadence = {
+{{- range .Functions}}
+  {{.Name}}: {
+    code: join({{range $index, $block := .Blocks}}{{if $index}}, {{end}}{{$block}}{{end}}),
+    get base64() {
+      return toBase64(this.code);
+    },
+    filePath: {{json .FilePath}},
+    hash: {{json .Hash}},
+  },
+{{- end}}
+} as const;
+
+`
+
+// slimFunction references the shared code blocks of a function
+type slimFunction struct {
+	Name     string
+	Blocks   []int
+	FilePath string
+	Hash     string
+}
+
+// jsonString renders a value as a JSON literal
+func jsonString(value string) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// SetSlim enables the browser bundle-size mode, embedding shared Cadence code once
+func (g *Generator) SetSlim(slim bool) {
+	g.Slim = slim
+}
+
+// generateCadenceMap renders the exported map of the raw Cadence code of the
+// generated functions
+func generateCadenceMap(functions []TypeScriptFunction) (string, error) {
+	tmpl, err := template.New("cadence").Funcs(template.FuncMap{"json": jsonString}).Parse(cadenceTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse cadence template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, functions); err != nil {
+		return "", fmt.Errorf("failed to execute cadence template: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// generateSlimCadenceMap renders the exported map of the raw Cadence code of
+// the generated functions for browser bundles. Code is split into blocks
+// separated by blank lines and each distinct block is embedded once.
+func generateSlimCadenceMap(functions []TypeScriptFunction) (string, error) {
+	var blocks []string
+	indexes := make(map[string]int)
+	var slimFunctions []slimFunction
+	for _, function := range functions {
+		slim := slimFunction{
+			Name:     function.Name,
+			FilePath: function.FilePath,
+			Hash:     function.Hash,
+		}
+		for _, block := range strings.Split(function.Base64, "\n\n") {
+			index, ok := indexes[block]
+			if !ok {
+				index = len(blocks)
+				indexes[block] = index
+				blocks = append(blocks, block)
+			}
+			slim.Blocks = append(slim.Blocks, index)
+		}
+		slimFunctions = append(slimFunctions, slim)
+	}
+
+	tmpl, err := template.New("cadence").Funcs(template.FuncMap{"json": jsonString}).Parse(slimCadenceTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse cadence template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Blocks    []string
+		Functions []slimFunction
+	}{
+		Blocks:    blocks,
+		Functions: slimFunctions,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute cadence template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,66 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+const declarationsTemplate = `/** Parameters of the generated functions by name */
+export interface CadenceParameters {
+{{- range .}}
+  {{.Name}}: [{{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{if $param.Optional}}?{{end}}: {{$param.Type}}{{end}}];
+{{- end}}
+}
+
+/** Response types of the generated functions by name */
+export interface CadenceResponses {
+{{- range .}}
+  {{.Name}}: {{responseType .}};
+{{- end}}
+}
+
+export type CadenceFunctionName = keyof CadenceResponses;
+
+/** Signatures of the generated functions, to be implemented by a custom execution layer */
+export interface CadenceFunctions {
+{{- range $index, $func := .}}
+{{- if $func.Deprecated}}
+  /** @deprecated {{$func.Deprecated}} */
+{{- end}}
+  {{$func.Name}}({{range $index, $param := $func.Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{if $param.Optional}}?{{end}}: {{$param.Type}}{{end}}): Promise<{{responseType $func}}>;
+{{- end}}
+}
+`
+
+// GenerateDeclarations generates type-only declarations (.d.ts): the interfaces
+// of all structs and the parameter, response and function signature types of
+// all transactions and scripts, without fcl or any implementation
+func (g *Generator) GenerateDeclarations() (string, error) {
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+	for _, tag := range tagNames {
+		functions = append(functions, taggedFunctions[tag]...)
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("/** Generated type declarations from Cadence files */\n\n")
+	buffer.WriteString(flowAddressType)
+	buffer.WriteString("\n")
+
+	if err := g.writeInterfaces(&buffer); err != nil {
+		return "", err
+	}
+	buffer.WriteString(g.metadataViewTypes())
+
+	funcMap := template.FuncMap{
+		"responseType": responseType,
+	}
+	tmpl, err := template.New("declarations").Funcs(funcMap).Parse(declarationsTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse declarations template: %w", err)
+	}
+	if err := tmpl.Execute(&buffer, functions); err != nil {
+		return "", fmt.Errorf("failed to execute declarations template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,48 @@
+package typescript
+
+// estimateTypes declares the estimator and its results
+const estimateTypes = `/** Computation usage and fee of a dry-run transaction */
+export interface TransactionEstimation {
+  computationUsage: number;
+  /** Estimated fee in FLOW, if reported by the estimator */
+  fee?: string;
+}
+
+/** Estimation of a transaction compared to the compute limit it is sent with */
+export interface ComputationEstimate extends TransactionEstimation {
+  limit: number;
+  exceedsLimit: boolean;
+}
+
+/** Dry-runs a transaction without sending it, e.g. through a simulation service or an emulator fork */
+export type TransactionEstimator = <Name extends CadenceFunctionName>(
+  config: CadenceConfig<Name>,
+) => Promise<TransactionEstimation>;
+
+`
+
+// estimateField declares the registered estimator of the service
+const estimateField = "  private estimator?: TransactionEstimator;\n"
+
+// estimateMethods registers the estimator and runs it on intercepted configs
+const estimateMethods = `  /** Registers the estimator dry-running transactions for the estimate helpers, which throw without one */
+  useEstimator(estimator: TransactionEstimator) {
+    this.estimator = estimator;
+  }
+
+  private async estimate<Name extends CadenceFunctionName>(config: CadenceConfig<Name>): Promise<ComputationEstimate> {
+    if (!this.estimator) {
+      throw new Error("No transaction estimator registered, estimates need one registered with useEstimator");
+    }
+    const c = await this.runRequestInterceptors(config);
+    const estimation = await this.estimator(c);
+    return { ...estimation, limit: c.limit, exceedsLimit: estimation.computationUsage > c.limit };
+  }
+
+`
+
+// SetEstimates enables estimate helpers dry-running transactions before they
+// are signed with the estimator the app registers, as none is generated
+func (g *Generator) SetEstimates(estimates bool) {
+	g.Estimates = estimates
+}
@@ -0,0 +1,97 @@
+package typescript
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultAccessNodes are the access nodes of networks without configured ones
+var defaultAccessNodes = map[string]string{
+	"mainnet":  "https://rest-mainnet.onflow.org",
+	"testnet":  "https://rest-testnet.onflow.org",
+	"emulator": "http://127.0.0.1:8888",
+}
+
+// accessNodesDeclaration declares the access node of each network, or the
+// prioritized access nodes of each network if any are configured
+func accessNodesDeclaration(configured map[string][]string) string {
+	var b strings.Builder
+	if len(configured) == 0 {
+		b.WriteString("/** Access nodes of per-call network overrides */\n")
+		b.WriteString("const accessNodes: Record<FlowNetwork, string> = {\n")
+	} else {
+		b.WriteString("/** Access nodes of each network in order of priority, see onNetwork */\n")
+		b.WriteString("const accessNodes: Record<FlowNetwork, string[]> = {\n")
+	}
+	for _, network := range []string{"mainnet", "testnet", "emulator"} {
+		if len(configured) == 0 {
+			fmt.Fprintf(&b, "  %s: %q,\n", network, defaultAccessNodes[network])
+			continue
+		}
+		nodes := configured[network]
+		if len(nodes) == 0 {
+			nodes = []string{defaultAccessNodes[network]}
+		}
+		quoted := make([]string, len(nodes))
+		for i, node := range nodes {
+			quoted[i] = fmt.Sprintf("%q", node)
+		}
+		fmt.Fprintf(&b, "  %s: [%s],\n", network, strings.Join(quoted, ", "))
+	}
+	b.WriteString("};\n")
+	return b.String()
+}
+
+// failoverTypes classifies the failures moving calls to the next access node
+const failoverTypes = `/** Whether an fcl call failed because of its access node, e.g. a 5xx response or a timeout */
+export function isAccessNodeFailure(error: unknown): boolean {
+  const message = String((error as { message?: unknown })?.message ?? error);
+  return /fetch failed|timed? ?out|ECONNRESET|ECONNREFUSED|\b5\d\d\b/i.test(message);
+}
+
+`
+
+// failoverField declares the time after which an access node is skipped
+const failoverField = "  /** Milliseconds after which an access node is considered unavailable and the next one is tried */\n  accessNodeTimeout = 10_000;\n"
+
+// failoverMethod runs scripts against the access nodes of a network in order
+// of priority, passing each access node to the call instead of the fcl
+// configuration so that concurrent calls do not interfere
+func failoverMethod() string {
+	return `  /**
+   * Runs a script on the given or configured network, failing over to the next access
+   * node of the network on server errors and timeouts. The access node is passed to the
+   * call only, so concurrent calls, e.g. of batch, do not change each other's access node.
+   */
+  private async onNetwork<T>(network: FlowNetwork | undefined, run: (target?: AccessNodeTarget) => Promise<T>): Promise<T> {
+    const selected: FlowNetwork = network ?? (await fcl.config().get("flow.network"));
+    const nodes = accessNodes[selected];
+    if (!nodes || (!network && nodes.length < 2)) {
+      return run();
+    }
+    for (let index = 0; ; index++) {
+      const target: AccessNodeTarget = { network: selected, node: nodes[index] };
+      try {
+        return await this.withAccessNodeTimeout(target.node, () => run(target));
+      } catch (error) {
+        if (index + 1 >= nodes.length || !isAccessNodeFailure(error)) {
+          throw error;
+        }
+      }
+    }
+  }
+
+  private async withAccessNodeTimeout<T>(node: string, run: () => Promise<T>): Promise<T> {
+    let timer: ReturnType<typeof setTimeout> | undefined;
+    const timeout = new Promise<never>((_, reject) => {
+      timer = setTimeout(() => reject(new Error("Access node " + node + " timed out")), this.accessNodeTimeout);
+    });
+    try {
+      return await Promise.race([run(), timeout]);
+    } finally {
+      clearTimeout(timer);
+    }
+  }
+
+`
+}
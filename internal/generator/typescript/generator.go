@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -17,6 +18,32 @@ type Generator struct {
 	Report  analyzer.Report
 	Files   map[string]string
 	BaseDir string
+	// Validate adds runtime argument validation to the generated functions
+	Validate bool
+	// Telemetry reports every query and mutation to registered hooks
+	Telemetry bool
+	// Validators is the validation library of generated input schemas
+	Validators string
+	// Estimates adds estimate helpers dry-running transactions
+	Estimates bool
+	// Idempotency adds idempotency keys to transactions
+	Idempotency bool
+	// OfflineSigning adds functions exporting unsigned transactions
+	OfflineSigning bool
+	// Cache adds a TTL cache of script results
+	Cache bool
+	// Retry adds a retry policy applied to fcl calls
+	Retry bool
+	// Logging adds logger hooks receiving fcl calls
+	Logging bool
+	// Slim minimizes the bundle size of the generated code
+	Slim bool
+	// Integrity checks the embedded code of a function before it is executed
+	Integrity bool
+	// Node signs transactions with a server signer for Node.js backends
+	Node bool
+	// Names overrides the function names of scripts and transactions by file name
+	Names map[string]string
 }
 
 // New creates a new TypeScript code generator
@@ -51,12 +78,19 @@ var typeMapping = map[string]string{
 	"Int128":    "string",
 	"Int256":    "string",
 	"Bool":      "boolean",
-	"Address":   "string",
+	"Address":   "FlowAddress",
 	"UFix64":    "string",
 	"Fix64":     "string",
 	"AnyStruct": "any",
 }
 
+// flowAddressType declares the template literal type of Address values
+const flowAddressType = "/** A Flow account address, e.g. 0x1654653399040a61 */\nexport type FlowAddress = `0x${string}`;\n"
+
+// contractIdentifierType declares the template literal type of the import
+// aliases keying the addresses export
+const contractIdentifierType = "/** A contract import alias, e.g. 0xFlowToken */\nexport type ContractIdentifier = `0x${string}`;\n"
+
 // fclTypeMapping only includes types that need special handling in FCL
 var fclTypeMapping = map[string]string{
 	"UInt128":   "UInt128",
@@ -73,6 +107,38 @@ type TypeScriptFunction struct {
 	ReturnType string
 	Base64     string
 	Type       string
+	// CadenceReturnType is the original Cadence return type string
+	CadenceReturnType string
+	// Deprecated is the deprecation message of the // codegen:deprecated pragma
+	Deprecated string
+	// FilePath is the path of the originating .cdc file
+	FilePath string
+	// Hash is the hex SHA-256 of the trimmed Cadence code
+	Hash string
+	// Pagination names the offset and limit parameters of a paginated script
+	Pagination *analyzer.Pagination
+	// SignerLabels names the accounts authorizing a transaction in prepare
+	// order after the signers and the roles they hold, e.g. adminAsPayer
+	SignerLabels []string
+	// Messages are the user-facing messages of the // codegen:title and
+	// // codegen:description pragmas by key
+	Messages map[string]string
+	// Translations are the messages in other locales, by locale and key
+	Translations map[string]map[string]string
+	// Signers lists the signers of a transaction with roles in prepare order,
+	// e.g. admin (payer), user
+	Signers string
+	// Proposer and Payer are the index of the authorization holding the role,
+	// if any
+	Proposer string
+	Payer    string
+	// ProposerLabel and PayerLabel are the labels of the signers holding the
+	// role, if any
+	ProposerLabel string
+	PayerLabel    string
+	// Union is the discriminated union the result of a script returning
+	// AnyStruct with a // codegen:returns pragma is resolved into
+	Union *TypeScriptUnion
 }
 
 // TypeScriptParameter represents a parameter in TypeScript
@@ -108,12 +174,38 @@ const functionTemplate = `{{- if .Tag}}
 {{- end}}{{range $index, $func := .Functions}}
 {{if $index}}
 
-{{end}}  public async {{$func.Name}}({{range $index, $param := $func.Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{if $param.Optional}}?{{end}}: {{$param.Type}}{{end}}){{if $func.ReturnType}}: Promise<{{$func.ReturnType}}>{{end}} {
-    const code = ` + "`" + `
-{{$func.Base64}}
-` + "`" + `;
+{{end}}  /**
+   {{- with $func.Messages}}{{if .title}}
+   * {{comment .title}}
+   {{- end}}{{if .description}}
+   * {{comment .description}}
+   {{- end}}{{end}}
+   * Source: {{$func.FilePath}}
+   {{- if $func.Hash}}
+   * SHA-256: {{$func.Hash}}
+   {{- end}}
+   * Generated by cadence-codegen{{if $.Version}} {{$.Version}}{{end}}
+   {{- if $func.Signers}}
+   * Authorizations: {{$func.Signers}}
+   {{- end}}
+   {{- if $func.Deprecated}}
+   * @deprecated {{$func.Deprecated}}
+   {{- end}}
+   */
+  public async {{$func.Name}}({{range $index, $param := $func.Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{if $param.Optional}}?{{end}}: {{$param.Type}}{{end}}{{if $func.Parameters}}, {{end}}options?: {{if eq $func.Type "query"}}QueryOptions{{else}}MutationOptions{{$func.SignersOption}}{{end}}){{if $func.ReturnType}}: Promise<{{$func.ReturnType}}>{{else if eq $func.Type "transaction"}}: Promise<string>{{end}} {
+    {{- if and $.Validate $func.Parameters}}
+    validateArguments("{{$func.Name}}", [
+      {{- range $func.Parameters}}
+      ["{{.Name}}", {{.Name}}, "{{.TypeStr}}"],
+      {{- end}}
+    ]);
+    {{- end}}
+    const code = cadence.{{$func.Name}}.code;
+    {{- if $.Integrity}}
+    await this.checkIntegrity("{{$func.Name}}", code);
+    {{- end}}
     {{- if eq $func.Type "query"}}
-    let config = {
+    let config: CadenceConfig<"{{$func.Name}}"> = {
       cadence: code.trim(),
       name: "{{$func.Name}}",
       type: "script",
@@ -122,14 +214,21 @@ const functionTemplate = `{{- if .Tag}}
         arg({{.Name}}, {{getFCLType .TypeStr}}),
         {{- end}}
       ],
-      limit: 9999,
+      limit: options?.limit ?? 9999,
     };
     config = await this.runRequestInterceptors(config);
-    let response = await fcl.query(config);
+    {{- $query := "this.sendScript(config, target)"}}
+    {{- if $.Telemetry}}{{$query = printf "this.instrument(\"%s\", \"script\", () => %s)" $func.Name $query}}{{end}}
+    {{- $query = printf "this.onNetwork(options?.network, (target) => %s)" $query}}
+    {{- if $.Retry}}{{$query = printf "this.withRetry(config, () => %s)" $query}}{{end}}
+    {{- if $.Logging}}{{$query = printf "this.logged(config, () => %s)" $query}}{{end}}
+    {{- if $.Cache}}{{$query = printf "this.cached(config, options?.network, () => %s)" $query}}{{end}}
+    let response = {{if $func.Union}}resolve{{$func.Union.Name}}(await {{$query}}){{else}}await {{$query}}{{end}};
     const result = await this.runResponseInterceptors(config, response);
     return result.response;
     {{- else}}
-    let config = {
+    this.rejectNetwork("{{$func.Name}}", options);
+    let config: CadenceConfig<"{{$func.Name}}"> = {
       cadence: code.trim(),
       name: "{{$func.Name}}",
       type: "transaction",
@@ -138,14 +237,87 @@ const functionTemplate = `{{- if .Tag}}
         arg({{.Name}}, {{getFCLType .TypeStr}}),
         {{- end}}
       ],
-      limit: 9999,
+      limit: options?.limit ?? 9999,
+      {{- if $.Node}}
+      payer: options?.payer{{with $func.PayerLabel}} ?? options?.signers?.{{.}}{{end}} ?? this.serverAuthorization(),
+      proposer: options?.proposer{{with $func.ProposerLabel}} ?? options?.signers?.{{.}}{{end}} ?? this.serverAuthorization(),
+      authorizations: options?.authorizations ?? [{{serverAuthorizations $func.SignerLabels}}],
+      {{- else}}
+      payer: options?.payer{{with $func.PayerLabel}} ?? options?.signers?.{{.}}{{end}}{{if $func.Payer}} ?? options?.authorizations?.[{{$func.Payer}}]{{end}},
+      proposer: options?.proposer{{with $func.ProposerLabel}} ?? options?.signers?.{{.}}{{end}}{{if $func.Proposer}} ?? options?.authorizations?.[{{$func.Proposer}}]{{end}},
+      authorizations: options?.authorizations{{with $func.SignerLabels}} ?? (options?.signers && [{{userAuthorizations .}}]){{end}},
+      {{- end}}
+      {{- if $.Idempotency}}
+      idempotencyKey: options?.idempotencyKey,
+      {{- end}}
     };
     config = await this.runRequestInterceptors(config);
-    let txId = await fcl.mutate(config);
+    {{- $mutate := "fcl.mutate(config)"}}
+    {{- if $.Telemetry}}{{$mutate = printf "this.instrument(\"%s\", \"transaction\", () => fcl.mutate(config))" $func.Name}}{{end}}
+    {{- if $.Retry}}{{$mutate = printf "this.withRetry(config, () => %s)" $mutate}}{{end}}
+    {{- if $.Logging}}{{$mutate = printf "this.logged(config, () => %s)" $mutate}}{{end}}
+    let txId = await {{$mutate}};
     const result = await this.runResponseInterceptors(config, txId);
     return result.response;
     {{- end}}
   }
+{{- if and $.Estimates (eq $func.Type "transaction")}}
+
+  /** Estimates the computation usage of {{$func.Name}} without sending it, e.g. to warn users before signing */
+  public async estimate{{pascalCase $func.Name}}({{range $index, $param := $func.Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{if $param.Optional}}?{{end}}: {{$param.Type}}{{end}}{{if $func.Parameters}}, {{end}}options?: MutationOptions{{$func.SignersOption}}): Promise<ComputationEstimate> {
+    {{- if $.Integrity}}
+    await this.checkIntegrity("{{$func.Name}}", cadence.{{$func.Name}}.code);
+    {{- end}}
+    return this.estimate<"{{$func.Name}}">({
+      cadence: cadence.{{$func.Name}}.code.trim(),
+      name: "{{$func.Name}}",
+      type: "transaction",
+      args: (arg: any, t: any) => [
+        {{- range $func.Parameters}}
+        arg({{.Name}}, {{getFCLType .TypeStr}}),
+        {{- end}}
+      ],
+      limit: options?.limit ?? 9999,
+      payer: options?.payer{{with $func.PayerLabel}} ?? options?.signers?.{{.}}{{end}}{{if $func.Payer}} ?? options?.authorizations?.[{{$func.Payer}}]{{end}},
+      proposer: options?.proposer{{with $func.ProposerLabel}} ?? options?.signers?.{{.}}{{end}}{{if $func.Proposer}} ?? options?.authorizations?.[{{$func.Proposer}}]{{end}},
+      authorizations: options?.authorizations{{with $func.SignerLabels}} ?? (options?.signers && [{{userAuthorizations .}}]){{end}},
+    });
+  }
+{{- end}}
+{{- if and $.OfflineSigning (eq $func.Type "transaction")}}
+
+  /** Exports {{$func.Name}} unsigned for hardware wallets and multi-party signing instead of sending it */
+  public async build{{pascalCase $func.Name}}Payload({{range $func.Parameters}}{{.Name}}{{if .Optional}}?{{end}}: {{.Type}}, {{end}}signers: PayloadSigners, options?: Pick<QueryOptions, "limit">): Promise<UnsignedTransaction> {
+    {{- if $.Integrity}}
+    await this.checkIntegrity("{{$func.Name}}", cadence.{{$func.Name}}.code);
+    {{- end}}
+    return this.buildPayload<"{{$func.Name}}">({
+      cadence: cadence.{{$func.Name}}.code.trim(),
+      name: "{{$func.Name}}",
+      type: "transaction",
+      args: (arg: any, t: any) => [
+        {{- range $func.Parameters}}
+        arg({{.Name}}, {{getFCLType .TypeStr}}),
+        {{- end}}
+      ],
+      limit: options?.limit ?? 9999,
+    }, signers);
+  }
+{{- end}}
+{{- if $func.Pagination}}
+
+  /** Fetches every page of {{$func.Name}}, requesting pageSize items per page */
+  public async fetchAll{{pascalCase $func.Name}}({{range pageParameters $func}}{{.Name}}{{if .Optional}}?{{end}}: {{.Type}}, {{end}}pageSize = 100, options?: QueryOptions): Promise<{{$func.ReturnType}}> {
+    const items: {{$func.ReturnType}} = [];
+    for (let offset = 0; ; offset += pageSize) {
+      const page = await this.{{$func.Name}}({{pageArguments $func}}, options);
+      items.push(...page);
+      if (page.length < pageSize) {
+        return items;
+      }
+    }
+  }
+{{- end}}
 {{- end}}`
 
 // decodeBase64ToUTF8 decodes base64 string to UTF-8 string and formats it
@@ -172,6 +344,34 @@ func decodeBase64ToUTF8(base64Str string) string {
 	return code
 }
 
+// SignersOption returns the signers option of a transaction with
+// authorizers, taking an authorization per signer named after its roles
+func (f TypeScriptFunction) SignersOption() string {
+	if len(f.SignerLabels) == 0 {
+		return ""
+	}
+	fields := make([]string, len(f.SignerLabels))
+	for i, label := range f.SignerLabels {
+		fields[i] = label + "?: AuthorizationFunction"
+	}
+	return " & { signers?: { " + strings.Join(fields, "; ") + " } }"
+}
+
+// userAuthorizations returns the authorizations of a transaction from the
+// signers option, defaulting to the current user
+func userAuthorizations(labels []string) string {
+	authorizations := make([]string, len(labels))
+	for i, label := range labels {
+		authorizations[i] = "options.signers." + label + " ?? (fcl.authz as AuthorizationFunction)"
+	}
+	return strings.Join(authorizations, ", ")
+}
+
+// FunctionName returns the name of the generated function for a Cadence file
+func FunctionName(filename string) string {
+	return formatFunctionName(filename)
+}
+
 // formatFunctionName formats the filename into a valid TypeScript function name
 func formatFunctionName(filename string) string {
 	// Remove .cdc extension
@@ -309,159 +509,14 @@ func convertCadenceTypeToTypeScript(cadenceType string) string {
 	return tsType
 }
 
-// Generate generates TypeScript code for all transactions and scripts
-func (g *Generator) Generate() (string, error) {
-	var buffer bytes.Buffer
+// buildFunctions converts the report into TypeScript functions. It returns the
+// untagged functions, the tagged functions grouped by tag and the sorted tag names.
+func (g *Generator) buildFunctions() ([]TypeScriptFunction, map[string][]TypeScriptFunction, []string) {
 	var functions []TypeScriptFunction
-	// Remove interfaces variable declaration
-	// var interfaces []TypeScriptInterface
 
 	// Map to store functions by tag
 	taggedFunctions := make(map[string][]TypeScriptFunction)
 
-	// Add header with imports
-	buffer.WriteString("import * as fcl from \"@onflow/fcl\";\n\n")
-	buffer.WriteString("/** Generated from Cadence files */\n")
-
-	// 1. Output all interfaces/types (including composite types)
-	// Add FlowSigner interface
-	buffer.WriteString("/** Flow Signer interface for transaction signing */\n")
-	buffer.WriteString("export interface FlowSigner {\n")
-	buffer.WriteString("  address: string;\n")
-	buffer.WriteString("  keyIndex: number;\n")
-	buffer.WriteString("  sign(signableData: Uint8Array): Promise<Uint8Array>;\n")
-	buffer.WriteString("  authzFunc: (account: any) => Promise<any>;\n")
-	buffer.WriteString("}\n\n")
-
-	// Add CompositeSignature and AuthorizationAccount interfaces
-	buffer.WriteString("export interface CompositeSignature {\n")
-	buffer.WriteString("  addr: string;\n")
-	buffer.WriteString("  keyId: number;\n")
-	buffer.WriteString("  signature: string;\n")
-	buffer.WriteString("}\n\n")
-	buffer.WriteString("export interface AuthorizationAccount extends Record<string, any> {\n")
-	buffer.WriteString("  tempId: string;\n")
-	buffer.WriteString("  addr: string;\n")
-	buffer.WriteString("  keyId: number;\n")
-	buffer.WriteString("  signingFunction: (signable: { message: string }) => Promise<CompositeSignature>;\n")
-	buffer.WriteString("}\n\n")
-	buffer.WriteString("export type AuthorizationFunction = (account: any) => Promise<AuthorizationAccount>;\n\n")
-
-	// Export addresses if available
-	if g.Report.Addresses != nil {
-		buffer.WriteString("/** Network addresses for contract imports */\n")
-		buffer.WriteString("export const addresses = ")
-		// Convert addresses to JSON string
-		addressesJSON, err := json.Marshal(g.Report.Addresses)
-		if err != nil {
-			return "", fmt.Errorf("failed to marshal addresses: %w", err)
-		}
-		buffer.WriteString(string(addressesJSON))
-		buffer.WriteString(";\n\n")
-	}
-
-	// Generate interfaces from composite types
-	interfaceTmpl, err := template.New("interface").Parse(interfaceTemplate)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse interface template: %w", err)
-	}
-
-	// Group structs by contract name for nested types
-	contractStructs := make(map[string][]analyzer.Struct)
-	regularStructs := make([]analyzer.Struct, 0)
-
-	for _, composite := range g.Report.Structs {
-		if strings.Contains(composite.Name, ".") {
-			// This is a nested type, group by contract
-			parts := strings.Split(composite.Name, ".")
-			if len(parts) == 2 {
-				contractName := parts[0]
-				contractStructs[contractName] = append(contractStructs[contractName], composite)
-			}
-		} else {
-			// This is a regular struct
-			regularStructs = append(regularStructs, composite)
-		}
-	}
-
-	// Sort regular structs by name for consistent ordering
-	sort.Slice(regularStructs, func(i, j int) bool {
-		return flattenStructName(regularStructs[i].Name) < flattenStructName(regularStructs[j].Name)
-	})
-
-	// Generate regular struct interfaces
-	for _, composite := range regularStructs {
-		tsInterface := TypeScriptInterface{
-			Name:   flattenStructName(composite.Name),
-			Fields: make([]TypeScriptField, 0),
-		}
-		for _, field := range composite.Fields {
-			tsType := convertCadenceTypeToTypeScript(field.TypeStr)
-			tsInterface.Fields = append(tsInterface.Fields, TypeScriptField{
-				Name:     field.Name,
-				Type:     tsType,
-				Optional: field.Optional,
-			})
-		}
-		err = interfaceTmpl.Execute(&buffer, tsInterface)
-		if err != nil {
-			return "", fmt.Errorf("failed to execute interface template: %w", err)
-		}
-		buffer.WriteString("\n\n")
-	}
-
-	// Sort contract names for consistent ordering of nested types
-	var contractNames []string
-	for contractName := range contractStructs {
-		contractNames = append(contractNames, contractName)
-	}
-	sort.Strings(contractNames)
-
-	// Generate nested type interfaces as namespaces
-	for _, contractName := range contractNames {
-		structs := contractStructs[contractName]
-		// Sort structs within each contract by name
-		sort.Slice(structs, func(i, j int) bool {
-			return flattenStructName(structs[i].Name) < flattenStructName(structs[j].Name)
-		})
-
-		for _, composite := range structs {
-			tsInterface := TypeScriptInterface{
-				Name:   flattenStructName(composite.Name),
-				Fields: make([]TypeScriptField, 0),
-			}
-			for _, field := range composite.Fields {
-				tsType := convertCadenceTypeToTypeScript(field.TypeStr)
-				tsInterface.Fields = append(tsInterface.Fields, TypeScriptField{
-					Name:     field.Name,
-					Type:     tsType,
-					Optional: field.Optional,
-				})
-			}
-			err = interfaceTmpl.Execute(&buffer, tsInterface)
-			if err != nil {
-				return "", fmt.Errorf("failed to execute interface template: %w", err)
-			}
-			buffer.WriteString("\n\n")
-		}
-	}
-
-	// 2. Output class header and interceptor related code
-	buffer.WriteString("type RequestInterceptor = (config: any) => any | Promise<any>;\n")
-	buffer.WriteString("type ResponseInterceptor = (config: any, response: any) => { config: any; response: any } | Promise<{ config: any; response: any }>;\n\n")
-	buffer.WriteString("export class CadenceService {\n")
-	buffer.WriteString("  private requestInterceptors: RequestInterceptor[] = [];\n")
-	buffer.WriteString("  private responseInterceptors: ResponseInterceptor[] = [];\n\n")
-
-	// Insert constructor
-	buffer.WriteString("  constructor() {\n")
-	buffer.WriteString("  }\n\n")
-
-	buffer.WriteString("  useRequestInterceptor(interceptor: RequestInterceptor) {\n    this.requestInterceptors.push(interceptor);\n  }\n\n")
-	buffer.WriteString("  useResponseInterceptor(interceptor: ResponseInterceptor) {\n    this.responseInterceptors.push(interceptor);\n  }\n\n")
-	buffer.WriteString("  private async runRequestInterceptors(config: any) {\n    let c = config;\n    for (const interceptor of this.requestInterceptors) {\n      c = await interceptor(c);\n    }\n    return c;\n  }\n\n")
-	buffer.WriteString("  private async runResponseInterceptors(config: any, response: any) {\n    let c = config;\n    let r = response;\n    for (const interceptor of this.responseInterceptors) {\n      const result = await interceptor(c, r);\n      c = result.config;\n      r = result.response;\n    }\n    return { config: c, response: r };\n  }\n\n")
-
 	// Generate functions for transactions
 	// First collect all transaction filenames and sort them
 	var transactionFilenames []string
@@ -473,10 +528,27 @@ func (g *Generator) Generate() (string, error) {
 	for _, filename := range transactionFilenames {
 		result := g.Report.Transactions[filename]
 		tsFunction := TypeScriptFunction{
-			Name:       formatFunctionName(filename),
+			Name:       g.functionName(filename),
 			Parameters: make([]TypeScriptParameter, 0),
 			Base64:     decodeBase64ToUTF8(result.Base64),
 			Type:       "transaction",
+			Deprecated: strings.ReplaceAll(result.Deprecated, "*/", "* /"),
+			FilePath:   result.SourcePath(),
+			Hash:       result.CodeHash(),
+			Messages:   result.Messages,
+		}
+		tsFunction.Translations = result.Translations
+		if len(result.Roles) > 0 {
+			tsFunction.Signers, tsFunction.Proposer, tsFunction.Payer = signerRoles(result)
+		}
+		for _, signer := range result.Signers {
+			tsFunction.SignerLabels = append(tsFunction.SignerLabels, result.SignerLabel(signer.Name))
+		}
+		if signer, ok := result.Roles[analyzer.RoleProposer]; ok {
+			tsFunction.ProposerLabel = result.SignerLabel(signer)
+		}
+		if signer, ok := result.Roles[analyzer.RolePayer]; ok {
+			tsFunction.PayerLabel = result.SignerLabel(signer)
 		}
 
 		for _, param := range result.Parameters {
@@ -508,13 +580,20 @@ func (g *Generator) Generate() (string, error) {
 	for _, filename := range scriptFilenames {
 		result := g.Report.Scripts[filename]
 		tsFunction := TypeScriptFunction{
-			Name:       formatFunctionName(filename),
+			Name:       g.functionName(filename),
 			Parameters: make([]TypeScriptParameter, 0),
 			Base64:     decodeBase64ToUTF8(result.Base64),
 			Type:       "query",
+			Deprecated: strings.ReplaceAll(result.Deprecated, "*/", "* /"),
+			FilePath:   result.SourcePath(),
+			Hash:       result.CodeHash(),
+			Pagination: result.Pagination,
+			Messages:   result.Messages,
 		}
+		tsFunction.Translations = result.Translations
 
 		if result.ReturnType != "" {
+			tsFunction.CadenceReturnType = result.ReturnType
 			tsType := convertCadenceTypeToTypeScript(result.ReturnType)
 			// Replace all function return type references
 			if strings.HasPrefix(tsType, "[") && strings.HasSuffix(tsType, "]") {
@@ -532,6 +611,13 @@ func (g *Generator) Generate() (string, error) {
 			}
 			tsFunction.ReturnType = tsType
 		}
+		if len(result.ReturnUnion) > 0 {
+			tsFunction.Union = g.returnUnion(tsFunction.Name, result.ReturnType, result.ReturnUnion)
+			tsFunction.ReturnType = tsFunction.Union.Name
+			if tsFunction.Union.Optional {
+				tsFunction.ReturnType += " | undefined"
+			}
+		}
 
 		for _, param := range result.Parameters {
 			tsType := convertCadenceTypeToTypeScript(param.TypeStr)
@@ -569,37 +655,285 @@ func (g *Generator) Generate() (string, error) {
 		})
 	}
 
+	return functions, taggedFunctions, tagNames
+}
+
+// Generate generates TypeScript code for all transactions and scripts
+func (g *Generator) Generate() (string, error) {
+	var buffer bytes.Buffer
+
+	// Add header with imports
+	buffer.WriteString("import * as fcl from \"@onflow/fcl\";\n")
+	buffer.WriteString("import type { Account, CompositeSignature, TransactionStatus } from \"@onflow/typedefs\";\n")
+	if g.OfflineSigning {
+		buffer.WriteString(offlineImport)
+	}
+	if g.Node {
+		buffer.WriteString(nodeImport)
+	}
+	buffer.WriteString("\n")
+	buffer.WriteString("export type { Account, CompositeSignature, TransactionStatus };\n\n")
+	buffer.WriteString("/** Generated from Cadence files */\n")
+
+	// 1. Output all interfaces/types (including composite types)
+	buffer.WriteString(flowAddressType)
+	buffer.WriteString("\n")
+	buffer.WriteString(contractIdentifierType)
+	buffer.WriteString("\n")
+
+	// Add FlowSigner interface
+	buffer.WriteString("/** Flow Signer interface for transaction signing */\n")
+	buffer.WriteString("export interface FlowSigner {\n")
+	buffer.WriteString("  address: string;\n")
+	buffer.WriteString("  keyIndex: number;\n")
+	buffer.WriteString("  sign(signableData: Uint8Array): Promise<Uint8Array>;\n")
+	buffer.WriteString("  authzFunc: AuthorizationFunction;\n")
+	buffer.WriteString("}\n\n")
+
+	// Add the signature returned by signing functions and the AuthorizationAccount interface
+	buffer.WriteString("/** Signature returned by signing functions, fcl adds the remaining CompositeSignature fields */\n")
+	buffer.WriteString("export type SigningResult = Pick<CompositeSignature, \"addr\" | \"keyId\" | \"signature\">;\n\n")
+	buffer.WriteString("export interface AuthorizationAccount extends Record<string, any> {\n")
+	buffer.WriteString("  tempId: string;\n")
+	buffer.WriteString("  addr: string;\n")
+	buffer.WriteString("  keyId: number;\n")
+	buffer.WriteString("  signingFunction: (signable: { message: string }) => Promise<SigningResult>;\n")
+	buffer.WriteString("}\n\n")
+	buffer.WriteString("export type AuthorizationFunction = (account: AuthorizationAccount) => Promise<AuthorizationAccount>;\n\n")
+
+	// Export addresses if available
+	if g.Report.Addresses != nil {
+		buffer.WriteString("/** Network addresses for contract imports */\n")
+		buffer.WriteString("export const addresses: Record<string, Record<ContractIdentifier, FlowAddress>> = ")
+		// Convert addresses to JSON string
+		addressesJSON, err := json.Marshal(g.Report.Addresses)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal addresses: %w", err)
+		}
+		buffer.WriteString(string(addressesJSON))
+		buffer.WriteString(";\n\n")
+	}
+
+	// Export the storage and public paths used by the Cadence code
+	buffer.WriteString(g.generatePaths())
+
+	if err := g.writeInterfaces(&buffer); err != nil {
+		return "", err
+	}
+
+	// Output the MetadataViews types and resolvers if scripts use them
+	buffer.WriteString(g.generateMetadataViews())
+
+	// Output argument validation helpers if enabled
+	if g.Validate {
+		validation, err := generateValidation()
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString(validation)
+		buffer.WriteString("\n")
+	}
+
+	// 2. Output class header and interceptor related code
+	if g.Telemetry {
+		buffer.WriteString(telemetryTypes)
+	}
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+	allFunctions := append([]TypeScriptFunction(nil), functions...)
+	for _, tag := range tagNames {
+		allFunctions = append(allFunctions, taggedFunctions[tag]...)
+	}
+	generateMap := generateCadenceMap
+	if g.Slim {
+		generateMap = generateSlimCadenceMap
+	}
+	unions, err := g.generateUnions(allFunctions)
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(unions)
+	cadenceMap, err := generateMap(allFunctions)
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(cadenceMap)
+	manifest, err := g.generateManifest()
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(manifest)
+	messages, err := generateMessages(allFunctions)
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(messages)
+	interceptorTypes, err := generateInterceptorTypes(allFunctions, g.Idempotency)
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(interceptorTypes)
+	buffer.WriteString(optionsTypes(g.Idempotency, g.Report.AccessNodes))
+	buffer.WriteString(networkTypes)
+	if len(g.Report.AccessNodes) > 0 {
+		buffer.WriteString(failoverTypes)
+	}
+	if g.Idempotency {
+		buffer.WriteString(idempotencyTypes)
+	}
+	if g.OfflineSigning {
+		buffer.WriteString(offlineTypes)
+	}
+	if g.Cache {
+		buffer.WriteString(cacheTypes)
+	}
+	if g.Retry {
+		buffer.WriteString(retryTypes)
+	}
+	if g.Logging {
+		buffer.WriteString(loggingTypes)
+	}
+	if g.Estimates {
+		buffer.WriteString(estimateTypes)
+	}
+	if g.Integrity {
+		buffer.WriteString(integrityTypes)
+		buffer.WriteString(integrityHashes(allFunctions))
+	}
+	if g.Node {
+		buffer.WriteString(nodeTypes)
+	}
+	buffer.WriteString("export class CadenceService {\n")
+	buffer.WriteString("  private requestInterceptors: RequestInterceptor[] = [];\n")
+	buffer.WriteString("  private responseInterceptors: ResponseInterceptor[] = [];\n")
+	if len(g.Report.AccessNodes) > 0 {
+		buffer.WriteString(failoverField)
+	}
+	if g.Telemetry {
+		buffer.WriteString(telemetryField)
+	}
+	if g.Estimates {
+		buffer.WriteString(estimateField)
+	}
+	if g.Cache {
+		buffer.WriteString(cacheFields)
+	}
+	if g.Retry {
+		buffer.WriteString(retryField)
+	}
+	if g.Logging {
+		buffer.WriteString(loggingField)
+	}
+	if g.Integrity {
+		buffer.WriteString(integrityField)
+	}
+	if g.Node {
+		buffer.WriteString(nodeField)
+	}
+	buffer.WriteString("\n")
+
+	// Insert constructor
+	buffer.WriteString("  constructor() {\n")
+	buffer.WriteString("  }\n\n")
+
+	buffer.WriteString("  useRequestInterceptor(interceptor: RequestInterceptor) {\n    this.requestInterceptors.push(interceptor);\n  }\n\n")
+	buffer.WriteString("  useResponseInterceptor(interceptor: ResponseInterceptor) {\n    this.responseInterceptors.push(interceptor);\n  }\n\n")
+	buffer.WriteString(interceptorMethods)
+	if len(g.Report.AccessNodes) > 0 {
+		buffer.WriteString(failoverMethod())
+	} else {
+		buffer.WriteString(networkMethod)
+	}
+	buffer.WriteString(sendScriptMethod(g.Report.Addresses != nil))
+	buffer.WriteString(rejectNetworkMethod)
+	buffer.WriteString("  async getAccount(address: string): Promise<Account> {\n    return fcl.account(address);\n  }\n\n")
+	buffer.WriteString("  async waitForTransaction(txId: string): Promise<TransactionStatus> {\n    return fcl.tx(txId).onceSealed();\n  }\n\n")
+	buffer.WriteString(batchMethod)
+	if g.Telemetry {
+		buffer.WriteString(telemetryMethods)
+	}
+	if g.Estimates {
+		buffer.WriteString(estimateMethods)
+	}
+	if g.Idempotency {
+		buffer.WriteString(idempotencyMethods)
+	}
+	if g.OfflineSigning {
+		buffer.WriteString(offlineMethods(g.Report.Addresses != nil))
+	}
+	if g.Cache {
+		buffer.WriteString(cacheMethods)
+	}
+	if g.Retry {
+		buffer.WriteString(retryMethods)
+	}
+	if g.Logging {
+		buffer.WriteString(loggingMethods)
+	}
+	if g.Integrity {
+		buffer.WriteString(integrityMethods)
+	}
+	if g.Node {
+		buffer.WriteString(nodeMethods)
+	}
+	setup, err := g.generateSetupMethod()
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(setup)
+
 	// Generate functions
 	funcMap := template.FuncMap{
-		"getFCLType": getFCLType,
+		"getFCLType":           getFCLType,
+		"pascalCase":           pascalCase,
+		"pageParameters":       pageParameters,
+		"pageArguments":        pageArguments,
+		"serverAuthorizations": serverAuthorizations,
+		"userAuthorizations":   userAuthorizations,
+		"comment":              commentText,
 	}
 	tmpl, err := template.New("function").Funcs(funcMap).Parse(functionTemplate)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
 	// First generate the base functions
-	err = tmpl.Execute(&buffer, struct {
-		Functions []TypeScriptFunction
-		Tag       string
+	data := struct {
+		Functions      []TypeScriptFunction
+		Tag            string
+		Validate       bool
+		Telemetry      bool
+		Estimates      bool
+		Idempotency    bool
+		OfflineSigning bool
+		Cache          bool
+		Retry          bool
+		Logging        bool
+		Integrity      bool
+		Node           bool
+		Version        string
 	}{
-		Functions: functions,
-		Tag:       "",
-	})
-	if err != nil {
+		Functions:      functions,
+		Tag:            "",
+		Validate:       g.Validate,
+		Telemetry:      g.Telemetry,
+		Estimates:      g.Estimates,
+		Idempotency:    g.Idempotency,
+		OfflineSigning: g.OfflineSigning,
+		Cache:          g.Cache,
+		Retry:          g.Retry,
+		Logging:        g.Logging,
+		Integrity:      g.Integrity,
+		Node:           g.Node,
+		Version:        g.Report.CodegenVersion,
+	}
+	if err := tmpl.Execute(&buffer, data); err != nil {
 		return "", fmt.Errorf("failed to execute template: %w", err)
 	}
 	// Then generate tagged functions in separate sections
 	for _, tag := range tagNames {
-		tagFunctions := taggedFunctions[tag]
 		buffer.WriteString("\n")
-		err = tmpl.Execute(&buffer, struct {
-			Functions []TypeScriptFunction
-			Tag       string
-		}{
-			Functions: tagFunctions,
-			Tag:       tag,
-		})
-		if err != nil {
+		data.Functions = taggedFunctions[tag]
+		data.Tag = tag
+		if err := tmpl.Execute(&buffer, data); err != nil {
 			return "", fmt.Errorf("failed to execute template: %w", err)
 		}
 	}
@@ -608,3 +942,92 @@ func (g *Generator) Generate() (string, error) {
 
 	return buffer.String(), nil
 }
+
+// writeInterfaces writes the interfaces of all structs, nested types after
+// regular ones
+func (g *Generator) writeInterfaces(buffer *bytes.Buffer) error {
+	// Generate interfaces from composite types
+	interfaceTmpl, err := template.New("interface").Parse(interfaceTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse interface template: %w", err)
+	}
+
+	// Group structs by contract name for nested types
+	contractStructs := make(map[string][]analyzer.Struct)
+	regularStructs := make([]analyzer.Struct, 0)
+
+	for _, composite := range g.Report.Structs {
+		if strings.Contains(composite.Name, ".") {
+			// This is a nested type, group by contract
+			parts := strings.Split(composite.Name, ".")
+			if len(parts) == 2 {
+				contractName := parts[0]
+				contractStructs[contractName] = append(contractStructs[contractName], composite)
+			}
+		} else {
+			// This is a regular struct
+			regularStructs = append(regularStructs, composite)
+		}
+	}
+
+	// Sort regular structs by name for consistent ordering
+	sort.Slice(regularStructs, func(i, j int) bool {
+		return flattenStructName(regularStructs[i].Name) < flattenStructName(regularStructs[j].Name)
+	})
+
+	// Generate regular struct interfaces
+	for _, composite := range regularStructs {
+		err = interfaceTmpl.Execute(buffer, structInterface(composite))
+		if err != nil {
+			return fmt.Errorf("failed to execute interface template: %w", err)
+		}
+		buffer.WriteString("\n\n")
+	}
+
+	// Sort contract names for consistent ordering of nested types
+	var contractNames []string
+	for contractName := range contractStructs {
+		contractNames = append(contractNames, contractName)
+	}
+	sort.Strings(contractNames)
+
+	// Generate nested type interfaces as namespaces
+	for _, contractName := range contractNames {
+		structs := contractStructs[contractName]
+		// Sort structs within each contract by name
+		sort.Slice(structs, func(i, j int) bool {
+			return flattenStructName(structs[i].Name) < flattenStructName(structs[j].Name)
+		})
+
+		for _, composite := range structs {
+			err = interfaceTmpl.Execute(buffer, structInterface(composite))
+			if err != nil {
+				return fmt.Errorf("failed to execute interface template: %w", err)
+			}
+			buffer.WriteString("\n\n")
+		}
+	}
+	return nil
+}
+
+// signerRoles lists the signers of a transaction with their roles, e.g.
+// admin (payer), user, and returns the index of the authorizations of its
+// proposer and payer, empty for roles no signer holds
+func signerRoles(result analyzer.AnalysisResult) (string, string, string) {
+	var signers []string
+	proposer, payer := "", ""
+	for i, signer := range result.Signers {
+		label := signer.Name
+		if roles := result.SignerRoles(signer.Name); len(roles) > 0 {
+			label += " (" + strings.Join(roles, ", ") + ")"
+		}
+		signers = append(signers, label)
+		if result.Roles[analyzer.RoleProposer] == signer.Name {
+			proposer = strconv.Itoa(i)
+		}
+		if result.Roles[analyzer.RolePayer] == signer.Name {
+			payer = strconv.Itoa(i)
+		}
+	}
+	return strings.Join(signers, ", "), proposer, payer
+}
@@ -0,0 +1,69 @@
+package typescript
+
+// idempotencyTypes declares the store of submitted idempotency keys
+const idempotencyTypes = `/** Transaction IDs by idempotency key, see useIdempotency */
+export interface IdempotencyStore {
+  get(key: string): string | undefined | Promise<string | undefined>;
+  set(key: string, txId: string): void | Promise<void>;
+}
+
+/** Rejects a transaction whose idempotency key was already submitted */
+export class DuplicateTransactionError extends Error {
+  constructor(
+    readonly idempotencyKey: string,
+    readonly txId: string,
+  ) {
+    super(` + "`" + `transaction with idempotency key ${idempotencyKey} was already submitted as ${txId}` + "`" + `);
+    this.name = "DuplicateTransactionError";
+  }
+}
+
+/** Keeps idempotency keys in memory, e.g. for the lifetime of a page */
+export function memoryIdempotencyStore(): IdempotencyStore {
+  const txIds = new Map<string, string>();
+  return {
+    get: (key) => txIds.get(key),
+    set: (key, txId) => {
+      txIds.set(key, txId);
+    },
+  };
+}
+
+/** Creates a key identifying one logical submission across retries */
+export function createIdempotencyKey(): string {
+  return crypto.randomUUID();
+}
+
+`
+
+// idempotencyMethods registers the interceptors checking and storing keys
+const idempotencyMethods = `  /**
+   * Rejects transactions sent with an idempotency key that was already
+   * submitted with a DuplicateTransactionError carrying the first transaction
+   * ID, so app-level retries do not send a transaction twice
+   */
+  useIdempotency(store: IdempotencyStore = memoryIdempotencyStore()) {
+    this.useRequestInterceptor(async (config) => {
+      if (config.type === "transaction" && config.idempotencyKey) {
+        const txId = await store.get(config.idempotencyKey);
+        if (txId) {
+          throw new DuplicateTransactionError(config.idempotencyKey, txId);
+        }
+      }
+      return config;
+    });
+    this.useResponseInterceptor(async (config, response) => {
+      if (config.type === "transaction" && config.idempotencyKey) {
+        await store.set(config.idempotencyKey, response as string);
+      }
+      return { config, response };
+    });
+  }
+
+`
+
+// SetIdempotency enables idempotency keys detecting duplicate submissions of
+// transactions
+func (g *Generator) SetIdempotency(idempotency bool) {
+	g.Idempotency = idempotency
+}
@@ -0,0 +1,277 @@
+package typescript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+const indexerTemplate = `import * as fcl from "@onflow/fcl";
+
+/** Generated event indexer client for Cadence events */
+
+{{.FlowAddressType}}
+{{- if .Addresses}}
+
+/** Network addresses for contract imports */
+export const addresses: Record<string, Record<string, string>> = {{.Addresses}};
+{{- end}}
+{{- range .Interfaces}}
+
+{{.}}
+{{- end}}
+{{- range .Events}}
+
+/** Payload of {{.Contract}}.{{.Name}} */
+export interface {{.InterfaceName}} {
+{{- range .Fields}}
+  {{.Name}}{{if .Optional}}?{{end}}: {{.Type}};
+{{- end}}
+}
+{{- end}}
+
+/** An event decoded from the Access API */
+export interface IndexedEvent<T> {
+  type: string;
+  blockId: string;
+  blockHeight: number;
+  blockTimestamp: string;
+  transactionId: string;
+  transactionIndex: number;
+  eventIndex: number;
+  data: T;
+}
+
+export type EventHandler<T> = (event: IndexedEvent<T>) => void | Promise<void>;
+
+export interface EventIndexerOptions {
+  /** Access API REST endpoint, e.g. https://rest-mainnet.onflow.org */
+  accessNode: string;
+  /** Network used to look up contract addresses */
+  network: string;
+  /** Contract addresses per network, keyed by import alias (e.g. 0xFlowToken) */
+  addresses?: Record<string, Record<string, string>>;
+  /** Delay between polls in milliseconds */
+  pollInterval?: number;
+  /** Maximum number of blocks per events request */
+  batchSize?: number;
+  /** Called when a poll fails; polling continues afterwards */
+  onError?: (error: unknown) => void;
+}
+
+/** Polls the Access API for events and decodes their payloads */
+export class EventIndexer {
+  private options: Required<Omit<EventIndexerOptions, "onError">> & Pick<EventIndexerOptions, "onError">;
+
+  constructor(options: EventIndexerOptions) {
+    this.options = {
+      pollInterval: 2000,
+      batchSize: 250,
+      addresses: {{if .Addresses}}addresses{{else}}{}{{end}},
+      ...options,
+    };
+  }
+
+  /** Returns the fully qualified event type, e.g. A.1654653399040a61.FlowToken.TokensDeposited */
+  eventType(contract: string, name: string): string {
+    const networkAddresses = this.options.addresses[this.options.network] ?? {};
+    const address = networkAddresses["0x" + contract] ?? networkAddresses[contract];
+    if (!address) {
+      throw new Error(` + "`" + `no address for contract ${contract} on ${this.options.network}` + "`" + `);
+    }
+    return ` + "`" + `A.${address.replace(/^0x/, "")}.${contract}.${name}` + "`" + `;
+  }
+
+  /** Returns the height of the latest sealed block */
+  async latestSealedHeight(): Promise<number> {
+    const response = await fetch(` + "`" + `${this.options.accessNode}/v1/blocks?height=sealed` + "`" + `);
+    if (!response.ok) {
+      throw new Error(` + "`" + `failed to fetch latest sealed block: ${response.status}` + "`" + `);
+    }
+    const blocks = await response.json();
+    return Number(blocks[0].header.height);
+  }
+
+  /** Fetches and decodes all events of a type between two block heights (inclusive) */
+  async fetchEvents<T>(type: string, startHeight: number, endHeight: number): Promise<IndexedEvent<T>[]> {
+    const url = ` + "`" + `${this.options.accessNode}/v1/events?type=${encodeURIComponent(type)}&start_height=${startHeight}&end_height=${endHeight}` + "`" + `;
+    const response = await fetch(url);
+    if (!response.ok) {
+      throw new Error(` + "`" + `failed to fetch events ${type}: ${response.status}` + "`" + `);
+    }
+    const blocks = await response.json();
+    const events: IndexedEvent<T>[] = [];
+    for (const block of blocks) {
+      for (const event of block.events ?? []) {
+        events.push({
+          type: event.type,
+          blockId: block.block_id,
+          blockHeight: Number(block.block_height),
+          blockTimestamp: block.block_timestamp,
+          transactionId: event.transaction_id,
+          transactionIndex: Number(event.transaction_index),
+          eventIndex: Number(event.event_index),
+          data: await decodePayload<T>(event.payload),
+        });
+      }
+    }
+    return events;
+  }
+
+  /** Polls for events of a type starting at startHeight; returns a function that stops polling */
+  subscribe<T>(type: string, startHeight: number, handler: EventHandler<T>): () => void {
+    let stopped = false;
+    let nextHeight = startHeight;
+
+    const poll = async () => {
+      while (!stopped) {
+        try {
+          const sealedHeight = await this.latestSealedHeight();
+          while (!stopped && nextHeight <= sealedHeight) {
+            const endHeight = Math.min(nextHeight + this.options.batchSize - 1, sealedHeight);
+            for (const event of await this.fetchEvents<T>(type, nextHeight, endHeight)) {
+              await handler(event);
+            }
+            nextHeight = endHeight + 1;
+          }
+        } catch (error) {
+          this.options.onError?.(error);
+        }
+        await new Promise((resolve) => setTimeout(resolve, this.options.pollInterval));
+      }
+    };
+    poll();
+
+    return () => {
+      stopped = true;
+    };
+  }
+{{range .Events}}
+  /** Subscribes to {{.Contract}}.{{.Name}} events */
+  on{{.InterfaceName}}(startHeight: number, handler: EventHandler<{{.InterfaceName}}>): () => void {
+    return this.subscribe(this.eventType("{{.Contract}}", "{{.Name}}"), startHeight, handler);
+  }
+{{end}}}
+
+/** Decodes a base64 encoded JSON-Cadence event payload */
+async function decodePayload<T>(payload: string): Promise<T> {
+  const json = typeof atob === "function" ? atob(payload) : Buffer.from(payload, "base64").toString("utf8");
+  return (await fcl.decode(JSON.parse(json))) as T;
+}
+`
+
+// indexerEvent holds the data needed to render the indexer code of an event
+type indexerEvent struct {
+	Name          string
+	Contract      string
+	InterfaceName string
+	Fields        []TypeScriptField
+}
+
+// GenerateIndexer generates an event indexer client for all events in the report
+func (g *Generator) GenerateIndexer() (string, error) {
+	var keys []string
+	for key := range g.Report.Events {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var events []indexerEvent
+	var fieldTypes []string
+	for _, key := range keys {
+		event := g.Report.Events[key]
+		ie := indexerEvent{
+			Name:          event.Name,
+			Contract:      event.Contract,
+			InterfaceName: flattenStructName(event.Contract + "." + event.Name),
+		}
+		for _, field := range event.Fields {
+			tsType := convertCadenceTypeToTypeScript(field.TypeStr)
+			fieldTypes = append(fieldTypes, tsType)
+			ie.Fields = append(ie.Fields, TypeScriptField{
+				Name:     field.Name,
+				Type:     tsType,
+				Optional: field.Optional,
+			})
+		}
+		events = append(events, ie)
+	}
+
+	// Declare the struct interfaces used by event fields
+	interfaceTmpl, err := template.New("interface").Parse(interfaceTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse interface template: %w", err)
+	}
+	joinedTypes := strings.Join(fieldTypes, " ")
+	var structNames []string
+	for name := range g.Report.Structs {
+		structNames = append(structNames, name)
+	}
+	sort.Strings(structNames)
+	var interfaces []string
+	for _, name := range structNames {
+		composite := g.Report.Structs[name]
+		interfaceName := flattenStructName(composite.Name)
+		if !regexp.MustCompile(`\b` + regexp.QuoteMeta(interfaceName) + `\b`).MatchString(joinedTypes) {
+			continue
+		}
+		var buffer bytes.Buffer
+		if err := interfaceTmpl.Execute(&buffer, structInterface(composite)); err != nil {
+			return "", fmt.Errorf("failed to execute interface template: %w", err)
+		}
+		interfaces = append(interfaces, buffer.String())
+	}
+
+	var addresses string
+	if g.Report.Addresses != nil {
+		addressesJSON, err := json.Marshal(g.Report.Addresses)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal addresses: %w", err)
+		}
+		addresses = string(addressesJSON)
+	}
+
+	tmpl, err := template.New("indexer").Parse(indexerTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse indexer template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		FlowAddressType string
+		Addresses       string
+		Interfaces      []string
+		Events          []indexerEvent
+	}{
+		FlowAddressType: strings.TrimSuffix(flowAddressType, "\n"),
+		Addresses:       addresses,
+		Interfaces:      interfaces,
+		Events:          events,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute indexer template: %w", err)
+	}
+
+	return buffer.String(), nil
+}
+
+// structInterface converts an analyzed struct into a TypeScript interface
+func structInterface(composite analyzer.Struct) TypeScriptInterface {
+	tsInterface := TypeScriptInterface{
+		Name:   flattenStructName(composite.Name),
+		Fields: make([]TypeScriptField, 0),
+	}
+	for _, field := range composite.Fields {
+		tsInterface.Fields = append(tsInterface.Fields, TypeScriptField{
+			Name:     field.Name,
+			Type:     convertCadenceTypeToTypeScript(field.TypeStr),
+			Optional: field.Optional,
+		})
+	}
+	return tsInterface
+}
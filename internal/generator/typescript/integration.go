@@ -0,0 +1,187 @@
+package typescript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// EmulatorServiceAddress is the service account of the Flow emulator, used
+// as the zero value of Address arguments in integration tests
+const EmulatorServiceAddress = "0xf8d6e0586b0a20c7"
+
+const integrationTemplate = `/** Generated emulator integration tests running every Cadence script */
+{{- if eq .Framework "jest"}}
+import { describe, it, expect, beforeAll, afterAll } from "@jest/globals";
+import path from "node:path";
+{{- else}}
+import { describe, it, expect, beforeAll, afterAll } from "vitest";
+import { fileURLToPath } from "node:url";
+{{- end}}
+import { spawn, execSync, type ChildProcess } from "node:child_process";
+import * as fcl from "@onflow/fcl";
+import { CadenceService } from "{{.ImportPath}}";
+
+/** Directory of flow.json, contracts are deployed with its emulator deployments */
+{{- if eq .Framework "jest"}}
+const projectDir = path.join(__dirname, {{.ProjectDir}});
+{{- else}}
+const projectDir = fileURLToPath(new URL({{.ProjectDir}}, import.meta.url));
+{{- end}}
+const accessNode = "http://127.0.0.1:8888";
+
+/** Contract addresses of the emulator deployments in flow.json */
+const contractAddresses: Record<string, string> = {{.Addresses}};
+
+async function waitForEmulator(timeout = 30_000) {
+  const deadline = Date.now() + timeout;
+  while (Date.now() < deadline) {
+    try {
+      const response = await fetch(accessNode + "/v1/blocks?height=sealed");
+      if (response.ok) return;
+    } catch {
+      // Not listening yet
+    }
+    await new Promise((resolve) => setTimeout(resolve, 500));
+  }
+  throw new Error("Flow emulator did not start within " + timeout + "ms");
+}
+
+describe("Cadence scripts on the Flow emulator", () => {
+  let emulator: ChildProcess;
+  let service: CadenceService;
+
+  beforeAll(async () => {
+    emulator = spawn("flow", ["emulator"], { cwd: projectDir, stdio: "ignore" });
+    await waitForEmulator();
+    execSync("flow project deploy --network emulator", { cwd: projectDir, stdio: "inherit" });
+    fcl.config({ "flow.network": "emulator", "accessNode.api": accessNode, ...contractAddresses });
+    service = new CadenceService();
+  }, 120_000);
+
+  afterAll(() => {
+    emulator?.kill();
+  });
+{{- range .Functions}}
+  {{- if .Skip}}
+
+  // {{.Name}} takes arguments without a zero value, such as structs
+  it.skip("{{.Name}} executes and decodes its result", () => {});
+  {{- else}}
+
+  it("{{.Name}} executes and decodes its result", async () => {
+    await expect(service.{{.Name}}({{join .Arguments ", "}})).resolves.toBeDefined();
+  });
+  {{- end}}
+{{- end}}
+});
+`
+
+// integrationFunction is a script executed by the integration tests
+type integrationFunction struct {
+	Name      string
+	Arguments []string
+	Skip      bool
+}
+
+// zeroValue returns a TypeScript literal with the zero value of a Cadence
+// type, or false for types without one such as structs
+func zeroValue(cadenceType string) (string, bool) {
+	cadenceType = strings.TrimSpace(cadenceType)
+	if strings.HasSuffix(cadenceType, "?") {
+		return "null", true
+	}
+	if strings.HasPrefix(cadenceType, "[") && strings.HasSuffix(cadenceType, "]") {
+		return "[]", true
+	}
+	if strings.HasPrefix(cadenceType, "{") && strings.HasSuffix(cadenceType, "}") {
+		return "{}", true
+	}
+
+	switch cadenceType {
+	case "String", "Character":
+		return `""`, true
+	case "Address":
+		return `"` + EmulatorServiceAddress + `"`, true
+	case "Bool":
+		return "false", true
+	case "UFix64", "Fix64":
+		return `"0.0"`, true
+	case "UInt128", "UInt256", "Int128", "Int256":
+		return `"0"`, true
+	}
+	if tsType, ok := typeMapping[cadenceType]; ok && tsType == "number" {
+		return "0", true
+	}
+	return "", false
+}
+
+// GenerateIntegrationTests generates a test suite that starts the Flow
+// emulator in projectDir, deploys the contracts of its flow.json and executes
+// every script with zero value arguments. importPath is the module path of the
+// generated service and projectDir the path of the project, both relative to
+// the test file. addresses holds the emulator contract addresses keyed by
+// import alias.
+func (g *Generator) GenerateIntegrationTests(framework string, importPath string, projectDir string, addresses map[string]interface{}) (string, error) {
+	if framework != TestFrameworkVitest && framework != TestFrameworkJest {
+		return "", fmt.Errorf("unsupported test framework: %s", framework)
+	}
+
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+	for _, tag := range tagNames {
+		functions = append(functions, taggedFunctions[tag]...)
+	}
+	var scripts []integrationFunction
+	for _, function := range functions {
+		if function.Type != "query" {
+			continue
+		}
+		script := integrationFunction{Name: function.Name}
+		for _, param := range function.Parameters {
+			value, ok := zeroValue(param.TypeStr)
+			if !ok {
+				script.Skip = true
+				break
+			}
+			script.Arguments = append(script.Arguments, value)
+		}
+		scripts = append(scripts, script)
+	}
+
+	if addresses == nil {
+		addresses = map[string]interface{}{}
+	}
+	addressesJSON, err := json.MarshalIndent(addresses, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal addresses: %w", err)
+	}
+	projectDirJSON, err := json.Marshal(projectDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal project directory: %w", err)
+	}
+
+	tmpl, err := template.New("integration").Funcs(template.FuncMap{"join": strings.Join}).Parse(integrationTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse integration test template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Framework  string
+		ImportPath string
+		ProjectDir string
+		Addresses  string
+		Functions  []integrationFunction
+	}{
+		Framework:  framework,
+		ImportPath: importPath,
+		ProjectDir: string(projectDirJSON),
+		Addresses:  string(addressesJSON),
+		Functions:  scripts,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute integration test template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,55 @@
+package typescript
+
+import (
+	"fmt"
+	"strings"
+)
+
+// integrityTypes declares the error thrown when embedded code was modified
+const integrityTypes = `/** Thrown when the embedded Cadence code of a function no longer matches its hash */
+export class CadenceIntegrityError extends Error {
+  constructor(public readonly functionName: string) {
+    super("Cadence code of " + functionName + " does not match its hash");
+    this.name = "CadenceIntegrityError";
+  }
+}
+
+`
+
+// integrityHashes declares the expected hash of the code of every function in
+// a frozen constant of the module, so that changes to the exported cadence map
+// at runtime cannot change the hash the code is checked against
+func integrityHashes(functions []TypeScriptFunction) string {
+	var b strings.Builder
+	b.WriteString("/** SHA-256 of the Cadence code of every function, kept apart from the exported cadence map */\n")
+	b.WriteString("const integrityHashes: Readonly<Record<CadenceFunctionName, string>> = Object.freeze({\n")
+	for _, function := range functions {
+		fmt.Fprintf(&b, "  %s: %q,\n", function.Name, function.Hash)
+	}
+	b.WriteString("});\n\n")
+	return b.String()
+}
+
+// integrityField declares the code already checked per function
+const integrityField = "  private verifiedCode = new Map<string, string>();\n"
+
+// integrityMethods hashes the code of a function before it is executed
+const integrityMethods = `  private async checkIntegrity(name: CadenceFunctionName, code: string) {
+    if (this.verifiedCode.get(name) === code) {
+      return;
+    }
+    const digest = await crypto.subtle.digest("SHA-256", new TextEncoder().encode(code.trim()));
+    const hash = Array.from(new Uint8Array(digest), (byte) => byte.toString(16).padStart(2, "0")).join("");
+    if (hash !== integrityHashes[name]) {
+      throw new CadenceIntegrityError(name);
+    }
+    this.verifiedCode.set(name, code);
+  }
+
+`
+
+// SetIntegrity enables the integrity check of the embedded code of a function
+// before it is executed
+func (g *Generator) SetIntegrity(integrity bool) {
+	g.Integrity = integrity
+}
@@ -0,0 +1,111 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+const interceptorTemplate = `/** Response types of the generated functions by name */
+export interface CadenceResponses {
+{{- range .Functions}}
+  {{.Name}}: {{responseType .}};
+{{- end}}
+}
+
+export type CadenceFunctionName = keyof CadenceResponses;
+
+/** Configuration of the fcl query or mutate call of a generated function */
+export interface CadenceConfig<Name extends CadenceFunctionName = CadenceFunctionName> {
+  cadence: string;
+  name: Name;
+  type: "script" | "transaction";
+  args: (arg: any, t: any) => unknown[];
+  limit: number;
+  /** Authorizations of transactions, fcl defaults to the current user */
+  payer?: AuthorizationFunction;
+  proposer?: AuthorizationFunction;
+  authorizations?: AuthorizationFunction[];
+  {{- if .Idempotency}}
+  /** Identifies one logical submission of a transaction across retries */
+  idempotencyKey?: string;
+  {{- end}}
+}
+
+export type RequestInterceptor = <Name extends CadenceFunctionName>(
+  config: CadenceConfig<Name>,
+) => CadenceConfig<Name> | Promise<CadenceConfig<Name>>;
+
+export type ResponseInterceptor = <Name extends CadenceFunctionName>(
+  config: CadenceConfig<Name>,
+  response: CadenceResponses[Name],
+) => InterceptedResponse<Name> | Promise<InterceptedResponse<Name>>;
+
+export interface InterceptedResponse<Name extends CadenceFunctionName> {
+  config: CadenceConfig<Name>;
+  response: CadenceResponses[Name];
+}
+
+`
+
+// interceptorMethods runs the interceptors in registration order
+const interceptorMethods = `  private async runRequestInterceptors<Name extends CadenceFunctionName>(config: CadenceConfig<Name>): Promise<CadenceConfig<Name>> {
+    let c = config;
+    for (const interceptor of this.requestInterceptors) {
+      c = await interceptor(c);
+    }
+    return c;
+  }
+
+  private async runResponseInterceptors<Name extends CadenceFunctionName>(
+    config: CadenceConfig<Name>,
+    response: CadenceResponses[Name],
+  ): Promise<InterceptedResponse<Name>> {
+    let c = config;
+    let r = response;
+    for (const interceptor of this.responseInterceptors) {
+      const result = await interceptor(c, r);
+      c = result.config;
+      r = result.response;
+    }
+    return { config: c, response: r };
+  }
+
+`
+
+// responseType returns the resolved type of a generated function
+func responseType(function TypeScriptFunction) string {
+	switch {
+	case function.ReturnType != "":
+		return function.ReturnType
+	case function.Type == "transaction":
+		return "string"
+	default:
+		return "any"
+	}
+}
+
+// generateInterceptorTypes renders the config, response and interceptor types
+// of the generated functions, with the idempotency key of transactions if
+// idempotency is enabled
+func generateInterceptorTypes(functions []TypeScriptFunction, idempotency bool) (string, error) {
+	funcMap := template.FuncMap{
+		"responseType": responseType,
+	}
+	tmpl, err := template.New("interceptors").Funcs(funcMap).Parse(interceptorTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse interceptor template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Functions   []TypeScriptFunction
+		Idempotency bool
+	}{
+		Functions:   functions,
+		Idempotency: idempotency,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute interceptor template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,69 @@
+package typescript
+
+// loggingTypes declares the logger receiving generated calls
+const loggingTypes = `/** A query or mutation passed to CadenceLogger.onRequest */
+export interface CadenceLogRequest {
+  name: CadenceFunctionName;
+  type: "script" | "transaction";
+  config: CadenceConfig;
+}
+
+/** A finished query or mutation, with its duration in milliseconds */
+export interface CadenceLogResponse extends CadenceLogRequest {
+  duration: number;
+  response: unknown;
+}
+
+/** A failed query or mutation, with its duration in milliseconds */
+export interface CadenceLogError extends CadenceLogRequest {
+  duration: number;
+  error: unknown;
+}
+
+/** Receives generated calls, e.g. an adapter forwarding them to pino or Sentry */
+export interface CadenceLogger {
+  onRequest?(event: CadenceLogRequest): void;
+  onResponse?(event: CadenceLogResponse): void;
+  onError?(event: CadenceLogError): void;
+}
+
+`
+
+// loggingField declares the registered loggers of the service
+const loggingField = "  private loggers: CadenceLogger[] = [];\n"
+
+// loggingMethods registers loggers and reports fcl calls to them
+const loggingMethods = `  /** Registers a logger receiving every query and mutation after the request interceptors ran */
+  useLogger(logger: CadenceLogger) {
+    this.loggers.push(logger);
+  }
+
+  private async logged<T>(config: CadenceConfig, run: () => Promise<T>): Promise<T> {
+    const request: CadenceLogRequest = { name: config.name, type: config.type, config };
+    const log = (report: (logger: CadenceLogger) => void) => {
+      for (const logger of this.loggers) {
+        try {
+          report(logger);
+        } catch {
+          // Failing loggers must not fail the interaction
+        }
+      }
+    };
+    const start = Date.now();
+    log((logger) => logger.onRequest?.(request));
+    try {
+      const response = await run();
+      log((logger) => logger.onResponse?.({ ...request, duration: Date.now() - start, response }));
+      return response;
+    } catch (error) {
+      log((logger) => logger.onError?.({ ...request, duration: Date.now() - start, error }));
+      throw error;
+    }
+  }
+
+`
+
+// SetLogging enables the logger hooks of generated functions
+func (g *Generator) SetLogging(logging bool) {
+	g.Logging = logging
+}
@@ -0,0 +1,90 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// manifestEntry is a signed script or transaction of the manifest
+type manifestEntry struct {
+	Key  string // Manifest key, e.g. scripts/get_balance.cdc
+	Name string // Function of the cadence map holding its code
+}
+
+// manifestTemplate embeds the signed manifest of the report and a helper
+// verifying the embedded code against it
+const manifestTemplate = `/** Signed manifest of the embedded Cadence code, checked by verifyCadenceManifest */
+export const cadenceManifest = {
+  publicKey: {{json .PublicKey}},
+  signature: {{json .Signature}},
+  entries: [
+{{- range .Entries}}
+    [{{json .Key}}, {{json .Name}}],
+{{- end}}
+  ],
+} as const;
+
+/**
+ * Verifies that the embedded Cadence code is unchanged since the report was
+ * signed. Pass the base64 Ed25519 public key pinned by the app rather than
+ * cadenceManifest.publicKey, which ships in the same bundle as the code.
+ * Requires Ed25519 support in Web Crypto.
+ */
+export async function verifyCadenceManifest(publicKey: string): Promise<boolean> {
+  const encoder = new TextEncoder();
+  const fromBase64 = (value: string) => Uint8Array.from(atob(value), (char) => char.charCodeAt(0));
+  const toHex = (data: ArrayBuffer) => Array.from(new Uint8Array(data), (byte) => byte.toString(16).padStart(2, "0")).join("");
+  let payload = "";
+  for (const [key, name] of cadenceManifest.entries) {
+    const hash = await crypto.subtle.digest("SHA-256", encoder.encode(cadence[name].code.trim()));
+    payload += key + " " + toHex(hash) + "\n";
+  }
+  try {
+    const verifyKey = await crypto.subtle.importKey("raw", fromBase64(publicKey), { name: "Ed25519" }, false, ["verify"]);
+    return await crypto.subtle.verify({ name: "Ed25519" }, verifyKey, fromBase64(cadenceManifest.signature), encoder.encode(payload));
+  } catch {
+    return false;
+  }
+}
+
+`
+
+// generateManifest renders the signed manifest of the report and its
+// verification helper. It returns nothing if the report is not signed, and an
+// error if the manifest does not match the code of the report.
+func (g *Generator) generateManifest() (string, error) {
+	manifest := g.Report.Manifest
+	if manifest == nil {
+		return "", nil
+	}
+	if err := g.Report.VerifyManifest(); err != nil {
+		return "", fmt.Errorf("failed to verify report manifest: %w", err)
+	}
+
+	var entries []manifestEntry
+	for _, key := range manifest.Keys() {
+		_, fileName, _ := strings.Cut(key, "/")
+		entries = append(entries, manifestEntry{Key: key, Name: g.functionName(fileName)})
+	}
+
+	tmpl, err := template.New("manifest").Funcs(template.FuncMap{"json": jsonString}).Parse(manifestTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse manifest template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		PublicKey string
+		Signature string
+		Entries   []manifestEntry
+	}{
+		PublicKey: manifest.PublicKey,
+		Signature: manifest.Signature,
+		Entries:   entries,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute manifest template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,99 @@
+package typescript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// messagesTemplate exports the user-facing messages of the functions, e.g.
+// for wallets showing interaction titles
+const messagesTemplate = `/** User-facing messages of the functions by name, from // codegen:title and // codegen:description pragmas */
+export const cadenceMessages: Record<string, { title?: string; description?: string }> = {
+{{- range .Messages}}
+  {{.Name}}: {{.Messages}},
+{{- end}}
+};
+
+{{if .Locales -}}
+/** Messages of the functions translated into other locales, by locale and function name */
+export const cadenceTranslations: Record<string, Record<string, { title?: string; description?: string }>> = {
+{{- range .Locales}}
+  {{json .Locale}}: {
+  {{- range .Messages}}
+    {{.Name}}: {{.Messages}},
+  {{- end}}
+  },
+{{- end}}
+};
+
+{{end}}`
+
+// messagesEntry is the messages of a function as a JSON object
+type messagesEntry struct {
+	Name     string
+	Messages string
+}
+
+// localeEntries is the messages of the functions in a locale
+type localeEntries struct {
+	Locale   string
+	Messages []messagesEntry
+}
+
+// generateMessages renders the messages and translations of the functions
+// having any, or nothing if none has
+func generateMessages(functions []TypeScriptFunction) (string, error) {
+	var entries []messagesEntry
+	translated := make(map[string][]messagesEntry)
+	for _, function := range functions {
+		if len(function.Messages) > 0 {
+			// Map keys are marshalled sorted
+			data, err := json.Marshal(function.Messages)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal messages: %w", err)
+			}
+			entries = append(entries, messagesEntry{Name: function.Name, Messages: string(data)})
+		}
+		for locale, messages := range function.Translations {
+			data, err := json.Marshal(messages)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal messages: %w", err)
+			}
+			translated[locale] = append(translated[locale], messagesEntry{Name: function.Name, Messages: string(data)})
+		}
+	}
+	if len(entries) == 0 && len(translated) == 0 {
+		return "", nil
+	}
+	var locales []localeEntries
+	for locale, messages := range translated {
+		locales = append(locales, localeEntries{Locale: locale, Messages: messages})
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i].Locale < locales[j].Locale })
+
+	tmpl, err := template.New("messages").Funcs(template.FuncMap{"json": jsonString}).Parse(messagesTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse messages template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Messages []messagesEntry
+		Locales  []localeEntries
+	}{
+		Messages: entries,
+		Locales:  locales,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute messages template: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// commentText makes value safe to embed in a JSDoc comment
+func commentText(value string) string {
+	return strings.ReplaceAll(value, "*/", "* /")
+}
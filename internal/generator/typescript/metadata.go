@@ -0,0 +1,187 @@
+package typescript
+
+import "strings"
+
+// metadataView is a MetadataViews type, declared unless the report already
+// contains a struct of the same flattened name
+type metadataView struct {
+	Name string
+	Code string
+}
+
+// metadataViews are the MetadataViews types, in dependency order
+var metadataViews = []metadataView{
+	{"MetadataViewsHTTPFile", `/** MetadataViews.HTTPFile */
+export interface MetadataViewsHTTPFile {
+  url: string;
+}
+`},
+	{"MetadataViewsIPFSFile", `/** MetadataViews.IPFSFile, path is relative to the directory of cid */
+export interface MetadataViewsIPFSFile {
+  cid: string;
+  path?: string | null;
+}
+`},
+	{"MetadataViewsFile", `/** A MetadataViews.File implementation */
+export type MetadataViewsFile = MetadataViewsHTTPFile | MetadataViewsIPFSFile;
+`},
+	{"MetadataViewsDisplay", `/** MetadataViews.Display */
+export interface MetadataViewsDisplay {
+  name: string;
+  description: string;
+  thumbnail: MetadataViewsFile;
+}
+`},
+	{"MetadataViewsMedia", `/** MetadataViews.Media */
+export interface MetadataViewsMedia {
+  file: MetadataViewsFile;
+  mediaType: string;
+}
+`},
+	{"MetadataViewsMedias", `/** MetadataViews.Medias */
+export interface MetadataViewsMedias {
+  items: MetadataViewsMedia[];
+}
+`},
+	{"MetadataViewsRoyalty", `/** MetadataViews.Royalty, cut is a fraction of the sale price, e.g. "0.05" */
+export interface MetadataViewsRoyalty {
+  receiver: { address: FlowAddress; [field: string]: any };
+  cut: string;
+  description: string;
+}
+`},
+	{"MetadataViewsRoyalties", `/** MetadataViews.Royalties */
+export interface MetadataViewsRoyalties {
+  cutInfos: MetadataViewsRoyalty[];
+}
+`},
+	{"MetadataViewsEdition", `/** MetadataViews.Edition */
+export interface MetadataViewsEdition {
+  name?: string | null;
+  number: number;
+  max?: number | null;
+}
+`},
+	{"MetadataViewsEditions", `/** MetadataViews.Editions */
+export interface MetadataViewsEditions {
+  infoList: MetadataViewsEdition[];
+}
+`},
+	{"MetadataViewsSerial", `/** MetadataViews.Serial */
+export interface MetadataViewsSerial {
+  number: number;
+}
+`},
+	{"MetadataViewsExternalURL", `/** MetadataViews.ExternalURL */
+export interface MetadataViewsExternalURL {
+  url: string;
+}
+`},
+	{"MetadataViewsLicense", `/** MetadataViews.License, an SPDX license identifier */
+export interface MetadataViewsLicense {
+  spdxIdentifier: string;
+}
+`},
+	{"MetadataViewsRarity", `/** MetadataViews.Rarity */
+export interface MetadataViewsRarity {
+  score?: string | null;
+  max?: string | null;
+  description?: string | null;
+}
+`},
+	{"MetadataViewsTrait", `/** MetadataViews.Trait */
+export interface MetadataViewsTrait {
+  name: string;
+  value: any;
+  displayType?: string | null;
+  rarity?: MetadataViewsRarity | null;
+}
+`},
+	{"MetadataViewsTraits", `/** MetadataViews.Traits */
+export interface MetadataViewsTraits {
+  traits: MetadataViewsTrait[];
+}
+`},
+	{"MetadataViewsNFTCollectionDisplay", `/** MetadataViews.NFTCollectionDisplay */
+export interface MetadataViewsNFTCollectionDisplay {
+  name: string;
+  description: string;
+  externalURL: MetadataViewsExternalURL;
+  squareImage: MetadataViewsMedia;
+  bannerImage: MetadataViewsMedia;
+  socials: Record<string, MetadataViewsExternalURL>;
+}
+`},
+}
+
+// metadataResolvers resolves typed views from the dictionaries of views
+// returned by scripts
+const metadataResolvers = `/** The MetadataViews views by name */
+export interface MetadataViewsByName {
+  Display: MetadataViewsDisplay;
+  Editions: MetadataViewsEditions;
+  ExternalURL: MetadataViewsExternalURL;
+  License: MetadataViewsLicense;
+  Medias: MetadataViewsMedias;
+  NFTCollectionDisplay: MetadataViewsNFTCollectionDisplay;
+  Royalties: MetadataViewsRoyalties;
+  Serial: MetadataViewsSerial;
+  Traits: MetadataViewsTraits;
+}
+
+/**
+ * Returns a view of a dictionary of views keyed by view name or type identifier,
+ * e.g. A.1d7e57aa55817448.MetadataViews.Display, or undefined if it is missing
+ */
+export function resolveMetadataView<V extends keyof MetadataViewsByName>(
+  views: Record<string, any> | null | undefined,
+  view: V
+): MetadataViewsByName[V] | undefined {
+  if (!views) {
+    return undefined;
+  }
+  const key = Object.keys(views).find((identifier) => identifier === view || identifier.endsWith(".MetadataViews." + view));
+  return key === undefined ? undefined : views[key] ?? undefined;
+}
+
+/** Returns the URL of a file, resolving IPFS files through gateway */
+export function metadataFileURL(file: MetadataViewsFile, gateway = "https://ipfs.io/ipfs/"): string {
+  if ("url" in file) {
+    return file.url;
+  }
+  return gateway + file.cid + (file.path ? "/" + file.path : "");
+}
+
+/** Returns the sum of the royalty cuts as a percentage of the sale price */
+export function royaltyPercentage(royalties: MetadataViewsRoyalties): number {
+  return royalties.cutInfos.reduce((sum, royalty) => sum + Number(royalty.cut), 0) * 100;
+}
+
+`
+
+// metadataViewTypes declares the MetadataViews types if a script imports
+// MetadataViews. Types the report already declares are left out.
+func (g *Generator) metadataViewTypes() string {
+	if !g.Report.ScriptsImport("MetadataViews") {
+		return ""
+	}
+	var b strings.Builder
+	for _, view := range metadataViews {
+		if _, ok := g.Report.Structs[view.Name]; ok {
+			continue
+		}
+		b.WriteString(view.Code)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// generateMetadataViews declares the MetadataViews types and their resolver
+// helpers if a script imports MetadataViews
+func (g *Generator) generateMetadataViews() string {
+	types := g.metadataViewTypes()
+	if types == "" {
+		return ""
+	}
+	return types + metadataResolvers
+}
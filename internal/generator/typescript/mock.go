@@ -0,0 +1,164 @@
+package typescript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/outblock/cadence-codegen/internal/fixtures"
+)
+
+const mockTemplate = `import { CadenceService } from "{{.ImportPath}}";
+{{- if .TypeImports}}
+import type { {{join .TypeImports ", "}} } from "{{.ImportPath}}";
+{{- end}}
+import { fixtures } from "./fixtures";
+
+/** Transaction ID returned by mocked transactions */
+export const MOCK_TRANSACTION_ID = "{{.TransactionID}}";
+
+/** Mock CadenceService returning canned responses from the fixtures folder */
+export class MockCadenceService extends CadenceService {
+  private overrides: Record<string, any> = {};
+
+  constructor(private latency: number = 0) {
+    super();
+  }
+
+  /** Override the canned response of a function */
+  setFixture(name: string, response: any) {
+    this.overrides[name] = response;
+  }
+
+  private async respond(name: string, response: any): Promise<any> {
+    if (this.latency > 0) {
+      await new Promise((resolve) => setTimeout(resolve, this.latency));
+    }
+    const value = name in this.overrides ? this.overrides[name] : response;
+    return value === undefined ? value : JSON.parse(JSON.stringify(value));
+  }
+{{range .Functions}}
+  public async {{.Name}}({{range $index, $param := .Parameters}}{{if $index}}, {{end}}_{{$param.Name}}{{if $param.Optional}}?{{end}}: {{$param.Type}}{{end}}){{if .ReturnType}}: Promise<{{.ReturnType}}>{{else if eq .Type "transaction"}}: Promise<string>{{end}} {
+    {{- if eq .Type "query"}}
+    return this.respond("{{.Name}}", fixtures.{{.Name}});
+    {{- else}}
+    return this.respond("{{.Name}}", MOCK_TRANSACTION_ID);
+    {{- end}}
+  }
+{{end}}}
+`
+
+const fixturesIndexTemplate = `/** Canned script responses used by MockCadenceService, edit the JSON files to change them */
+{{- range .}}
+import {{.}} from "./{{.}}.json";
+{{- end}}
+
+export const fixtures = {
+{{- range .}}
+  {{.}},
+{{- end}}
+};
+`
+
+// GenerateMock generates a mock implementation of the CadenceService that
+// returns canned responses. importPath is the module path of the generated
+// service relative to the mock module. It returns the mock module and the
+// fixtures folder contents keyed by file name.
+func (g *Generator) GenerateMock(importPath string) (string, map[string]string, error) {
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+	for _, tag := range tagNames {
+		functions = append(functions, taggedFunctions[tag]...)
+	}
+
+	sampler := fixtures.New(g.Report)
+	files := make(map[string]string)
+	var scriptNames []string
+	for _, function := range functions {
+		if function.Type != "query" {
+			continue
+		}
+		var response interface{}
+		if function.CadenceReturnType != "" {
+			response = sampler.Sample(function.CadenceReturnType)
+		}
+		if function.Union != nil {
+			kind := function.Union.Members[0].Kind
+			response = map[string]interface{}{"kind": kind, "value": sampler.Sample(kind)}
+		}
+		data, err := json.MarshalIndent(response, "", "  ")
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal fixture: %w", err)
+		}
+		files[function.Name+".json"] = string(data) + "\n"
+		scriptNames = append(scriptNames, function.Name)
+	}
+
+	indexTmpl, err := template.New("fixtures").Parse(fixturesIndexTemplate)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse fixtures template: %w", err)
+	}
+	var index bytes.Buffer
+	if err := indexTmpl.Execute(&index, scriptNames); err != nil {
+		return "", nil, fmt.Errorf("failed to execute fixtures template: %w", err)
+	}
+	files["index.ts"] = index.String()
+
+	mockTmpl, err := template.New("mock").Funcs(template.FuncMap{"join": strings.Join}).Parse(mockTemplate)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse mock template: %w", err)
+	}
+	var mock bytes.Buffer
+	err = mockTmpl.Execute(&mock, struct {
+		ImportPath    string
+		TypeImports   []string
+		TransactionID string
+		Functions     []TypeScriptFunction
+	}{
+		ImportPath:    importPath,
+		TypeImports:   g.referencedInterfaces(functions),
+		TransactionID: fixtures.SampleTransactionID,
+		Functions:     functions,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to execute mock template: %w", err)
+	}
+
+	return mock.String(), files, nil
+}
+
+// referencedInterfaces returns the sorted names of the generated struct
+// interfaces, FlowAddress and result unions used in the signatures of the
+// given functions
+func (g *Generator) referencedInterfaces(functions []TypeScriptFunction) []string {
+	var signatures []string
+	var unions []string
+	for _, function := range functions {
+		if function.Union != nil {
+			unions = append(unions, function.Union.Name)
+		} else {
+			signatures = append(signatures, function.ReturnType)
+		}
+		for _, param := range function.Parameters {
+			signatures = append(signatures, param.Type)
+		}
+	}
+	joined := strings.Join(signatures, " ")
+
+	var names []string
+	for _, composite := range g.Report.Structs {
+		name := flattenStructName(composite.Name)
+		if regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`).MatchString(joined) {
+			names = append(names, name)
+		}
+	}
+	if regexp.MustCompile(`\bFlowAddress\b`).MatchString(joined) {
+		names = append(names, "FlowAddress")
+	}
+	names = append(names, unions...)
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,103 @@
+package typescript
+
+import "strings"
+
+// nodeImport imports the signing primitives of Node.js
+const nodeImport = "import { createECDH, createPrivateKey, sign } from \"node:crypto\";\n"
+
+// nodeTypes declares server-side signers and the authorization functions
+// wrapping them
+const nodeTypes = `/** Signs transactions on a server, e.g. with a private key or a KMS */
+export interface ServerSigner {
+  address: string;
+  keyIndex: number;
+  /** Signs the hex encoded message, returning the hex encoded signature */
+  signMessage(message: string): Promise<string>;
+}
+
+/** Creates an fcl authorization function signing with signer instead of fcl.currentUser */
+export function serverAuthorization(signer: ServerSigner): AuthorizationFunction {
+  return async (account) => ({
+    ...account,
+    tempId: signer.address + "-" + signer.keyIndex,
+    addr: fcl.sansPrefix(signer.address),
+    keyId: signer.keyIndex,
+    signingFunction: async (signable) => ({
+      addr: fcl.withPrefix(signer.address),
+      keyId: signer.keyIndex,
+      signature: await signer.signMessage(signable.message),
+    }),
+  });
+}
+
+/** Signature and hash algorithms of a Flow account key */
+export interface PrivateKeyOptions {
+  /** Defaults to ECDSA_P256 */
+  signatureAlgorithm?: "ECDSA_P256" | "ECDSA_secp256k1";
+  /** Defaults to SHA3_256 */
+  hashAlgorithm?: "SHA3_256" | "SHA2_256";
+}
+
+/** Creates a ServerSigner from a hex encoded private key, signing with node:crypto */
+export function privateKeySigner(address: string, keyIndex: number, privateKey: string, options: PrivateKeyOptions = {}): ServerSigner {
+  const secp256k1 = options.signatureAlgorithm === "ECDSA_secp256k1";
+  const d = Buffer.from(privateKey.replace(/^0x/, "").padStart(64, "0"), "hex");
+  const ecdh = createECDH(secp256k1 ? "secp256k1" : "prime256v1");
+  ecdh.setPrivateKey(d);
+  const publicKey = ecdh.getPublicKey();
+  const key = createPrivateKey({
+    key: {
+      kty: "EC",
+      crv: secp256k1 ? "secp256k1" : "P-256",
+      d: d.toString("base64url"),
+      x: publicKey.subarray(1, 33).toString("base64url"),
+      y: publicKey.subarray(33).toString("base64url"),
+    },
+    format: "jwk",
+  });
+  const hash = options.hashAlgorithm === "SHA2_256" ? "sha256" : "sha3-256";
+  return {
+    address,
+    keyIndex,
+    async signMessage(message) {
+      return sign(hash, Buffer.from(message, "hex"), { key, dsaEncoding: "ieee-p1363" }).toString("hex");
+    },
+  };
+}
+
+`
+
+// nodeField declares the authorization of the registered server signer
+const nodeField = "  private authorization?: AuthorizationFunction;\n"
+
+// nodeMethods registers the server signer proposing, paying for and
+// authorizing transactions by default
+const nodeMethods = `  /** Registers the signer proposing, paying for and authorizing transactions unless overridden per call */
+  useSigner(signer: ServerSigner) {
+    this.authorization = serverAuthorization(signer);
+  }
+
+  private serverAuthorization(): AuthorizationFunction {
+    if (!this.authorization) {
+      throw new Error("No server signer registered, see useSigner");
+    }
+    return this.authorization;
+  }
+
+`
+
+// serverAuthorizations returns the authorizations of a transaction from the
+// signers option, defaulting to the registered server signer
+func serverAuthorizations(labels []string) string {
+	authorizations := make([]string, len(labels))
+	for i, label := range labels {
+		authorizations[i] = "options?.signers?." + label + " ?? this.serverAuthorization()"
+	}
+	return strings.Join(authorizations, ", ")
+}
+
+// SetNode enables the Node.js flavor for backends, signing transactions with
+// a registered server signer instead of fcl.currentUser
+func (g *Generator) SetNode(node bool) {
+	g.Node = node
+}
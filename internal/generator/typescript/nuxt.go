@@ -0,0 +1,129 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const nuxtTemplate = `/** Generated Nuxt 3 composables for the Cadence service, auto-imported from composables/ */
+import * as fcl from "@onflow/fcl";
+import { ref, toValue, type MaybeRefOrGetter } from "vue";
+import { useAsyncData, useRuntimeConfig } from "#app";
+import { CadenceService } from "{{.ImportPath}}";
+
+const accessNodes: Record<string, string> = {
+  mainnet: "https://rest-mainnet.onflow.org",
+  testnet: "https://rest-testnet.onflow.org",
+  emulator: "http://127.0.0.1:8888",
+};
+
+let service: CadenceService | undefined;
+
+/**
+ * Returns the shared CadenceService. fcl is configured on first use, on the server
+ * and in the browser, from runtimeConfig.public.flow ({ network, accessNode }).
+ */
+export function useCadenceService(): CadenceService {
+  if (!service) {
+    const flow = (useRuntimeConfig().public.flow ?? {}) as { network?: string; accessNode?: string };
+    const network = flow.network ?? "mainnet";
+    fcl.config({
+      "flow.network": network,
+      "accessNode.api": flow.accessNode ?? accessNodes[network] ?? accessNodes.mainnet,
+    });
+    service = new CadenceService();
+  }
+  return service;
+}
+
+/** Tracks the id, pending state and error of a transaction sent in the browser */
+function useTransaction<Args extends unknown[]>(name: string, send: (...args: Args) => Promise<string>) {
+  const txId = ref<string | null>(null);
+  const pending = ref(false);
+  const error = ref<unknown>(null);
+
+  async function execute(...args: Args): Promise<string> {
+    if (import.meta.server) {
+      throw new Error(` + "`" + `${name} can only be sent in the browser` + "`" + `);
+    }
+    pending.value = true;
+    error.value = null;
+    try {
+      txId.value = await send(...args);
+      return txId.value;
+    } catch (e) {
+      error.value = e;
+      throw e;
+    } finally {
+      pending.value = false;
+    }
+  }
+
+  return { execute, txId, pending, error };
+}
+{{range $func := .Functions}}
+{{- if eq .Type "query"}}
+/** Runs the {{.Name}} script with useAsyncData, refetching when the arguments change */
+export function {{composable .Name}}({{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{if $param.Optional}}?{{end}}: MaybeRefOrGetter<Parameters<CadenceService["{{$func.Name}}"]>[{{$index}}]>{{end}}) {
+  const cadence = useCadenceService();
+  return useAsyncData(
+    ` + "`" + `cadence:{{.Name}}:${JSON.stringify([{{range $index, $param := .Parameters}}{{if $index}}, {{end}}toValue({{$param.Name}}){{end}}])}` + "`" + `,
+    () => cadence.{{.Name}}({{range $index, $param := .Parameters}}{{if $index}}, {{end}}toValue({{$param.Name}}){{end}}),
+    {{- if .Parameters}}
+    { watch: [{{range $index, $param := .Parameters}}{{if $index}}, {{end}}() => toValue({{$param.Name}}){{end}}] },
+    {{- end}}
+  );
+}
+{{- else}}
+/** Sends the {{.Name}} transaction, returning execute with its txId, pending and error refs */
+export function {{composable .Name}}() {
+  const cadence = useCadenceService();
+  return useTransaction("{{.Name}}", (...args: Parameters<CadenceService["{{.Name}}"]>) => cadence.{{.Name}}(...args));
+}
+{{- end}}
+{{end}}`
+
+// pascalCase upper cases the first letter of a generated function name
+func pascalCase(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// nuxtComposableName returns the composable of a generated function, e.g. useFlowGetBalance
+func nuxtComposableName(name string) string {
+	return "useFlow" + pascalCase(name)
+}
+
+// GenerateNuxt generates Nuxt 3 composables: useAsyncData based composables
+// per script and transaction senders per transaction. importPath is the module
+// path of the generated service relative to the composables module.
+func (g *Generator) GenerateNuxt(importPath string) (string, error) {
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+	for _, tag := range tagNames {
+		functions = append(functions, taggedFunctions[tag]...)
+	}
+
+	funcMap := template.FuncMap{
+		"composable": nuxtComposableName,
+	}
+	tmpl, err := template.New("nuxt").Funcs(funcMap).Parse(nuxtTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Nuxt template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		ImportPath string
+		Functions  []TypeScriptFunction
+	}{
+		ImportPath: importPath,
+		Functions:  functions,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute Nuxt template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,102 @@
+package typescript
+
+// offlineImport imports the RLP encoders of transaction messages
+const offlineImport = "import { encodeTransactionEnvelope, encodeTransactionPayload } from \"@onflow/sdk\";\n"
+
+// offlineTypes declares unsigned transactions exported for offline signing
+const offlineTypes = `/** Accounts signing an exported transaction */
+export interface PayloadSigners {
+  proposer: { address: FlowAddress; keyId: number };
+  payer: FlowAddress;
+  authorizers: FlowAddress[];
+}
+
+/** A signature collected for an exported transaction */
+export interface VoucherSignature {
+  address: FlowAddress;
+  keyId: number;
+  sig: string;
+}
+
+/** Unsigned transaction in the JSON voucher format of fcl */
+export interface TransactionVoucher {
+  cadence: string;
+  refBlock: string;
+  computeLimit: number;
+  arguments: unknown[];
+  proposalKey: { address: FlowAddress; keyId: number; sequenceNum: number };
+  payer: FlowAddress;
+  authorizers: FlowAddress[];
+  payloadSigs: VoucherSignature[];
+  envelopeSigs: VoucherSignature[];
+}
+
+/** An exported transaction with the RLP encoded message signed by the proposer and authorizers */
+export interface UnsignedTransaction {
+  voucher: TransactionVoucher;
+  payloadMessage: string;
+}
+
+`
+
+// offlineMethods builds vouchers from intercepted configs, with the import
+// aliases resolved with the fcl configuration and, if addresses are exported,
+// the addresses of the configured network
+func offlineMethods(addresses bool) string {
+	networkAliases := ""
+	if addresses {
+		networkAliases = "      ...(addresses[await fcl.config().get(\"flow.network\")] ?? {}),\n"
+	}
+	return `  /**
+   * Returns the RLP encoded message signed by the payer, once the payload
+   * signatures are added to the voucher
+   */
+  envelopeMessage(voucher: TransactionVoucher): string {
+    return encodeTransactionEnvelope(voucher);
+  }
+
+  private async buildPayload<Name extends CadenceFunctionName>(config: CadenceConfig<Name>, signers: PayloadSigners): Promise<UnsignedTransaction> {
+    const c = await this.runRequestInterceptors(config);
+    const [block, proposer] = await Promise.all([fcl.block(), fcl.account(signers.proposer.address)]);
+    const key = proposer.keys.find((k) => k.index === signers.proposer.keyId);
+    if (!key) {
+      throw new Error(` + "`" + `key ${signers.proposer.keyId} not found on ${signers.proposer.address}` + "`" + `);
+    }
+    const voucher: TransactionVoucher = {
+      cadence: await this.resolveImports(c.cadence),
+      refBlock: block.id,
+      computeLimit: c.limit,
+      arguments: c.args((value: unknown, type: any) => type.asArgument(value), fcl.t),
+      proposalKey: { ...signers.proposer, sequenceNum: key.sequenceNumber },
+      payer: signers.payer,
+      authorizers: signers.authorizers,
+      payloadSigs: [],
+      envelopeSigs: [],
+    };
+    return { voucher, payloadMessage: encodeTransactionPayload(voucher) };
+  }
+
+  /**
+   * Replaces the import aliases, e.g. 0xFungibleToken, and the imports by
+   * contract name of code with contract addresses, as fcl.mutate does, so
+   * the signed payload holds the code sent to the network
+   */
+  private async resolveImports(code: string): Promise<string> {
+    const aliases: Record<string, string> = {
+` + networkAliases + `      ...(await fcl.config().where(/^0x/)),
+    };
+    const { code: resolved, missing } = replaceImportAliases(code, aliases);
+    if (missing.length > 0) {
+      throw new Error("no contract address configured for " + missing.join(", "));
+    }
+    return resolved;
+  }
+
+`
+}
+
+// SetOfflineSigning enables functions exporting unsigned transactions for
+// hardware wallets and multi-party signing
+func (g *Generator) SetOfflineSigning(offlineSigning bool) {
+	g.OfflineSigning = offlineSigning
+}
@@ -0,0 +1,122 @@
+package typescript
+
+// optionsTypes declares the per-call overrides of the generated functions and
+// the access nodes of their networks, with the idempotency key of mutations if
+// idempotency is enabled
+func optionsTypes(idempotency bool, accessNodes map[string][]string) string {
+	idempotencyKey := ""
+	if idempotency {
+		idempotencyKey = "  /** Identifies one logical submission across retries, see useIdempotency */\n  idempotencyKey?: string;\n"
+	}
+	return `export type FlowNetwork = "mainnet" | "testnet" | "emulator";
+
+` + accessNodesDeclaration(accessNodes) + `
+/** Per-call overrides of the fcl query of a generated script */
+export interface QueryOptions {
+  /** Compute limit, defaults to 9999 */
+  limit?: number;
+  /** Network to execute on instead of the configured one */
+  network?: FlowNetwork;
+}
+
+/**
+ * Per-call overrides of the fcl mutation of a generated transaction. fcl signs and
+ * sends transactions with its global configuration, so they have no network option.
+ */
+export interface MutationOptions extends Omit<QueryOptions, "network"> {
+  payer?: AuthorizationFunction;
+  proposer?: AuthorizationFunction;
+  authorizations?: AuthorizationFunction[];
+` + idempotencyKey + `}
+
+`
+}
+
+// networkMethod runs scripts on the access node of the network of the
+// options, passing it to the call instead of the fcl configuration so that
+// concurrent calls on other networks do not interfere
+const networkMethod = `  /**
+   * Runs a script on the configured network, or on the given network with its access
+   * node passed to the call only, so concurrent calls, e.g. of batch, do not interfere.
+   */
+  private async onNetwork<T>(network: FlowNetwork | undefined, run: (target?: AccessNodeTarget) => Promise<T>): Promise<T> {
+    return run(network ? { network, node: accessNodes[network] } : undefined);
+  }
+
+`
+
+// networkTypes declares the access node of a script call and the replacement
+// of import aliases
+const networkTypes = `/** The network and access node a single script call is sent to */
+interface AccessNodeTarget {
+  network: FlowNetwork;
+  node: string;
+}
+
+/**
+ * Replaces the import aliases, e.g. 0xFungibleToken, and the imports by contract
+ * name of code with the addresses of aliases, returning the imports left unresolved
+ */
+function replaceImportAliases(code: string, aliases: Record<string, string>): { code: string; missing: string[] } {
+  const missing: string[] = [];
+  const resolved = code
+    .replace(/(\bfrom\s+)(0x\w+)/g, (match: string, from: string, alias: string) => {
+      if (aliases[alias]) return from + aliases[alias];
+      if (!/^0x[0-9a-fA-F]{1,16}$/.test(alias)) missing.push(alias);
+      return match;
+    })
+    .replace(/\bimport\s+"(\w+)"/g, (match: string, name: string) => {
+      if (aliases["0x" + name]) return "import " + name + " from " + aliases["0x" + name];
+      missing.push(name);
+      return match;
+    });
+  return { code: resolved, missing };
+}
+
+`
+
+// sendScriptMethod runs scripts with fcl.query, or on the access node of a
+// call with fcl.send. The contract import aliases of the network of the call
+// are replaced as well if the addresses export is generated.
+func sendScriptMethod(addresses bool) string {
+	comment, code := "", "config.cadence"
+	if addresses {
+		comment = " with the contract addresses of its network"
+		code = `replaceImportAliases(config.cadence, {
+      ...(await fcl.config().where(/^0x/)),
+      ...(addresses[target.network] ?? {}),
+    }).code`
+	}
+	return `  /**
+   * Runs a script with fcl.query, or on the access node of target` + comment + `.
+   * fcl resolves the remaining import aliases with its configuration.
+   */
+  private async sendScript<Name extends CadenceFunctionName>(config: CadenceConfig<Name>, target?: AccessNodeTarget): Promise<any> {
+    if (!target) {
+      return fcl.query(config);
+    }
+    const code = ` + code + `;
+    const response = await fcl.send([fcl.script(code), fcl.args(config.args(fcl.arg, fcl.t)), fcl.limit(config.limit)], {
+      node: target.node,
+    });
+    return fcl.decode(response);
+  }
+
+`
+}
+
+// rejectNetworkMethod fails mutations given a network option, e.g. from
+// JavaScript callers. fcl signs and sends transactions with its global
+// configuration, so a mutation cannot target another network for one call
+// without affecting the calls running meanwhile.
+const rejectNetworkMethod = `  /**
+   * Throws if a transaction is given a network option. fcl signs and sends transactions
+   * with its global configuration, configure fcl for the network instead.
+   */
+  private rejectNetwork(name: string, options?: object): void {
+    if (options && "network" in options && options.network !== undefined) {
+      throw new Error(` + "`" + `${name}: transactions are sent to the network fcl is configured for, the network option is only supported by scripts` + "`" + `);
+    }
+  }
+
+`
@@ -0,0 +1,32 @@
+package typescript
+
+import "strings"
+
+// pageParameters returns the parameters of a paginated function without its
+// offset and limit
+func pageParameters(function TypeScriptFunction) []TypeScriptParameter {
+	var parameters []TypeScriptParameter
+	for _, param := range function.Parameters {
+		if param.Name != function.Pagination.Offset && param.Name != function.Pagination.Limit {
+			parameters = append(parameters, param)
+		}
+	}
+	return parameters
+}
+
+// pageArguments returns the arguments calling a paginated function with the
+// offset and pageSize variables of its fetchAll helper
+func pageArguments(function TypeScriptFunction) string {
+	var arguments []string
+	for _, param := range function.Parameters {
+		switch param.Name {
+		case function.Pagination.Offset:
+			arguments = append(arguments, "offset")
+		case function.Pagination.Limit:
+			arguments = append(arguments, "pageSize")
+		default:
+			arguments = append(arguments, param.Name)
+		}
+	}
+	return strings.Join(arguments, ", ")
+}
@@ -0,0 +1,72 @@
+package typescript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// pathHelpers declares the path type and converts paths into arguments
+const pathHelpers = `/** A storage or public path, e.g. /public/flowTokenBalance */
+export type CadencePath = ` + "`/${\"storage\" | \"public\"}/${string}`" + `;
+
+/** Converts a path into the { domain, identifier } value of Path arguments */
+export function pathArgument(path: CadencePath): { domain: "storage" | "public"; identifier: string } {
+  const [, domain, identifier] = path.split("/");
+  return { domain: domain as "storage" | "public", identifier };
+}
+
+`
+
+// pathDomains are the domains of the path constants and their labels
+var pathDomains = []struct {
+	domain string
+	label  string
+}{
+	{"storage", "Storage"},
+	{"public", "Public"},
+}
+
+// generatePaths declares the storage and public paths used by the scripts
+// and transactions of the report as constants, skipping domains without
+// paths. It returns nothing if no paths are used.
+func (g *Generator) generatePaths() string {
+	domains := map[string]map[string]bool{"storage": {}, "public": {}}
+	found := false
+	for _, results := range []map[string]analyzer.AnalysisResult{g.Report.Scripts, g.Report.Transactions} {
+		for _, result := range results {
+			for _, path := range result.Paths {
+				if identifiers, ok := domains[path.Domain]; ok {
+					identifiers[path.Identifier] = true
+					found = true
+				}
+			}
+		}
+	}
+	if !found {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, d := range pathDomains {
+		if len(domains[d.domain]) == 0 {
+			continue
+		}
+		var identifiers []string
+		for identifier := range domains[d.domain] {
+			identifiers = append(identifiers, identifier)
+		}
+		sort.Strings(identifiers)
+
+		fmt.Fprintf(&b, "/** %s paths used by the generated functions */\n", d.label)
+		fmt.Fprintf(&b, "export const %sPaths = {\n", d.label)
+		for _, identifier := range identifiers {
+			fmt.Fprintf(&b, "  %s: \"/%s/%s\",\n", identifier, d.domain, identifier)
+		}
+		b.WriteString("} as const;\n\n")
+	}
+	b.WriteString(pathHelpers)
+	return b.String()
+}
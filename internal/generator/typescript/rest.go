@@ -0,0 +1,193 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Supported frameworks for generated REST servers
+const (
+	RESTFrameworkExpress = "express"
+	RESTFrameworkFastify = "fastify"
+)
+
+const restTemplate = `/** Generated {{.Framework}} server exposing the Cadence service over REST */
+{{- if eq .Framework "fastify"}}
+import Fastify, { FastifyInstance } from "fastify";
+{{- else}}
+import express, { Request, Response, Router } from "express";
+{{- end}}
+{{.Library.Import}}
+import { CadenceService } from "{{.ImportPath}}";
+{{- if .Library.Helpers}}
+
+{{.Library.Helpers}}
+{{- end}}
+{{- if .Library.SafeParse}}
+
+{{.Library.SafeParse}}
+{{- end}}
+
+/** Parses the JSON encoded query string values of non-string parameters */
+function parseQuery(query: Record<string, any>, json: string[]) {
+  const values: Record<string, any> = { ...query };
+  for (const key of json) {
+    if (typeof values[key] !== "string") continue;
+    try {
+      values[key] = JSON.parse(values[key]);
+    } catch {
+      // Left as is for the input schema to reject
+    }
+  }
+  return values;
+}
+
+/** A script (GET) or transaction (POST) endpoint */
+interface Endpoint {
+  method: "GET" | "POST";
+  path: string;
+  input: {{.Library.SchemaType}};
+  /** Parameters passed as JSON in the query string */
+  json: string[];
+  execute: (service: CadenceService, input: any) => Promise<any>;
+}
+
+/** Endpoints of the generated functions */
+export const endpoints: Record<string, Endpoint> = {
+{{- range .Functions}}
+  {{.Name}}: {
+    method: "{{if eq .Type "query"}}GET{{else}}POST{{end}}",
+    path: "/{{if eq .Type "query"}}scripts{{else}}transactions{{end}}/{{.Name}}",
+    json: [{{range $index, $param := nonStrings .Parameters}}{{if $index}}, {{end}}"{{$param.Name}}"{{end}}],
+    input: {{$.Library.Object}}({
+      {{- range .Parameters}}
+      {{.Name}}: {{schema .TypeStr}},
+      {{- end}}
+    }),
+    execute: (service, input) => service.{{.Name}}({{range $index, $param := .Parameters}}{{if $index}}, {{end}}input.{{$param.Name}}{{end}}),
+  },
+{{- end}}
+};
+
+/** Validates the request of an endpoint and executes it, returning the HTTP status and body */
+async function handle(endpoint: Endpoint, service: CadenceService, raw: any): Promise<[number, any]> {
+  const value = endpoint.method === "GET" ? parseQuery(raw ?? {}, endpoint.json) : raw ?? {};
+  const parsed = {{if .Library.SafeParse}}safeParse(endpoint.input, value){{else}}endpoint.input.safeParse(value){{end}};
+  if (!parsed.success) {
+    return [400, { error: "invalid request", issues: parsed.error.issues }];
+  }
+  try {
+    const result = await endpoint.execute(service, parsed.data);
+    return [200, endpoint.method === "GET" ? { result } : { transactionId: result }];
+  } catch (error: any) {
+    return [500, { error: error?.message ?? String(error) }];
+  }
+}
+{{if eq .Framework "fastify"}}
+/** Registers the endpoints on a Fastify instance */
+export function registerCadenceRoutes(app: FastifyInstance, service: CadenceService = new CadenceService()) {
+  for (const endpoint of Object.values(endpoints)) {
+    app.route({
+      method: endpoint.method,
+      url: endpoint.path,
+      handler: async (request, reply) => {
+        const [status, body] = await handle(endpoint, service, endpoint.method === "GET" ? request.query : request.body);
+        return reply.status(status).send(body);
+      },
+    });
+  }
+}
+
+/** Creates a Fastify server exposing the endpoints */
+export function createCadenceServer(service: CadenceService = new CadenceService()) {
+  const app = Fastify();
+  registerCadenceRoutes(app, service);
+  return app;
+}
+{{- else}}
+/** Creates an Express router exposing the endpoints */
+export function createCadenceRouter(service: CadenceService = new CadenceService()): Router {
+  const router = Router();
+  router.use(express.json());
+  for (const endpoint of Object.values(endpoints)) {
+    const handler = async (request: Request, response: Response) => {
+      const [status, body] = await handle(endpoint, service, endpoint.method === "GET" ? request.query : request.body);
+      response.status(status).json(body);
+    };
+    if (endpoint.method === "GET") {
+      router.get(endpoint.path, handler);
+    } else {
+      router.post(endpoint.path, handler);
+    }
+  }
+  return router;
+}
+
+/** Creates an Express server exposing the endpoints */
+export function createCadenceServer(service: CadenceService = new CadenceService()) {
+  const app = express();
+  app.use(createCadenceRouter(service));
+  return app;
+}
+{{- end}}
+`
+
+// nonStringParameters returns the parameters that are not strings in TypeScript
+func nonStringParameters(parameters []TypeScriptParameter) []TypeScriptParameter {
+	var result []TypeScriptParameter
+	for _, param := range parameters {
+		tsType := strings.TrimSpace(strings.TrimSuffix(param.Type, "| undefined"))
+		if tsType != "string" && tsType != "FlowAddress" {
+			result = append(result, param)
+		}
+	}
+	return result
+}
+
+// GenerateREST generates a Node server exposing every script as a GET and
+// every transaction as a POST endpoint, validating requests with the library
+// set with SetValidators.
+// importPath is the module path of the generated service relative to the
+// server module.
+func (g *Generator) GenerateREST(framework string, importPath string) (string, error) {
+	if framework != RESTFrameworkExpress && framework != RESTFrameworkFastify {
+		return "", fmt.Errorf("unsupported REST framework: %s", framework)
+	}
+
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+	for _, tag := range tagNames {
+		functions = append(functions, taggedFunctions[tag]...)
+	}
+
+	library, err := g.validators()
+	if err != nil {
+		return "", err
+	}
+
+	funcMap := template.FuncMap{
+		"schema":     library.schema,
+		"nonStrings": nonStringParameters,
+	}
+	tmpl, err := template.New("rest").Funcs(funcMap).Parse(restTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse REST template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Framework  string
+		ImportPath string
+		Library    *validatorLibrary
+		Functions  []TypeScriptFunction
+	}{
+		Framework:  framework,
+		ImportPath: importPath,
+		Library:    library,
+		Functions:  functions,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute REST template: %w", err)
+	}
+	return buffer.String(), nil
+}
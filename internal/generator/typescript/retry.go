@@ -0,0 +1,61 @@
+package typescript
+
+// retryTypes declares the retry policy of generated functions
+const retryTypes = `/** Retry behavior of generated functions, see useRetryPolicy */
+export interface RetryPolicy {
+  /** Attempts including the first one, defaults to 3 */
+  maxAttempts?: number;
+  /** Delay in milliseconds before the given retry, starting at 1, defaults to exponential backoff from 500ms */
+  backoff?: (attempt: number) => number;
+  /** Whether a failed call is retried, defaults to isRetryableError */
+  retryable?: (error: unknown, config: CadenceConfig) => boolean;
+}
+
+/**
+ * Retries network failures, timeouts, rate limits and server errors of the
+ * access node for scripts. Transactions are not retried, as they may have been
+ * submitted before failing.
+ */
+export function isRetryableError(error: unknown, config: CadenceConfig): boolean {
+  if (config.type !== "script") {
+    return false;
+  }
+  const message = String((error as { message?: unknown })?.message ?? error);
+  return /fetch failed|network|timed? ?out|ECONNRESET|ECONNREFUSED|\b(429|50[0-4])\b/i.test(message);
+}
+
+`
+
+// retryField declares the registered retry policy of the service
+const retryField = "  private retryPolicy?: Required<RetryPolicy>;\n"
+
+// retryMethods registers the retry policy and applies it to fcl calls
+const retryMethods = `  /** Retries failed queries and mutations, e.g. useRetryPolicy({ maxAttempts: 5 }) */
+  useRetryPolicy(policy: RetryPolicy = {}) {
+    this.retryPolicy = {
+      maxAttempts: policy.maxAttempts ?? 3,
+      backoff: policy.backoff ?? ((attempt) => 500 * 2 ** (attempt - 1)),
+      retryable: policy.retryable ?? isRetryableError,
+    };
+  }
+
+  private async withRetry<T>(config: CadenceConfig, run: () => Promise<T>): Promise<T> {
+    for (let attempt = 1; ; attempt++) {
+      try {
+        return await run();
+      } catch (error) {
+        const policy = this.retryPolicy;
+        if (!policy || attempt >= policy.maxAttempts || !policy.retryable(error, config)) {
+          throw error;
+        }
+        await new Promise((resolve) => setTimeout(resolve, policy.backoff(attempt)));
+      }
+    }
+  }
+
+`
+
+// SetRetry enables the retry policy of generated functions
+func (g *Generator) SetRetry(retry bool) {
+	g.Retry = retry
+}
@@ -0,0 +1,82 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// setupStep is a setup transaction run by ensureAccountSetup
+type setupStep struct {
+	Name  string // Function of the setup transaction
+	Check string // Function of the script checking whether it is needed, if any
+}
+
+// setupTemplate sends the setup transactions an account still needs, in order
+const setupTemplate = `  /**
+   * Sends the setup transactions the account still needs, in order, waiting
+   * for each to be sealed: {{range $index, $step := .Steps}}{{if $index}}, {{end}}{{$step.Name}}{{end}}.
+{{- if .Checks}}
+   * Setups with a check script are skipped if it returns true for address.
+{{- end}}
+   * Returns the IDs of the sent transactions.
+   */
+  async ensureAccountSetup({{if .Checks}}address: FlowAddress, {{end}}options?: MutationOptions): Promise<string[]> {
+    const txIds: string[] = [];
+    const send = async (txId: string) => {
+      await this.waitForTransaction(txId);
+      txIds.push(txId);
+    };
+{{- range .Steps}}
+{{- if .Check}}
+    if (!(await this.{{.Check}}(address))) {
+      await send(await this.{{.Name}}(options));
+    }
+{{- else}}
+    await send(await this.{{.Name}}(options));
+{{- end}}
+{{- end}}
+    return txIds;
+  }
+
+`
+
+// generateSetupMethod renders ensureAccountSetup for the setup transactions
+// of the report. It returns nothing if the report has none.
+func (g *Generator) generateSetupMethod() (string, error) {
+	setups, err := g.Report.AccountSetups()
+	if err != nil {
+		return "", err
+	}
+	if len(setups) == 0 {
+		return "", nil
+	}
+
+	var steps []setupStep
+	checks := false
+	for _, setup := range setups {
+		step := setupStep{Name: g.functionName(setup.Transaction)}
+		if setup.Check != "" {
+			step.Check = g.functionName(setup.Check)
+			checks = true
+		}
+		steps = append(steps, step)
+	}
+
+	tmpl, err := template.New("setup").Parse(setupTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse setup template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Steps  []setupStep
+		Checks bool
+	}{
+		Steps:  steps,
+		Checks: checks,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute setup template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,104 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+const solidTemplate = `/** Generated SolidJS primitives for the Cadence service */
+import { createContext, createResource, createSignal, useContext, type Accessor } from "solid-js";
+import { CadenceService } from "{{.ImportPath}}";
+
+/** Provides the CadenceService used by the primitives, e.g. one with interceptors */
+export const CadenceContext = createContext<CadenceService>();
+
+const defaultService = new CadenceService();
+
+/** Returns the CadenceService of the context, or a default one */
+export function useCadenceService(): CadenceService {
+  return useContext(CadenceContext) ?? defaultService;
+}
+
+/** A value or an accessor of a value */
+export type MaybeAccessor<T> = T | Accessor<T>;
+
+function access<T>(value: MaybeAccessor<T>): T {
+  return typeof value === "function" ? (value as Accessor<T>)() : value;
+}
+
+/** Tracks the id, pending state and error of a transaction */
+function createTransaction<Args extends unknown[]>(send: (...args: Args) => Promise<string>) {
+  const [txId, setTxId] = createSignal<string>();
+  const [pending, setPending] = createSignal(false);
+  const [error, setError] = createSignal<unknown>();
+
+  async function execute(...args: Args): Promise<string> {
+    setPending(true);
+    setError(undefined);
+    try {
+      const id = await send(...args);
+      setTxId(id);
+      return id;
+    } catch (e) {
+      setError(() => e);
+      throw e;
+    } finally {
+      setPending(false);
+    }
+  }
+
+  return { execute, txId, pending, error };
+}
+{{range $func := .Functions}}
+{{- if eq .Type "query"}}
+/** Fetches the {{.Name}} script with createResource, refetching when the arguments change */
+export function create{{pascal .Name}}Resource({{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}: MaybeAccessor<Parameters<CadenceService["{{$func.Name}}"]>[{{$index}}]>{{end}}) {
+  const service = useCadenceService();
+  {{- if .Parameters}}
+  return createResource(
+    () => [{{range $index, $param := .Parameters}}{{if $index}}, {{end}}access({{$param.Name}}){{end}}] as const,
+    ([{{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{end}}]) => service.{{.Name}}({{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.Name}}{{end}}),
+  );
+  {{- else}}
+  return createResource(() => service.{{.Name}}());
+  {{- end}}
+}
+{{- else}}
+/** Wraps the {{.Name}} transaction in an action with txId, pending and error signals */
+export function create{{pascal .Name}}Action() {
+  const service = useCadenceService();
+  return createTransaction((...args: Parameters<CadenceService["{{.Name}}"]>) => service.{{.Name}}(...args));
+}
+{{- end}}
+{{end}}`
+
+// GenerateSolid generates SolidJS primitives: a createResource based
+// primitive per script and an action wrapper per transaction. importPath is the
+// module path of the generated service relative to the primitives module.
+func (g *Generator) GenerateSolid(importPath string) (string, error) {
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+	for _, tag := range tagNames {
+		functions = append(functions, taggedFunctions[tag]...)
+	}
+
+	funcMap := template.FuncMap{
+		"pascal": pascalCase,
+	}
+	tmpl, err := template.New("solid").Funcs(funcMap).Parse(solidTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse SolidJS template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		ImportPath string
+		Functions  []TypeScriptFunction
+	}{
+		ImportPath: importPath,
+		Functions:  functions,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute SolidJS template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,53 @@
+package typescript
+
+// telemetryTypes declares the event passed to telemetry hooks
+const telemetryTypes = `/** An executed query or mutation reported to telemetry hooks */
+export interface InteractionEvent {
+  name: string;
+  type: "script" | "transaction";
+  /** Duration of the fcl call in milliseconds */
+  duration: number;
+  success: boolean;
+  error?: unknown;
+}
+
+export type TelemetryHook = (event: InteractionEvent) => void;
+
+`
+
+// telemetryField declares the registered hooks of the service
+const telemetryField = "  private telemetryHooks: TelemetryHook[] = [];\n"
+
+// telemetryMethods registers hooks and times fcl calls
+const telemetryMethods = `  /** Registers a hook called after every query and mutation, e.g. to feed metrics into analytics */
+  useTelemetry(hook: TelemetryHook) {
+    this.telemetryHooks.push(hook);
+  }
+
+  private async instrument<T>(name: string, type: InteractionEvent["type"], run: () => Promise<T>): Promise<T> {
+    const start = Date.now();
+    const report = (success: boolean, error?: unknown) => {
+      for (const hook of this.telemetryHooks) {
+        try {
+          hook({ name, type, duration: Date.now() - start, success, error });
+        } catch {
+          // Failing hooks must not fail the interaction
+        }
+      }
+    };
+    try {
+      const result = await run();
+      report(true);
+      return result;
+    } catch (error) {
+      report(false, error);
+      throw error;
+    }
+  }
+
+`
+
+// SetTelemetry enables telemetry hooks invoked around every query and mutation
+func (g *Generator) SetTelemetry(telemetry bool) {
+	g.Telemetry = telemetry
+}
@@ -0,0 +1,213 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Supported test frameworks for generated test scaffolds
+const (
+	TestFrameworkVitest = "vitest"
+	TestFrameworkJest   = "jest"
+)
+
+const testTemplate = `/** Generated test scaffold for {{if .Tag}}{{.Tag}}{{else}}untagged{{end}} Cadence functions */
+{{- if eq .Framework "jest"}}
+import { describe, it, expect, beforeEach, jest } from "@jest/globals";
+{{- else}}
+import { describe, it, expect, vi, beforeEach } from "vitest";
+{{- end}}
+import * as fcl from "@onflow/fcl";
+import { CadenceService } from "{{.ImportPath}}";
+
+{{.Mock}}("@onflow/fcl", () => ({
+  query: {{.Fn}}(),
+  mutate: {{.Fn}}(),
+}));
+
+const mockedFcl = fcl as unknown as { query: any; mutate: any };
+
+// Records arguments as { value, type } pairs with types rendered as in the generated code
+const arg = (value: any, type: any) => ({ value, type });
+const t: any = new Proxy({}, {
+  get: (_target, name) => {
+    if (name === "Array") return (inner: string) => ` + "`t.Array(${inner})`" + `;
+    if (name === "Dictionary") return ({ key, value }: { key: string; value: string }) => ` + "`t.Dictionary({ key: ${key}, value: ${value} })`" + `;
+    return ` + "`t.${String(name)}`" + `;
+  },
+});
+
+describe("{{if .Tag}}{{.Tag}}{{else}}CadenceService{{end}}", () => {
+  let service: CadenceService;
+
+  beforeEach(() => {
+    mockedFcl.query.mockReset();
+    mockedFcl.mutate.mockReset();
+    service = new CadenceService();
+  });
+{{range .Functions}}
+  it("{{.Name}} encodes arguments and decodes the {{if eq .Type "query"}}result{{else}}transaction id{{end}}", async () => {
+    {{- if eq .Type "query"}}
+    const response = {{.SampleResponse}};
+    mockedFcl.query.mockResolvedValue(response);
+    {{- else}}
+    const response = "0000000000000000000000000000000000000000000000000000000000000001";
+    mockedFcl.mutate.mockResolvedValue(response);
+    {{- end}}
+
+    const result = await service.{{.Name}}({{range $index, $param := .Parameters}}{{if $index}}, {{end}}{{$param.SampleValue}}{{end}});
+
+    expect(result).toEqual({{if .Kind}}{ kind: {{.Kind}}, value: response }{{else}}response{{end}});
+    const config = mockedFcl.{{if eq .Type "query"}}query{{else}}mutate{{end}}.mock.calls[0][0];
+    expect(config.type).toBe("{{if eq .Type "query"}}script{{else}}transaction{{end}}");
+    expect(config.cadence.length).toBeGreaterThan(0);
+    expect(config.args(arg, t)).toEqual([
+      {{- range .Parameters}}
+      { value: {{.SampleValue}}, type: "{{.FCLType}}" },
+      {{- end}}
+    ]);
+  });
+{{end}}});
+`
+
+// testFunction holds the data needed to render the test of a single function
+type testFunction struct {
+	Name           string
+	Type           string
+	Parameters     []testParameter
+	SampleResponse string
+	Kind           string // JSON literal of the kind the result union resolves to, if any
+}
+
+// testParameter holds a sample argument and its expected FCL type
+type testParameter struct {
+	SampleValue string
+	FCLType     string
+}
+
+// sampleValue returns a TypeScript literal that is a valid value for the given Cadence type
+func sampleValue(cadenceType string) string {
+	cadenceType = strings.TrimSpace(cadenceType)
+	if strings.HasSuffix(cadenceType, "?") {
+		return sampleValue(strings.TrimSuffix(cadenceType, "?"))
+	}
+
+	if strings.HasPrefix(cadenceType, "[") && strings.HasSuffix(cadenceType, "]") {
+		elementType := strings.TrimPrefix(strings.TrimSuffix(cadenceType, "]"), "[")
+		return fmt.Sprintf("[%s]", sampleValue(elementType))
+	}
+
+	if strings.HasPrefix(cadenceType, "{") && strings.HasSuffix(cadenceType, "}") {
+		return "{}"
+	}
+
+	switch cadenceType {
+	case "String":
+		return `"test"`
+	case "Address":
+		return `"0x0000000000000001"`
+	case "Bool":
+		return "true"
+	case "UFix64", "Fix64":
+		return `"1.00000000"`
+	case "UInt128", "UInt256", "Int128", "Int256":
+		return `"1"`
+	}
+
+	if tsType, ok := typeMapping[cadenceType]; ok && tsType == "number" {
+		return "1"
+	}
+
+	// Structs and other composite types
+	return "{} as any"
+}
+
+// GenerateTests generates a test scaffold per tag for the generated service.
+// importPath is the module path of the generated service relative to the test files.
+// The result maps file names to file contents.
+func (g *Generator) GenerateTests(framework string, importPath string) (map[string]string, error) {
+	var mock, fn string
+	switch framework {
+	case TestFrameworkVitest:
+		mock, fn = "vi.mock", "vi.fn"
+	case TestFrameworkJest:
+		mock, fn = "jest.mock", "jest.fn"
+	default:
+		return nil, fmt.Errorf("unsupported test framework: %s", framework)
+	}
+
+	tmpl, err := template.New("test").Parse(testTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse test template: %w", err)
+	}
+
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+
+	render := func(tag string, tsFunctions []TypeScriptFunction) (string, error) {
+		var testFunctions []testFunction
+		for _, tsFunction := range tsFunctions {
+			tf := testFunction{
+				Name: tsFunction.Name,
+				Type: tsFunction.Type,
+			}
+			for _, param := range tsFunction.Parameters {
+				tf.Parameters = append(tf.Parameters, testParameter{
+					SampleValue: sampleValue(param.TypeStr),
+					FCLType:     getFCLType(param.TypeStr),
+				})
+			}
+			if tsFunction.Type == "query" {
+				tf.SampleResponse = sampleValue(tsFunction.CadenceReturnType)
+				if tsFunction.CadenceReturnType == "" {
+					tf.SampleResponse = "null"
+				}
+				if tsFunction.Union != nil {
+					tf.SampleResponse = g.unionSample(tsFunction.Union)
+					tf.Kind, _ = jsonString(tsFunction.Union.Members[0].Kind)
+				}
+			}
+			testFunctions = append(testFunctions, tf)
+		}
+
+		var buffer bytes.Buffer
+		err := tmpl.Execute(&buffer, struct {
+			Tag        string
+			Framework  string
+			ImportPath string
+			Mock       string
+			Fn         string
+			Functions  []testFunction
+		}{
+			Tag:        tag,
+			Framework:  framework,
+			ImportPath: importPath,
+			Mock:       mock,
+			Fn:         fn,
+			Functions:  testFunctions,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to execute test template: %w", err)
+		}
+		return buffer.String(), nil
+	}
+
+	files := make(map[string]string)
+	if len(functions) > 0 {
+		code, err := render("", functions)
+		if err != nil {
+			return nil, err
+		}
+		files["CadenceService.test.ts"] = code
+	}
+	for _, tag := range tagNames {
+		code, err := render(tag, taggedFunctions[tag])
+		if err != nil {
+			return nil, err
+		}
+		files[tag+".test.ts"] = code
+	}
+
+	return files, nil
+}
@@ -0,0 +1,86 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+const trpcTemplate = `/** Generated tRPC router for the Cadence service */
+import { initTRPC } from "@trpc/server";
+{{.Library.Import}}
+import { CadenceService } from "{{.ImportPath}}";
+
+const t = initTRPC.create();
+{{- if .Library.Helpers}}
+
+{{.Library.Helpers}}
+{{- end}}
+{{- if .Library.Parser}}
+
+{{.Library.Parser}}
+{{- end}}
+
+/** Input schemas of the generated procedures */
+export const inputs = {
+{{- range .Functions}}
+  {{.Name}}: {{$.Library.Object}}({
+    {{- range .Parameters}}
+    {{.Name}}: {{schema .TypeStr}},
+    {{- end}}
+  }),
+{{- end}}
+};
+
+/** Creates a router where scripts are queries and transactions are mutations */
+export function createCadenceRouter(service: CadenceService = new CadenceService()) {
+  return t.router({
+{{- range .Functions}}
+    {{.Name}}: t.procedure
+      .input({{if $.Library.Parser}}parser(inputs.{{.Name}}){{else}}inputs.{{.Name}}{{end}})
+      .{{if eq .Type "query"}}query{{else}}mutation{{end}}(({ input }) => service.{{.Name}}({{range $index, $param := .Parameters}}{{if $index}}, {{end}}input.{{$param.Name}}{{end}})),
+{{- end}}
+  });
+}
+
+export const cadenceRouter = createCadenceRouter();
+export type CadenceRouter = typeof cadenceRouter;
+`
+
+// GenerateTRPC generates a tRPC router exposing every script as a query and
+// every transaction as a mutation. importPath is the module path of the
+// generated service relative to the router module. Inputs are validated with
+// the library set with SetValidators.
+func (g *Generator) GenerateTRPC(importPath string) (string, error) {
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+	for _, tag := range tagNames {
+		functions = append(functions, taggedFunctions[tag]...)
+	}
+
+	library, err := g.validators()
+	if err != nil {
+		return "", err
+	}
+
+	funcMap := template.FuncMap{
+		"schema": library.schema,
+	}
+	tmpl, err := template.New("trpc").Funcs(funcMap).Parse(trpcTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse tRPC template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		ImportPath string
+		Library    *validatorLibrary
+		Functions  []TypeScriptFunction
+	}{
+		ImportPath: importPath,
+		Library:    library,
+		Functions:  functions,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute tRPC template: %w", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,186 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// TypeScriptUnion is the discriminated union a script returning AnyStruct
+// with a // codegen:returns pragma resolves its result into
+type TypeScriptUnion struct {
+	Name     string // Union type, e.g. GetInfoResult
+	Optional bool   // Whether the script returns AnyStruct?
+	Members  []TypeScriptUnionMember
+}
+
+// TypeScriptUnionMember is a type of a union, discriminated by its Cadence
+// type in the kind property
+type TypeScriptUnionMember struct {
+	Kind  string // Cadence type, e.g. FlowIDTableStaking.DelegatorInfo
+	Type  string // TypeScript type of the value
+	Guard string // TypeScript expression checking whether value has the type
+}
+
+// TypeScriptGuard is the type guard of a struct returned by a union
+type TypeScriptGuard struct {
+	Name   string   // Interface checked, e.g. StorageInfo
+	Fields []string // Fields every value of the interface has
+}
+
+const unionsTemplate = `{{range .Guards -}}
+/** Whether value has the fields of {{.Name}} */
+export function is{{.Name}}(value: unknown): value is {{.Name}} {
+  return typeof value === "object" && value !== null{{range .Fields}} && {{json .}} in value{{end}};
+}
+
+{{end}}
+{{- range .Functions}}{{$union := .Union -}}
+/** Result of {{.Name}}, discriminated by the Cadence type of the returned value */
+export type {{$union.Name}} =
+{{- range $union.Members}}
+  | { kind: {{json .Kind}}; value: {{.Type}} }
+{{- end}};
+
+/** Resolves the value returned by {{.Name}} into a {{$union.Name}}, trying its types in order */
+export function resolve{{$union.Name}}(value: unknown): {{$union.Name}}{{if $union.Optional}} | undefined{{end}} {
+  {{- if $union.Optional}}
+  if (value === null || value === undefined) {
+    return undefined;
+  }
+  {{- end}}
+  {{- range $union.Members}}
+  if ({{.Guard}}) {
+    return { kind: {{json .Kind}}, value: value as {{.Type}} };
+  }
+  {{- end}}
+  throw new Error({{json (printf "%s returned a value matching none of %s" .Name (kinds $union))}});
+}
+
+{{end}}`
+
+// returnUnion returns the union a script with the return union members
+// resolves its result into, named after the function
+func (g *Generator) returnUnion(name string, returnType string, members []string) *TypeScriptUnion {
+	union := &TypeScriptUnion{
+		Name:     pascalCase(name) + "Result",
+		Optional: strings.HasSuffix(returnType, "?"),
+	}
+	for _, member := range members {
+		union.Members = append(union.Members, TypeScriptUnionMember{
+			Kind:  member,
+			Type:  convertCadenceTypeToTypeScript(member),
+			Guard: g.typeGuard(member),
+		})
+	}
+	return union
+}
+
+// typeGuard returns a TypeScript expression checking whether value decodes
+// as the Cadence type, as fcl decodes it
+func (g *Generator) typeGuard(cadenceType string) string {
+	if strings.HasSuffix(cadenceType, "?") {
+		return fmt.Sprintf("(value === null || %s)", g.typeGuard(strings.TrimSuffix(cadenceType, "?")))
+	}
+	if strings.HasPrefix(cadenceType, "[") && strings.HasSuffix(cadenceType, "]") {
+		return "Array.isArray(value)"
+	}
+	if strings.HasPrefix(cadenceType, "{") && strings.HasSuffix(cadenceType, "}") {
+		return `typeof value === "object" && value !== null && !Array.isArray(value)`
+	}
+	if _, ok := g.Report.Structs[flattenStructName(cadenceType)]; ok {
+		return "is" + flattenStructName(cadenceType) + "(value)"
+	}
+	switch tsType := convertCadenceTypeToTypeScript(cadenceType); tsType {
+	case "string", "number", "boolean":
+		return fmt.Sprintf("typeof value === %q", tsType)
+	case "FlowAddress":
+		return `typeof value === "string"`
+	}
+	return "true"
+}
+
+// generateUnions renders the discriminated unions of the functions having
+// any, with the type guards of the structs they return, or nothing if none has
+func (g *Generator) generateUnions(functions []TypeScriptFunction) (string, error) {
+	var unionFunctions []TypeScriptFunction
+	var guards []TypeScriptGuard
+	seen := make(map[string]bool)
+	for _, function := range functions {
+		if function.Union == nil {
+			continue
+		}
+		unionFunctions = append(unionFunctions, function)
+		for _, member := range function.Union.Members {
+			name := flattenStructName(strings.TrimSuffix(member.Kind, "?"))
+			composite, ok := g.Report.Structs[name]
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			guard := TypeScriptGuard{Name: name}
+			for _, field := range composite.Fields {
+				if !field.Optional {
+					guard.Fields = append(guard.Fields, field.Name)
+				}
+			}
+			guards = append(guards, guard)
+		}
+	}
+	if len(unionFunctions) == 0 {
+		return "", nil
+	}
+	sort.Slice(guards, func(i, j int) bool { return guards[i].Name < guards[j].Name })
+
+	funcMap := template.FuncMap{
+		"json":  jsonString,
+		"kinds": unionKinds,
+	}
+	tmpl, err := template.New("unions").Funcs(funcMap).Parse(unionsTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse unions template: %w", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Guards    []TypeScriptGuard
+		Functions []TypeScriptFunction
+	}{
+		Guards:    guards,
+		Functions: unionFunctions,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute unions template: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// unionKinds lists the Cadence types of a union, e.g. StorageInfo, String
+func unionKinds(union *TypeScriptUnion) string {
+	var kinds []string
+	for _, member := range union.Members {
+		kinds = append(kinds, member.Kind)
+	}
+	return strings.Join(kinds, ", ")
+}
+
+// unionSample returns a sample value of the first type of a union as a
+// TypeScript literal, with the fields of structs so their type guard accepts it
+func (g *Generator) unionSample(union *TypeScriptUnion) string {
+	kind := union.Members[0].Kind
+	composite, ok := g.Report.Structs[flattenStructName(kind)]
+	if !ok {
+		return sampleValue(kind)
+	}
+	var fields []string
+	for _, field := range composite.Fields {
+		if !field.Optional {
+			fields = append(fields, fmt.Sprintf("%s: %s", field.Name, sampleValue(field.TypeStr)))
+		}
+	}
+	if len(fields) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(fields, ", ") + " }"
+}
@@ -0,0 +1,217 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"text/template"
+)
+
+// integerBounds lists the fixed-width Cadence integer types with their bit size and signedness
+var integerBounds = []struct {
+	Type   string
+	Bits   uint
+	Signed bool
+}{
+	{"Int8", 8, true},
+	{"Int16", 16, true},
+	{"Int32", 32, true},
+	{"Int64", 64, true},
+	{"Int128", 128, true},
+	{"Int256", 256, true},
+	{"UInt8", 8, false},
+	{"UInt16", 16, false},
+	{"UInt32", 32, false},
+	{"UInt64", 64, false},
+	{"UInt128", 128, false},
+	{"UInt256", 256, false},
+	{"Word8", 8, false},
+	{"Word16", 16, false},
+	{"Word32", 32, false},
+	{"Word64", 64, false},
+}
+
+// integerRange holds the rendered bounds of an integer type
+type integerRange struct {
+	Type string
+	Min  string
+	Max  string
+}
+
+const validationTemplate = `/** Error thrown when an argument does not match its Cadence type */
+export class CadenceValidationError extends Error {
+  constructor(
+    public functionName: string,
+    public argument: string,
+    public cadenceType: string,
+    public reason: string,
+  ) {
+    super(` + "`" + `${functionName}: invalid argument "${argument}" of type ${cadenceType}: ${reason}` + "`" + `);
+    this.name = "CadenceValidationError";
+  }
+}
+
+/** Inclusive bounds of Cadence integer types, null means unbounded */
+const integerBounds: Record<string, [string | null, string | null]> = {
+  Int: [null, null],
+  UInt: ["0", null],
+{{- range .}}
+  {{.Type}}: ["{{.Min}}", "{{.Max}}"],
+{{- end}}
+};
+
+/** Inclusive bounds of Cadence fixed point types, scaled by 10^8 */
+const fixedPointBounds: Record<string, [string, string]> = {
+  UFix64: ["0", "18446744073709551615"],
+  Fix64: ["-9223372036854775808", "9223372036854775807"],
+};
+
+/** Validates the arguments of a function before they are encoded by fcl */
+function validateArguments(functionName: string, args: [string, any, string][]) {
+  for (const [name, value, cadenceType] of args) {
+    const reason = validateValue(value, cadenceType.trim());
+    if (reason) {
+      throw new CadenceValidationError(functionName, name, cadenceType, reason);
+    }
+  }
+}
+
+/** Returns the index of the first top level separator in a type string, or -1 */
+function topLevelIndex(type: string, separator: string): number {
+  let depth = 0;
+  for (let i = 0; i < type.length; i++) {
+    const c = type[i];
+    if (c === "[" || c === "{" || c === "<" || c === "(") depth++;
+    else if (c === "]" || c === "}" || c === ">" || c === ")") depth--;
+    else if (c === separator && depth === 0) return i;
+  }
+  return -1;
+}
+
+/** Returns why a value does not match a Cadence type, or undefined if it does */
+function validateValue(value: any, cadenceType: string): string | undefined {
+  if (cadenceType.endsWith("?")) {
+    if (value === undefined || value === null) return undefined;
+    return validateValue(value, cadenceType.slice(0, -1).trim());
+  }
+  if (value === undefined || value === null) {
+    return "value is required";
+  }
+
+  if (cadenceType.startsWith("[") && cadenceType.endsWith("]")) {
+    if (!Array.isArray(value)) return "expected an array";
+    const inner = cadenceType.slice(1, -1);
+    const sizeIndex = topLevelIndex(inner, ";");
+    const elementType = (sizeIndex >= 0 ? inner.slice(0, sizeIndex) : inner).trim();
+    if (sizeIndex >= 0) {
+      const size = Number(inner.slice(sizeIndex + 1).trim());
+      if (value.length !== size) return ` + "`" + `expected ${size} elements, got ${value.length}` + "`" + `;
+    }
+    for (let i = 0; i < value.length; i++) {
+      const reason = validateValue(value[i], elementType);
+      if (reason) return ` + "`" + `element ${i}: ${reason}` + "`" + `;
+    }
+    return undefined;
+  }
+
+  if (cadenceType.startsWith("{") && cadenceType.endsWith("}")) {
+    const inner = cadenceType.slice(1, -1);
+    const colon = topLevelIndex(inner, ":");
+    if (colon < 0) return undefined;
+    if (typeof value !== "object") return "expected a dictionary";
+    const keyType = inner.slice(0, colon).trim();
+    const valueType = inner.slice(colon + 1).trim();
+    const entries: [any, any][] = Array.isArray(value)
+      ? value.map((entry: any) => [entry?.key, entry?.value])
+      : Object.entries(value);
+    for (const [key, entryValue] of entries) {
+      const keyReason = validateValue(key, keyType);
+      if (keyReason) return ` + "`" + `key ${key}: ${keyReason}` + "`" + `;
+      const valueReason = validateValue(entryValue, valueType);
+      if (valueReason) return ` + "`" + `value of ${key}: ${valueReason}` + "`" + `;
+    }
+    return undefined;
+  }
+
+  if (cadenceType in integerBounds) {
+    let integer: bigint;
+    if (typeof value === "number" && Number.isInteger(value)) {
+      integer = BigInt(value);
+    } else if (typeof value === "string" && /^-?\d+$/.test(value)) {
+      integer = BigInt(value);
+    } else {
+      return ` + "`" + `expected an integer, got ${JSON.stringify(value)}` + "`" + `;
+    }
+    const [min, max] = integerBounds[cadenceType];
+    if ((min !== null && integer < BigInt(min)) || (max !== null && integer > BigInt(max))) {
+      return ` + "`" + `${value} is out of range [${min ?? "-∞"}, ${max ?? "∞"}]` + "`" + `;
+    }
+    return undefined;
+  }
+
+  if (cadenceType in fixedPointBounds) {
+    const match = typeof value === "string" ? /^(-?)(\d+)\.(\d{1,8})$/.exec(value) : null;
+    if (!match) {
+      return ` + "`" + `expected a decimal string with 1 to 8 fractional digits (e.g. "1.0"), got ${JSON.stringify(value)}` + "`" + `;
+    }
+    const scaled = BigInt(match[1] + match[2] + match[3].padEnd(8, "0"));
+    const [min, max] = fixedPointBounds[cadenceType];
+    if (scaled < BigInt(min) || scaled > BigInt(max)) {
+      return ` + "`" + `${value} is out of range for ${cadenceType}` + "`" + `;
+    }
+    return undefined;
+  }
+
+  switch (cadenceType) {
+    case "Address":
+      if (typeof value !== "string" || !/^(0x)?[0-9a-fA-F]{16}$/.test(value)) {
+        return ` + "`" + `expected a 16 digit hex address (e.g. "0x0000000000000001"), got ${JSON.stringify(value)}` + "`" + `;
+      }
+      return undefined;
+    case "String":
+      return typeof value === "string" ? undefined : "expected a string";
+    case "Character":
+      return typeof value === "string" && Array.from(value).length === 1 ? undefined : "expected a single character";
+    case "Bool":
+      return typeof value === "boolean" ? undefined : "expected a boolean";
+  }
+
+  // Structs and other types are left to fcl
+  return undefined;
+}
+`
+
+// SetValidate enables runtime argument validation in the generated functions
+func (g *Generator) SetValidate(validate bool) {
+	g.Validate = validate
+}
+
+// generateValidation renders the argument validation helpers
+func generateValidation() (string, error) {
+	var ranges []integerRange
+	for _, bounds := range integerBounds {
+		limit := new(big.Int).Lsh(big.NewInt(1), bounds.Bits)
+		min := big.NewInt(0)
+		max := new(big.Int).Sub(limit, big.NewInt(1))
+		if bounds.Signed {
+			half := new(big.Int).Rsh(limit, 1)
+			min = new(big.Int).Neg(half)
+			max = new(big.Int).Sub(half, big.NewInt(1))
+		}
+		ranges = append(ranges, integerRange{
+			Type: bounds.Type,
+			Min:  min.String(),
+			Max:  max.String(),
+		})
+	}
+
+	tmpl, err := template.New("validation").Parse(validationTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse validation template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, ranges); err != nil {
+		return "", fmt.Errorf("failed to execute validation template: %w", err)
+	}
+	return buffer.String(), nil
+}
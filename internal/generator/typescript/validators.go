@@ -0,0 +1,253 @@
+package typescript
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Supported runtime validation libraries for generated input schemas
+const (
+	ValidatorsZod     = "zod"
+	ValidatorsValibot = "valibot"
+	ValidatorsIOTS    = "io-ts"
+)
+
+// validatorLibrary renders input schemas of Cadence parameters with a runtime
+// validation library
+type validatorLibrary struct {
+	// Import declares the library
+	Import string
+	// Helpers are declared after the imports, e.g. codecs shared by the schemas
+	Helpers string
+	// Object is the constructor of object schemas
+	Object string
+	// SchemaType is the TypeScript type of any schema
+	SchemaType string
+	// Parser declares parser(schema), wrapping a schema into a tRPC input
+	// parser. Empty if tRPC accepts the schemas as is.
+	Parser string
+	// SafeParse declares safeParse(schema, value), returning the result in
+	// the shape of zod's safeParse. Empty if the schemas have a safeParse method.
+	SafeParse string
+
+	typeMapping map[string]string
+	optional    string
+	array       string
+	record      string
+	stringKey   string
+	numberKey   string
+	anyType     string
+}
+
+// zodTypeMapping maps Cadence types to zod schemas matching typeMapping
+var zodTypeMapping = map[string]string{
+	"String":  "z.string()",
+	"Int":     "z.number().int()",
+	"UInt":    "z.number().int().nonnegative()",
+	"UInt8":   "z.number().int().min(0).max(255)",
+	"UInt16":  "z.number().int().min(0).max(65535)",
+	"UInt32":  "z.number().int().min(0).max(4294967295)",
+	"UInt64":  "z.number().int().nonnegative()",
+	"UInt128": "z.string().regex(/^\\d+$/)",
+	"UInt256": "z.string().regex(/^\\d+$/)",
+	"Int8":    "z.number().int().min(-128).max(127)",
+	"Int16":   "z.number().int().min(-32768).max(32767)",
+	"Int32":   "z.number().int().min(-2147483648).max(2147483647)",
+	"Int64":   "z.number().int()",
+	"Int128":  "z.string().regex(/^-?\\d+$/)",
+	"Int256":  "z.string().regex(/^-?\\d+$/)",
+	"Bool":    "z.boolean()",
+	"Address": "z.string().regex(/^0x[0-9a-fA-F]{1,16}$/).transform((value) => value as `0x${string}`)",
+	"UFix64":  "z.string().regex(/^\\d+\\.\\d{1,8}$/)",
+	"Fix64":   "z.string().regex(/^-?\\d+\\.\\d{1,8}$/)",
+}
+
+// valibotTypeMapping maps Cadence types to valibot schemas matching typeMapping
+var valibotTypeMapping = map[string]string{
+	"String":  "v.string()",
+	"Int":     "v.pipe(v.number(), v.integer())",
+	"UInt":    "v.pipe(v.number(), v.integer(), v.minValue(0))",
+	"UInt8":   "v.pipe(v.number(), v.integer(), v.minValue(0), v.maxValue(255))",
+	"UInt16":  "v.pipe(v.number(), v.integer(), v.minValue(0), v.maxValue(65535))",
+	"UInt32":  "v.pipe(v.number(), v.integer(), v.minValue(0), v.maxValue(4294967295))",
+	"UInt64":  "v.pipe(v.number(), v.integer(), v.minValue(0))",
+	"UInt128": "v.pipe(v.string(), v.regex(/^\\d+$/))",
+	"UInt256": "v.pipe(v.string(), v.regex(/^\\d+$/))",
+	"Int8":    "v.pipe(v.number(), v.integer(), v.minValue(-128), v.maxValue(127))",
+	"Int16":   "v.pipe(v.number(), v.integer(), v.minValue(-32768), v.maxValue(32767))",
+	"Int32":   "v.pipe(v.number(), v.integer(), v.minValue(-2147483648), v.maxValue(2147483647))",
+	"Int64":   "v.pipe(v.number(), v.integer())",
+	"Int128":  "v.pipe(v.string(), v.regex(/^-?\\d+$/))",
+	"Int256":  "v.pipe(v.string(), v.regex(/^-?\\d+$/))",
+	"Bool":    "v.boolean()",
+	"Address": "v.pipe(v.string(), v.regex(/^0x[0-9a-fA-F]{1,16}$/), v.transform((value) => value as `0x${string}`))",
+	"UFix64":  "v.pipe(v.string(), v.regex(/^\\d+\\.\\d{1,8}$/))",
+	"Fix64":   "v.pipe(v.string(), v.regex(/^-?\\d+\\.\\d{1,8}$/))",
+}
+
+// ioTSTypeMapping maps Cadence types to io-ts codecs matching typeMapping,
+// using the integer, pattern and address codecs of ioTSHelpers
+var ioTSTypeMapping = map[string]string{
+	"String":  "io.string",
+	"Int":     `integer("Int")`,
+	"UInt":    `integer("UInt", 0)`,
+	"UInt8":   `integer("UInt8", 0, 255)`,
+	"UInt16":  `integer("UInt16", 0, 65535)`,
+	"UInt32":  `integer("UInt32", 0, 4294967295)`,
+	"UInt64":  `integer("UInt64", 0)`,
+	"UInt128": `pattern("UInt128", /^\d+$/)`,
+	"UInt256": `pattern("UInt256", /^\d+$/)`,
+	"Int8":    `integer("Int8", -128, 127)`,
+	"Int16":   `integer("Int16", -32768, 32767)`,
+	"Int32":   `integer("Int32", -2147483648, 2147483647)`,
+	"Int64":   `integer("Int64")`,
+	"Int128":  `pattern("Int128", /^-?\d+$/)`,
+	"Int256":  `pattern("Int256", /^-?\d+$/)`,
+	"Bool":    "io.boolean",
+	"Address": "address",
+	"UFix64":  `pattern("UFix64", /^\d+\.\d{1,8}$/)`,
+	"Fix64":   `pattern("Fix64", /^-?\d+\.\d{1,8}$/)`,
+}
+
+// ioTSHelpers declares the refined codecs of Cadence integer, string and Address types
+const ioTSHelpers = `/** Refines a codec with a predicate, e.g. an integer range */
+function refine<A>(codec: io.Type<A>, predicate: (value: A) => boolean, name: string): io.Type<A> {
+  return new io.Type<A>(
+    name,
+    (u): u is A => codec.is(u) && predicate(u),
+    (u, c) => {
+      const result = codec.validate(u, c);
+      return isRight(result) && !predicate(result.right) ? io.failure(u, c) : result;
+    },
+    io.identity,
+  );
+}
+
+const integer = (name: string, min = -Infinity, max = Infinity) =>
+  refine(io.number, (n) => Number.isInteger(n) && n >= min && n <= max, name);
+
+const pattern = (name: string, regex: RegExp) => refine(io.string, (s) => regex.test(s), name);
+
+const isAddress = (u: unknown): u is ` + "`0x${string}`" + ` => typeof u === "string" && /^0x[0-9a-fA-F]{1,16}$/.test(u);
+
+const address = new io.Type<` + "`0x${string}`" + `>("Address", isAddress, (u, c) => (isAddress(u) ? io.success(u) : io.failure(u, c)), io.identity);`
+
+var validatorLibraries = map[string]*validatorLibrary{
+	ValidatorsZod: {
+		Import:      `import { z } from "zod";`,
+		Object:      "z.object",
+		SchemaType:  "z.ZodTypeAny",
+		typeMapping: zodTypeMapping,
+		optional:    "%s.optional()",
+		array:       "z.array(%s)",
+		record:      "z.record(%s, %s)",
+		stringKey:   "z.string()",
+		numberKey:   "z.coerce.number()",
+		anyType:     "z.any()",
+	},
+	ValidatorsValibot: {
+		Import:     `import * as v from "valibot";`,
+		Object:     "v.object",
+		SchemaType: "v.GenericSchema",
+		Parser: `/** Wraps a valibot schema into a tRPC input parser */
+function parser<S extends v.GenericSchema>(schema: S) {
+  return (input: unknown): v.InferOutput<S> => v.parse(schema, input);
+}`,
+		SafeParse: `/** Validates a value against a valibot schema, in the shape of zod's safeParse */
+function safeParse(schema: v.GenericSchema, value: unknown) {
+  const result = v.safeParse(schema, value);
+  return result.success
+    ? { success: true as const, data: result.output }
+    : { success: false as const, error: { issues: result.issues } };
+}`,
+		typeMapping: valibotTypeMapping,
+		optional:    "v.optional(%s)",
+		array:       "v.array(%s)",
+		record:      "v.record(%s, %s)",
+		stringKey:   "v.string()",
+		numberKey:   "v.pipe(v.string(), v.transform(Number))",
+		anyType:     "v.any()",
+	},
+	ValidatorsIOTS: {
+		Import: `import * as io from "io-ts";
+import { isRight } from "fp-ts/Either";
+import { PathReporter } from "io-ts/PathReporter";`,
+		Helpers:    ioTSHelpers,
+		Object:     "io.type",
+		SchemaType: "io.Mixed",
+		Parser: `/** Wraps an io-ts codec into a tRPC input parser */
+function parser<C extends io.Mixed>(codec: C) {
+  return (input: unknown): io.TypeOf<C> => {
+    const result = codec.decode(input);
+    if (!isRight(result)) {
+      throw new Error(PathReporter.report(result).join("\n"));
+    }
+    return result.right;
+  };
+}`,
+		SafeParse: `/** Validates a value against an io-ts codec, in the shape of zod's safeParse */
+function safeParse(codec: io.Mixed, value: unknown) {
+  const result = codec.decode(value);
+  return isRight(result)
+    ? { success: true as const, data: result.right }
+    : { success: false as const, error: { issues: PathReporter.report(result) } };
+}`,
+		typeMapping: ioTSTypeMapping,
+		optional:    "io.union([%s, io.undefined])",
+		array:       "io.array(%s)",
+		record:      "io.record(%s, %s)",
+		stringKey:   "io.string",
+		numberKey:   "io.string",
+		anyType:     "(io.unknown as io.Type<any>)",
+	},
+}
+
+// SetValidators sets the validation library of generated input schemas
+// (zod/valibot/io-ts), defaults to zod
+func (g *Generator) SetValidators(validators string) {
+	g.Validators = validators
+}
+
+// validators returns the validation library selected for the generator
+func (g *Generator) validators() (*validatorLibrary, error) {
+	name := g.Validators
+	if name == "" {
+		name = ValidatorsZod
+	}
+	library, ok := validatorLibraries[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported validators: %s", name)
+	}
+	return library, nil
+}
+
+// schema converts a Cadence type into a schema of the library. Struct and
+// unknown types are accepted as is.
+func (l *validatorLibrary) schema(cadenceType string) string {
+	cadenceType = strings.TrimSpace(cadenceType)
+	if strings.HasSuffix(cadenceType, "?") {
+		return fmt.Sprintf(l.optional, l.schema(strings.TrimSuffix(cadenceType, "?")))
+	}
+	if strings.HasPrefix(cadenceType, "[") && strings.HasSuffix(cadenceType, "]") {
+		element := strings.TrimPrefix(strings.TrimSuffix(cadenceType, "]"), "[")
+		if index := strings.Index(element, ";"); index >= 0 {
+			element = element[:index]
+		}
+		return fmt.Sprintf(l.array, l.schema(element))
+	}
+	if strings.HasPrefix(cadenceType, "{") && strings.HasSuffix(cadenceType, "}") {
+		inner := strings.TrimPrefix(strings.TrimSuffix(cadenceType, "}"), "{")
+		parts := strings.SplitN(inner, ":", 2)
+		if len(parts) == 2 {
+			key := l.stringKey
+			if typeMapping[strings.TrimSpace(parts[0])] == "number" {
+				key = l.numberKey
+			}
+			return fmt.Sprintf(l.record, key, l.schema(parts[1]))
+		}
+	}
+	if schema, ok := l.typeMapping[cadenceType]; ok {
+		return schema
+	}
+	return l.anyType
+}
@@ -0,0 +1,156 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+const workerTemplate = `/** Generated Web Worker running the scripts of the Cadence service off the UI thread */
+import * as fcl from "@onflow/fcl";
+import { CadenceService } from "{{.ImportPath}}";
+
+/** Configures fcl in the worker, or calls a script */
+export type WorkerRequest =
+  | { type: "config"; values: Record<string, unknown> }
+  | { type: "call"; id: number; name: string; args: unknown[] };
+
+export type WorkerResponse = { id: number; result: unknown } | { id: number; error: string };
+
+const service = new CadenceService();
+
+const scripts: Record<string, (...args: any[]) => Promise<unknown>> = {
+{{- range .Functions}}
+{{- if eq .Type "query"}}
+  {{.Name}}: (...args) => service.{{.Name}}(...(args as Parameters<CadenceService["{{.Name}}"]>)),
+{{- end}}
+{{- end}}
+};
+
+self.onmessage = async (event: MessageEvent<WorkerRequest>) => {
+  const request = event.data;
+  if (request.type === "config") {
+    fcl.config(request.values);
+    return;
+  }
+  const post = (response: WorkerResponse) => self.postMessage(response);
+  const script = scripts[request.name];
+  if (!script) {
+    post({ id: request.id, error: ` + "`" + `unknown script: ${request.name}` + "`" + ` });
+    return;
+  }
+  try {
+    post({ id: request.id, result: await script(...request.args) });
+  } catch (error: any) {
+    post({ id: request.id, error: error?.message ?? String(error) });
+  }
+};
+`
+
+const workerProxyTemplate = `/** Generated main-thread proxy running scripts in the Cadence Web Worker */
+import { CadenceService } from "{{.ImportPath}}";
+import type { WorkerRequest, WorkerResponse } from "./cadence.worker";
+
+/** Starts the generated worker as a module worker */
+export function createCadenceWorker(): Worker {
+  return new Worker(new URL("./cadence.worker.ts", import.meta.url), { type: "module" });
+}
+
+/**
+ * Runs scripts in the worker, so that decoding and validating large responses
+ * does not block the UI thread. Transactions need the wallet of the page and are
+ * sent by the service on the main thread.
+ */
+export class CadenceWorkerProxy {
+  private nextId = 0;
+  private pending = new Map<number, { resolve: (value: any) => void; reject: (reason: Error) => void }>();
+
+  /** fclConfig configures fcl in the worker, e.g. { "accessNode.api": "https://rest-testnet.onflow.org" } */
+  constructor(
+    private worker: Worker = createCadenceWorker(),
+    readonly service: CadenceService = new CadenceService(),
+    fclConfig?: Record<string, unknown>,
+  ) {
+    worker.addEventListener("message", (event: MessageEvent<WorkerResponse>) => {
+      const response = event.data;
+      const call = this.pending.get(response.id);
+      if (!call) return;
+      this.pending.delete(response.id);
+      if ("error" in response) {
+        call.reject(new Error(response.error));
+      } else {
+        call.resolve(response.result);
+      }
+    });
+    if (fclConfig) {
+      this.post({ type: "config", values: fclConfig });
+    }
+  }
+
+  private post(request: WorkerRequest) {
+    this.worker.postMessage(request);
+  }
+
+  private call<T>(name: string, args: unknown[]): Promise<T> {
+    const id = this.nextId++;
+    return new Promise<T>((resolve, reject) => {
+      this.pending.set(id, { resolve, reject });
+      this.post({ type: "call", id, name, args });
+    });
+  }
+
+  /** Stops the worker, rejecting pending scripts */
+  terminate() {
+    this.worker.terminate();
+    for (const call of this.pending.values()) {
+      call.reject(new Error("worker terminated"));
+    }
+    this.pending.clear();
+  }
+{{- range .Functions}}
+
+  {{.Name}}(...args: Parameters<CadenceService["{{.Name}}"]>): ReturnType<CadenceService["{{.Name}}"]> {
+    {{- if eq .Type "query"}}
+    return this.call("{{.Name}}", args);
+    {{- else}}
+    return this.service.{{.Name}}(...args);
+    {{- end}}
+  }
+{{- end}}
+}
+`
+
+// GenerateWorker generates a Web Worker module running the scripts of the
+// service and a main-thread proxy forwarding script calls to it. importPath is
+// the module path of the generated service relative to both modules.
+func (g *Generator) GenerateWorker(importPath string) (string, string, error) {
+	functions, taggedFunctions, tagNames := g.buildFunctions()
+	for _, tag := range tagNames {
+		functions = append(functions, taggedFunctions[tag]...)
+	}
+
+	data := struct {
+		ImportPath string
+		Functions  []TypeScriptFunction
+	}{
+		ImportPath: importPath,
+		Functions:  functions,
+	}
+
+	var worker, proxy bytes.Buffer
+	tmpl, err := template.New("worker").Parse(workerTemplate)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse worker template: %w", err)
+	}
+	if err := tmpl.Execute(&worker, data); err != nil {
+		return "", "", fmt.Errorf("failed to execute worker template: %w", err)
+	}
+	tmpl, err = template.New("proxy").Parse(workerProxyTemplate)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse worker proxy template: %w", err)
+	}
+	if err := tmpl.Execute(&proxy, data); err != nil {
+		return "", "", fmt.Errorf("failed to execute worker proxy template: %w", err)
+	}
+	return worker.String(), proxy.String(), nil
+}
@@ -0,0 +1,201 @@
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// NodeKind is the kind of a node in the dependency graph
+type NodeKind string
+
+const (
+	KindTransaction NodeKind = "transaction"
+	KindScript      NodeKind = "script"
+	KindContract    NodeKind = "contract"
+	KindStruct      NodeKind = "struct"
+)
+
+// EdgeKind is the kind of a dependency between two nodes
+type EdgeKind string
+
+const (
+	EdgeImports  EdgeKind = "imports"  // A script or transaction imports a contract
+	EdgeUses     EdgeKind = "uses"     // A script, transaction or struct references a struct type
+	EdgeDeclares EdgeKind = "declares" // A contract declares a nested struct type
+)
+
+// Node represents a script, transaction, contract or struct type
+type Node struct {
+	ID      string   `json:"id"`
+	Kind    NodeKind `json:"kind"`
+	Label   string   `json:"label"`
+	Address string   `json:"address,omitempty"`
+	Tag     string   `json:"tag,omitempty"`
+	// Resolved reports whether a struct definition is present in the report
+	Resolved bool `json:"resolved,omitempty"`
+}
+
+// Edge represents a dependency between two nodes
+type Edge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Kind EdgeKind `json:"kind"`
+}
+
+// Graph is the dependency graph of a report
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// typeNamePattern matches the (possibly qualified) type names in a type string
+var typeNamePattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*`)
+
+// builder collects nodes and edges without duplicates
+type builder struct {
+	report analyzer.Report
+	nodes  map[string]Node
+	edges  map[Edge]bool
+}
+
+// Build creates the dependency graph of scripts and transactions, the
+// contracts they import and the struct types they reference
+func Build(report analyzer.Report) *Graph {
+	b := &builder{
+		report: report,
+		nodes:  make(map[string]Node),
+		edges:  make(map[Edge]bool),
+	}
+
+	b.addResults(report.Transactions, KindTransaction)
+	b.addResults(report.Scripts, KindScript)
+
+	// Struct definitions reference other structs through their fields
+	for _, composite := range report.Structs {
+		from := b.addStruct(composite.Name)
+		for _, field := range composite.Fields {
+			b.addTypeReferences(from, field.TypeStr)
+		}
+	}
+
+	graph := &Graph{Nodes: make([]Node, 0), Edges: make([]Edge, 0)}
+	for _, node := range b.nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].ID < graph.Nodes[j].ID
+	})
+	for edge := range b.edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From != graph.Edges[j].From {
+			return graph.Edges[i].From < graph.Edges[j].From
+		}
+		return graph.Edges[i].To < graph.Edges[j].To
+	})
+	return graph
+}
+
+// addResults adds the nodes and edges of analyzed scripts or transactions
+func (b *builder) addResults(results map[string]analyzer.AnalysisResult, kind NodeKind) {
+	for filename, result := range results {
+		id := string(kind) + ":" + filename
+		b.nodes[id] = Node{ID: id, Kind: kind, Label: filename, Tag: result.Tag}
+
+		for _, imp := range result.Imports {
+			contract := b.addContract(imp.Contract, imp.Address)
+			b.edges[Edge{From: id, To: contract, Kind: EdgeImports}] = true
+		}
+		for _, param := range result.Parameters {
+			b.addTypeReferences(id, param.TypeStr)
+		}
+		if result.ReturnType != "" {
+			b.addTypeReferences(id, result.ReturnType)
+		}
+	}
+}
+
+// addContract adds a contract node and returns its ID
+func (b *builder) addContract(name string, address string) string {
+	id := "contract:" + name
+	node, ok := b.nodes[id]
+	if !ok {
+		node = Node{ID: id, Kind: KindContract, Label: name}
+	}
+	if node.Address == "" {
+		node.Address = address
+	}
+	b.nodes[id] = node
+	return id
+}
+
+// addStruct adds a struct node, and the contract declaring it for nested
+// types, and returns its ID
+func (b *builder) addStruct(name string) string {
+	id := "struct:" + name
+	if _, ok := b.nodes[id]; ok {
+		return id
+	}
+	_, resolved := b.report.Structs[strings.ReplaceAll(name, ".", "")]
+	b.nodes[id] = Node{ID: id, Kind: KindStruct, Label: name, Resolved: resolved}
+
+	if idx := strings.Index(name, "."); idx > 0 {
+		contract := b.addContract(name[:idx], "")
+		b.edges[Edge{From: contract, To: id, Kind: EdgeDeclares}] = true
+	}
+	return id
+}
+
+// addTypeReferences adds edges from a node to the struct types referenced in a type string.
+// Nested types are always included, other types only if their definition is in the report.
+func (b *builder) addTypeReferences(from string, typeStr string) {
+	for _, name := range typeNamePattern.FindAllString(typeStr, -1) {
+		_, known := b.report.Structs[strings.ReplaceAll(name, ".", "")]
+		if !known && !strings.Contains(name, ".") {
+			continue
+		}
+		to := b.addStruct(name)
+		if to != from {
+			b.edges[Edge{From: from, To: to, Kind: EdgeUses}] = true
+		}
+	}
+}
+
+// DOT renders the graph in the Graphviz DOT format
+func (g *Graph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph dependencies {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [fontname=\"Helvetica\"];\n")
+	for _, node := range g.Nodes {
+		label := node.Label
+		if node.Address != "" {
+			label += "\n" + node.Address
+		}
+		attrs := []string{fmt.Sprintf("label=%q", label)}
+		switch node.Kind {
+		case KindTransaction:
+			attrs = append(attrs, "shape=box", "style=filled", "fillcolor=\"#fde2c8\"")
+		case KindScript:
+			attrs = append(attrs, "shape=box", "style=filled", "fillcolor=\"#cfe8fc\"")
+		case KindContract:
+			attrs = append(attrs, "shape=component")
+		case KindStruct:
+			attrs = append(attrs, "shape=ellipse")
+			if !node.Resolved {
+				attrs = append(attrs, "style=dashed")
+			}
+		}
+		sb.WriteString(fmt.Sprintf("  %q [%s];\n", node.ID, strings.Join(attrs, ", ")))
+	}
+	for _, edge := range g.Edges {
+		sb.WriteString(fmt.Sprintf("  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Kind))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// CheckSource parses Cadence source code and returns its syntax errors,
+// using filePath for reporting
+func CheckSource(filePath string, content []byte) []Diagnostic {
+	_, _, _, diagnostics := parse(filePath, content)
+	return diagnostics
+}
+
+// CheckDirectory parses every .cdc file of a directory and its
+// subdirectories, or a single file, and returns the syntax errors sorted by
+// position. It also returns the number of files checked.
+func CheckDirectory(dirPath string) ([]Diagnostic, int, error) {
+	var diagnostics []Diagnostic
+	files := 0
+	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && filepath.Ext(path) == ".cdc" {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read file: %w", err)
+			}
+			files++
+			diagnostics = append(diagnostics, CheckSource(path, content)...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].File != diagnostics[j].File {
+			return diagnostics[i].File < diagnostics[j].File
+		}
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+	return diagnostics, files, nil
+}
@@ -0,0 +1,563 @@
+package lint
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// Severity describes how serious a diagnostic is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Rule names reported in diagnostics
+const (
+	RuleSyntax          = "syntax"
+	RuleNoEntryPoint    = "no-entry-point"
+	RuleParameterNaming = "parameter-naming"
+	RuleScriptReturn    = "script-return-type"
+	RuleScriptNoMain    = "script-no-main"
+	RuleUnusedImport    = "unused-import"
+	RuleMissingImport   = "missing-import"
+	RuleMisplacedFile   = "misplaced-file"
+	RuleCodeSize        = "code-size"
+	RuleParameterCount  = "parameter-count"
+	RuleUnusedParameter = "unused-parameter"
+	RuleDuplicateCode   = "duplicate-code"
+)
+
+// DefaultMaxCodeSize is the default limit in bytes for embedded Cadence code
+const DefaultMaxCodeSize = 8 * 1024
+
+// DefaultMaxParameters is the default limit of parameters of a script or transaction
+const DefaultMaxParameters = 8
+
+// Diagnostic represents a single lint finding
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+	Fixable  bool     `json:"fixable,omitempty"`
+}
+
+// String formats the diagnostic as file:line:column: severity [rule] message
+func (d Diagnostic) String() string {
+	location := d.File
+	if d.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+	}
+	return fmt.Sprintf("%s: %s [%s] %s", location, d.Severity, d.Rule, d.Message)
+}
+
+// Linter checks Cadence files against codegen conventions
+type Linter struct {
+	MaxCodeSize   int
+	MaxParameters int
+	// Root is the directory that directory-based rules look below; empty
+	// means the file paths are already relative to it
+	Root string
+}
+
+// New creates a new Linter with default settings
+func New() *Linter {
+	return &Linter{
+		MaxCodeSize:   DefaultMaxCodeSize,
+		MaxParameters: DefaultMaxParameters,
+	}
+}
+
+// SetMaxCodeSize sets the size limit in bytes for embedded Cadence code
+func (l *Linter) SetMaxCodeSize(size int) {
+	l.MaxCodeSize = size
+}
+
+// SetMaxParameters sets the limit of parameters of a script or transaction
+func (l *Linter) SetMaxParameters(count int) {
+	l.MaxParameters = count
+}
+
+// SetRoot sets the directory that directory-based rules look below
+func (l *Linter) SetRoot(root string) {
+	l.Root = root
+}
+
+// camelCasePattern matches lowerCamelCase identifiers
+var camelCasePattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+// LintFile lints a single Cadence file
+func (l *Linter) LintFile(filePath string) ([]Diagnostic, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return l.LintSource(filePath, content), nil
+}
+
+// newDiagnostic creates a diagnostic at pos of filePath. Cadence columns are
+// 0-based, editors expect 1-based columns.
+func newDiagnostic(filePath string, pos ast.Position, severity Severity, rule string, message string) Diagnostic {
+	column := 0
+	if pos.Line > 0 {
+		column = pos.Column + 1
+	}
+	return Diagnostic{
+		File:     filePath,
+		Line:     pos.Line,
+		Column:   column,
+		Severity: severity,
+		Rule:     rule,
+		Message:  message,
+	}
+}
+
+// parse normalizes and parses Cadence source code. It returns the normalized
+// content, the code without imports and the program, or the syntax errors.
+func parse(filePath string, content []byte) ([]byte, []byte, *ast.Program, []Diagnostic) {
+	content, err := analyzer.NormalizeSource(content)
+	if err != nil {
+		return nil, nil, nil, []Diagnostic{newDiagnostic(filePath, ast.Position{}, SeverityError, RuleSyntax, err.Error())}
+	}
+
+	_, code := analyzer.ExtractImports(content)
+
+	program, err := parser.ParseProgram(&analyzer.SimpleMemoryGauge{}, code, parser.Config{})
+	if err != nil {
+		var diagnostics []Diagnostic
+		var parseErr parser.Error
+		if errors.As(err, &parseErr) {
+			for _, childErr := range parseErr.Errors {
+				var pos ast.Position
+				if positioned, ok := childErr.(interface{ StartPosition() ast.Position }); ok {
+					pos = positioned.StartPosition()
+				}
+				diagnostics = append(diagnostics, newDiagnostic(filePath, pos, SeverityError, RuleSyntax, childErr.Error()))
+			}
+		} else {
+			diagnostics = append(diagnostics, newDiagnostic(filePath, ast.Position{}, SeverityError, RuleSyntax, err.Error()))
+		}
+		return nil, nil, nil, diagnostics
+	}
+	return content, code, program, nil
+}
+
+// LintSource lints Cadence source code, using filePath for reporting and,
+// relative to the root, for directory-based rules
+func (l *Linter) LintSource(filePath string, content []byte) []Diagnostic {
+	relPath := filePath
+	if l.Root != "" {
+		if rel, err := filepath.Rel(l.Root, filePath); err == nil && !strings.HasPrefix(rel, "..") {
+			relPath = rel
+			// Files the analyzer excludes with the .codegenignore of the root are not linted
+			ignore, err := analyzer.LoadIgnoreFile(filepath.Join(l.Root, analyzer.IgnoreFileName))
+			if err == nil && ignore.Match(filepath.ToSlash(rel), false) {
+				return nil
+			}
+		}
+	}
+	return l.lintSource(filePath, relPath, content)
+}
+
+// lintSource lints Cadence source code reported as filePath and located at
+// relPath below the root
+func (l *Linter) lintSource(filePath string, relPath string, content []byte) []Diagnostic {
+	// Files opting out of code generation are not held to its conventions
+	if _, skipped := analyzer.Pragma(content, "skip"); skipped {
+		return nil
+	}
+
+	var diagnostics []Diagnostic
+	report := func(pos ast.Position, severity Severity, rule string, format string, args ...interface{}) {
+		diagnostics = append(diagnostics, newDiagnostic(filePath, pos, severity, rule, fmt.Sprintf(format, args...)))
+	}
+
+	if l.exceedsCodeSize(len(content)) {
+		report(ast.Position{}, SeverityWarning, RuleCodeSize, "%s", l.codeSizeMessage(len(content)))
+	}
+
+	content, code, program, syntaxErrors := parse(filePath, content)
+	if len(syntaxErrors) > 0 {
+		return append(diagnostics, syntaxErrors...)
+	}
+
+	// Unused imports: the contract name should be referenced outside of comments and strings
+	imported := make(map[string]bool)
+	for _, contract := range analyzer.ImportedContracts(content) {
+		imported[contract] = true
+		if !analyzer.References(code, contract) {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     filePath,
+				Line:     importLine(content, contract),
+				Column:   1,
+				Severity: SeverityWarning,
+				Rule:     RuleUnusedImport,
+				Message:  fmt.Sprintf("import %s is never used", contract),
+				Fixable:  true,
+			})
+		}
+	}
+
+	// Missing imports: members of contracts that are neither imported nor declared
+	declared := make(map[string]bool)
+	for _, declaration := range program.Declarations() {
+		if identifier := declaration.DeclarationIdentifier(); identifier != nil {
+			declared[identifier.Identifier] = true
+		}
+	}
+	for _, reference := range analyzer.ReferencedContainers(code) {
+		if !imported[reference.Name] && !declared[reference.Name] {
+			report(offsetPosition(code, reference.Offset), SeverityWarning, RuleMissingImport,
+				"%s is referenced but not imported", reference.Name)
+		}
+	}
+
+	// Find the entry points the same way the analyzer does
+	var transaction *ast.TransactionDeclaration
+	for _, declaration := range program.Declarations() {
+		if decl, ok := declaration.(*ast.TransactionDeclaration); ok {
+			transaction = decl
+			break
+		}
+	}
+	var functions []*ast.FunctionDeclaration
+	if transaction == nil {
+		var err error
+		if functions, err = analyzer.ScriptEntryPoints(content, program); err != nil {
+			report(ast.Position{}, SeverityError, RuleNoEntryPoint, "%s", err.Error())
+			return diagnostics
+		}
+	}
+
+	var parameters []*ast.Parameter
+	var kind string
+	switch {
+	case transaction != nil:
+		kind = "transaction"
+		if transaction.ParameterList != nil {
+			parameters = transaction.ParameterList.Parameters
+		}
+		unused := make(map[string]bool)
+		for _, name := range analyzer.UnusedParameters(transaction) {
+			unused[name] = true
+		}
+		for _, param := range parameters {
+			if unused[param.Identifier.String()] {
+				report(param.StartPos, SeverityWarning, RuleUnusedParameter, "%s", unusedParameterMessage(param.Identifier.String()))
+			}
+		}
+		if l.exceedsParameters(len(parameters)) {
+			report(ast.Position{}, SeverityWarning, RuleParameterCount, "%s", l.parametersMessage(kind, len(parameters)))
+		}
+	case len(functions) > 0:
+		kind = "script"
+		// A single function with an access modifier is used as the entry point
+		// of a file without main by accident more often than by design, files
+		// with several entry points or a // codegen:entry pragma are deliberate
+		_, selected := analyzer.Pragma(content, "entry")
+		if name := functions[0].Identifier.String(); len(functions) == 1 && name != "main" && !selected {
+			report(functions[0].StartPosition(), SeverityWarning, RuleScriptNoMain,
+				"script has no main function, %s is used as the entry point; add // codegen:entry %s to make it explicit", name, name)
+		}
+		for _, function := range functions {
+			var functionParameters []*ast.Parameter
+			if function.ParameterList != nil {
+				functionParameters = function.ParameterList.Parameters
+			}
+			parameters = append(parameters, functionParameters...)
+			if function.ReturnTypeAnnotation == nil {
+				report(function.StartPosition(), SeverityWarning, RuleScriptReturn,
+					"script %s has no return type, generated bindings will not be typed", function.Identifier.String())
+			} else if err := analyzer.CheckReturnType(function); err != nil {
+				report(function.StartPosition(), SeverityError, RuleScriptReturn, "%s", err.Error())
+			}
+			if l.exceedsParameters(len(functionParameters)) {
+				report(function.StartPosition(), SeverityWarning, RuleParameterCount, "%s", l.parametersMessage(kind, len(functionParameters)))
+			}
+		}
+	case declaresContract(program):
+		// Contracts are deployed rather than called, they have no entry point
+		return diagnostics
+	default:
+		report(ast.Position{}, SeverityError, RuleNoEntryPoint, "no transaction or script found in file")
+		return diagnostics
+	}
+
+	for _, param := range parameters {
+		name := param.Identifier.String()
+		if !camelCasePattern.MatchString(name) {
+			report(param.StartPos, SeverityWarning, RuleParameterNaming,
+				"parameter %s should be lowerCamelCase", name)
+		}
+	}
+
+	// Transactions under scripts/ and scripts under transactions/ are confusing
+	for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(relPath)), "/") {
+		dir = strings.ToLower(dir)
+		if kind == "transaction" && (dir == "scripts" || dir == "script") {
+			report(ast.Position{}, SeverityWarning, RuleMisplacedFile, "transaction file is located in a %s directory", dir)
+		}
+		if kind == "script" && (dir == "transactions" || dir == "transaction") {
+			report(ast.Position{}, SeverityWarning, RuleMisplacedFile, "script file is located in a %s directory", dir)
+		}
+	}
+
+	return diagnostics
+}
+
+// LintDirectory lints all Cadence files in a directory and its subdirectories,
+// reporting files with duplicate code as well
+func (l *Linter) LintDirectory(dirPath string) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	files := make(map[string][]byte)
+	// Paths the analyzer excludes with the .codegenignore of the root are skipped
+	ignore := &analyzer.IgnoreFile{}
+	if info, err := os.Stat(dirPath); err == nil && info.IsDir() {
+		if ignore, err = analyzer.LoadIgnoreFile(filepath.Join(dirPath, analyzer.IgnoreFileName)); err != nil {
+			return nil, err
+		}
+	}
+	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Only directories below dirPath tell scripts and transactions apart
+		relPath, err := filepath.Rel(dirPath, path)
+		if err != nil {
+			relPath = path
+		}
+		if relPath != "." && ignore.Match(filepath.ToSlash(relPath), info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !info.IsDir() && filepath.Ext(path) == ".cdc" {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read file: %w", err)
+			}
+			if _, skipped := analyzer.Pragma(content, "skip"); !skipped {
+				files[path] = content
+			}
+			diagnostics = append(diagnostics, l.lintSource(path, relPath, content)...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	diagnostics = append(diagnostics, duplicates(files)...)
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].File != diagnostics[j].File {
+			return diagnostics[i].File < diagnostics[j].File
+		}
+		return diagnostics[i].Line < diagnostics[j].Line
+	})
+
+	return diagnostics, nil
+}
+
+// Duplicates reports the scripts and transactions of report whose code is
+// near-identical to others, e.g. copies differing only in hard-coded addresses
+func Duplicates(report analyzer.Report) []Diagnostic {
+	files := make(map[string][]byte)
+	for _, results := range []map[string]analyzer.AnalysisResult{report.Scripts, report.Transactions} {
+		for _, result := range results {
+			code, err := base64.StdEncoding.DecodeString(result.Base64)
+			if err != nil || len(code) == 0 {
+				continue
+			}
+			files[result.SourcePath()] = code
+		}
+	}
+	diagnostics := duplicates(files)
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].File < diagnostics[j].File
+	})
+	return diagnostics
+}
+
+// duplicates reports every file of files, by path, whose normalized code
+// hash is shared with other files
+func duplicates(files map[string][]byte) []Diagnostic {
+	groups := make(map[string][]string)
+	for path, content := range files {
+		hash := analyzer.NormalizedHash(content)
+		groups[hash] = append(groups[hash], path)
+	}
+
+	var diagnostics []Diagnostic
+	for _, paths := range groups {
+		if len(paths) < 2 {
+			continue
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			var others []string
+			for _, other := range paths {
+				if other != path {
+					others = append(others, other)
+				}
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     path,
+				Severity: SeverityWarning,
+				Rule:     RuleDuplicateCode,
+				Message:  fmt.Sprintf("code is near-identical to %s, consider consolidating them", strings.Join(others, ", ")),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// CheckBudget reports the scripts and transactions of report whose embedded
+// code or parameter list exceeds the limits of the linter, e.g. to warn while
+// generating code from a report
+func (l *Linter) CheckBudget(report analyzer.Report) []Diagnostic {
+	var diagnostics []Diagnostic
+	for kind, results := range map[string]map[string]analyzer.AnalysisResult{"script": report.Scripts, "transaction": report.Transactions} {
+		for _, result := range results {
+			code, err := base64.StdEncoding.DecodeString(result.Base64)
+			if err == nil && l.exceedsCodeSize(len(code)) {
+				diagnostics = append(diagnostics, Diagnostic{
+					File:     result.SourcePath(),
+					Severity: SeverityWarning,
+					Rule:     RuleCodeSize,
+					Message:  l.codeSizeMessage(len(code)),
+				})
+			}
+			if l.exceedsParameters(len(result.Parameters)) {
+				diagnostics = append(diagnostics, Diagnostic{
+					File:     result.SourcePath(),
+					Severity: SeverityWarning,
+					Rule:     RuleParameterCount,
+					Message:  l.parametersMessage(kind, len(result.Parameters)),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].File != diagnostics[j].File {
+			return diagnostics[i].File < diagnostics[j].File
+		}
+		return diagnostics[i].Rule < diagnostics[j].Rule
+	})
+	return diagnostics
+}
+
+// UnusedParameters reports the transaction parameters of report that are
+// never used in the transaction body, which generated functions still expose
+func UnusedParameters(report analyzer.Report) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, result := range report.Transactions {
+		for _, name := range result.UnusedParameters {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     result.SourcePath(),
+				Severity: SeverityWarning,
+				Rule:     RuleUnusedParameter,
+				Message:  unusedParameterMessage(name),
+			})
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].File != diagnostics[j].File {
+			return diagnostics[i].File < diagnostics[j].File
+		}
+		return diagnostics[i].Message < diagnostics[j].Message
+	})
+	return diagnostics
+}
+
+func unusedParameterMessage(name string) string {
+	return fmt.Sprintf("parameter %s is never used in the transaction body", name)
+}
+
+func (l *Linter) exceedsCodeSize(size int) bool {
+	return l.MaxCodeSize > 0 && size > l.MaxCodeSize
+}
+
+func (l *Linter) codeSizeMessage(size int) string {
+	return fmt.Sprintf("embedded code is %d bytes, exceeding the limit of %d bytes", size, l.MaxCodeSize)
+}
+
+func (l *Linter) exceedsParameters(count int) bool {
+	return l.MaxParameters > 0 && count > l.MaxParameters
+}
+
+func (l *Linter) parametersMessage(kind string, count int) string {
+	return fmt.Sprintf("%s has %d parameters, exceeding the limit of %d, consider splitting it", kind, count, l.MaxParameters)
+}
+
+// declaresContract reports whether program declares a contract or contract interface
+func declaresContract(program *ast.Program) bool {
+	for _, declaration := range program.Declarations() {
+		switch decl := declaration.(type) {
+		case *ast.CompositeDeclaration:
+			if decl.CompositeKind == common.CompositeKindContract {
+				return true
+			}
+		case *ast.InterfaceDeclaration:
+			if decl.CompositeKind == common.CompositeKindContract {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasErrors reports whether any diagnostic has error severity
+func HasErrors(diagnostics []Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// offsetPosition converts a byte offset of code into a parser position
+func offsetPosition(code []byte, offset int) ast.Position {
+	line := 1 + strings.Count(string(code[:offset]), "\n")
+	column := offset - (strings.LastIndexByte(string(code[:offset]), '\n') + 1)
+	return ast.Position{Offset: offset, Line: line, Column: column}
+}
+
+// importLine returns the 1-based line of the import of the given contract
+func importLine(content []byte, contract string) int {
+	for i, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(strings.ReplaceAll(line, ",", " "))
+		if len(fields) < 2 || fields[0] != "import" {
+			continue
+		}
+		for _, field := range fields[1:] {
+			if strings.Trim(field, `"`) == contract {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,144 @@
+package listing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// Interaction is a script or transaction with its signature
+type Interaction struct {
+	FileName   string               `json:"fileName"`
+	Type       string               `json:"type"` // script or transaction
+	Tag        string               `json:"tag,omitempty"`
+	Parameters []analyzer.Parameter `json:"parameters"`
+	ReturnType string               `json:"returnType,omitempty"`
+	Signers    int                  `json:"signers,omitempty"`
+	Signature  string               `json:"signature"` // e.g. (address: Address): UFix64
+}
+
+// Struct is a struct type with its fields
+type Struct struct {
+	Name   string           `json:"name"`
+	Fields []analyzer.Field `json:"fields"`
+}
+
+// Tag is a tag and the number of interactions it groups
+type Tag struct {
+	Name         string `json:"name"`
+	Interactions int    `json:"interactions"`
+}
+
+// Listing enumerates the interactions, structs and tags of a report, with
+// every list sorted by name
+type Listing struct {
+	Interactions []Interaction `json:"interactions"`
+	Structs      []Struct      `json:"structs"`
+	Tags         []Tag         `json:"tags"`
+}
+
+// Build lists the scripts, transactions, structs and tags of report
+func Build(report analyzer.Report) *Listing {
+	listing := &Listing{Interactions: []Interaction{}, Structs: []Struct{}, Tags: []Tag{}}
+	tags := make(map[string]int)
+	for _, results := range []map[string]analyzer.AnalysisResult{report.Transactions, report.Scripts} {
+		for fileName, result := range results {
+			parameters := result.Parameters
+			if parameters == nil {
+				parameters = []analyzer.Parameter{}
+			}
+			listing.Interactions = append(listing.Interactions, Interaction{
+				FileName:   fileName,
+				Type:       result.Type,
+				Tag:        result.Tag,
+				Parameters: parameters,
+				ReturnType: result.ReturnType,
+				Signers:    len(result.Signers),
+				Signature:  signature(result),
+			})
+			if result.Tag != "" {
+				tags[result.Tag]++
+			}
+		}
+	}
+	sort.Slice(listing.Interactions, func(i, j int) bool {
+		if listing.Interactions[i].Type != listing.Interactions[j].Type {
+			return listing.Interactions[i].Type > listing.Interactions[j].Type
+		}
+		return listing.Interactions[i].FileName < listing.Interactions[j].FileName
+	})
+
+	for name, composite := range report.Structs {
+		fields := composite.Fields
+		if fields == nil {
+			fields = []analyzer.Field{}
+		}
+		listing.Structs = append(listing.Structs, Struct{Name: name, Fields: fields})
+	}
+	sort.Slice(listing.Structs, func(i, j int) bool { return listing.Structs[i].Name < listing.Structs[j].Name })
+
+	for name, count := range tags {
+		listing.Tags = append(listing.Tags, Tag{Name: name, Interactions: count})
+	}
+	sort.Slice(listing.Tags, func(i, j int) bool { return listing.Tags[i].Name < listing.Tags[j].Name })
+	return listing
+}
+
+// signature renders the parameters and return type of result
+func signature(result analyzer.AnalysisResult) string {
+	var params []string
+	for _, param := range result.Parameters {
+		params = append(params, param.Name+": "+param.TypeStr)
+	}
+	signature := "(" + strings.Join(params, ", ") + ")"
+	if result.ReturnType != "" {
+		signature += ": " + result.ReturnType
+	}
+	return signature
+}
+
+// Table renders the listing as human readable tables
+func (l *Listing) Table() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "TYPE\tFILE\tTAG\tSIGNATURE")
+	for _, interaction := range l.Interactions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", interaction.Type, interaction.FileName, orDash(interaction.Tag), interaction.Signature)
+	}
+	w.Flush()
+
+	if len(l.Structs) > 0 {
+		sb.WriteString("\n")
+		fmt.Fprintln(w, "STRUCT\tFIELDS")
+		for _, s := range l.Structs {
+			var fields []string
+			for _, field := range s.Fields {
+				fields = append(fields, field.Name+": "+field.TypeStr)
+			}
+			fmt.Fprintf(w, "%s\t%s\n", s.Name, strings.Join(fields, ", "))
+		}
+		w.Flush()
+	}
+
+	if len(l.Tags) > 0 {
+		sb.WriteString("\n")
+		fmt.Fprintln(w, "TAG\tINTERACTIONS")
+		for _, tag := range l.Tags {
+			fmt.Fprintf(w, "%s\t%d\n", tag.Name, tag.Interactions)
+		}
+		w.Flush()
+	}
+	return sb.String()
+}
+
+// orDash renders an empty value as "-"
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
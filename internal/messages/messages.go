@@ -0,0 +1,117 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// DefaultFile is the default path of the messages catalog
+const DefaultFile = "messages.json"
+
+// Catalog holds the user-facing messages of the interactions in one locale,
+// e.g. transfer_flow.cdc -> title -> Transfer FLOW, ready to be translated
+type Catalog struct {
+	Locale   string                       `json:"locale"`
+	Messages map[string]map[string]string `json:"messages"` // file name -> key -> message
+}
+
+// Extract collects the messages of the scripts and transactions of report,
+// which are in the default locale
+func Extract(report analyzer.Report) *Catalog {
+	catalog := &Catalog{Locale: analyzer.FLIXDefaultLocale, Messages: make(map[string]map[string]string)}
+	for _, results := range []map[string]analyzer.AnalysisResult{report.Scripts, report.Transactions} {
+		for fileName, result := range results {
+			if len(result.Messages) > 0 {
+				catalog.Messages[fileName] = result.Messages
+			}
+		}
+	}
+	return catalog
+}
+
+// Save writes the catalog to path
+func (c *Catalog) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal messages: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write messages catalog: %w", err)
+	}
+	return nil
+}
+
+// LoadBundles reads the catalogs translated into other locales from the .json
+// files of dir, e.g. fr-FR.json. The locale of a catalog without one is its
+// file name.
+func LoadBundles(dir string) ([]*Catalog, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message bundles: %w", err)
+	}
+	sort.Strings(paths)
+	var bundles []*Catalog
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message bundle: %w", err)
+		}
+		catalog := &Catalog{}
+		if err := json.Unmarshal(data, catalog); err != nil {
+			return nil, fmt.Errorf("failed to parse message bundle %s: %w", path, err)
+		}
+		if catalog.Locale == "" {
+			catalog.Locale = strings.TrimSuffix(filepath.Base(path), ".json")
+		}
+		bundles = append(bundles, catalog)
+	}
+	return bundles, nil
+}
+
+// Merge adds the messages of the bundle to the translations of the scripts
+// and transactions of report, replacing those of FLIX templates. It returns
+// the file names of the bundle matching no script or transaction, sorted.
+// Bundles in the default locale are skipped, since the code declares those
+// messages.
+func (c *Catalog) Merge(report *analyzer.Report) []string {
+	if c.Locale == analyzer.FLIXDefaultLocale {
+		return nil
+	}
+	var unknown []string
+	for fileName, messages := range c.Messages {
+		matched := false
+		for _, results := range []map[string]analyzer.AnalysisResult{report.Scripts, report.Transactions} {
+			result, ok := results[fileName]
+			if !ok {
+				continue
+			}
+			matched = true
+			// Results of the same file can share their translations
+			translations := make(map[string]map[string]string)
+			for locale, translated := range result.Translations {
+				translations[locale] = translated
+			}
+			merged := make(map[string]string)
+			for key, message := range translations[c.Locale] {
+				merged[key] = message
+			}
+			for key, message := range messages {
+				merged[key] = message
+			}
+			translations[c.Locale] = merged
+			result.Translations = translations
+			results[fileName] = result
+		}
+		if !matched {
+			unknown = append(unknown, fileName)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
@@ -0,0 +1,205 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/lint"
+)
+
+// Rule describes a pre-1.0 syntax pattern and how to migrate it
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+	Message string
+	// Replacement is used to rewrite matches; empty means the rule can only be reported
+	Replacement string
+}
+
+// rules lists the pre-1.0 constructs detected by the migration assistant
+var rules = []Rule{
+	{
+		Name:        "pub-set-access",
+		Pattern:     regexp.MustCompile(`\bpub\(set\)\s+`),
+		Message:     "pub(set) was removed in Cadence 1.0, use access(all) with a setter function",
+		Replacement: "access(all) ",
+	},
+	{
+		Name:        "pub-access",
+		Pattern:     regexp.MustCompile(`\bpub\s+`),
+		Message:     "pub was removed in Cadence 1.0, use access(all)",
+		Replacement: "access(all) ",
+	},
+	{
+		Name:        "priv-access",
+		Pattern:     regexp.MustCompile(`\bpriv\s+`),
+		Message:     "priv was removed in Cadence 1.0, use access(self)",
+		Replacement: "access(self) ",
+	},
+	{
+		Name:        "auth-account-type",
+		Pattern:     regexp.MustCompile(`\bAuthAccount\b`),
+		Message:     "AuthAccount was replaced by entitled &Account references, narrow the entitlements after migrating",
+		Replacement: "auth(Storage, Contracts, Keys, Inbox, Capabilities) &Account",
+	},
+	{
+		Name:        "public-account-type",
+		Pattern:     regexp.MustCompile(`\bPublicAccount\b`),
+		Message:     "PublicAccount was replaced by &Account",
+		Replacement: "&Account",
+	},
+	{
+		Name:    "capability-api",
+		Pattern: regexp.MustCompile(`\.(link|unlink|getCapability|getLinkTarget)\s*[<(]`),
+		Message: "linking capability APIs were removed in Cadence 1.0, use account.capabilities and capability controllers",
+	},
+	{
+		Name:    "custom-destructor",
+		Pattern: regexp.MustCompile(`\bdestroy\s*\(\s*\)\s*\{`),
+		Message: "custom destructors were removed in Cadence 1.0, use a ResourceDestroyed event instead",
+	},
+	{
+		Name:    "restricted-type",
+		Pattern: regexp.MustCompile(`[@&][A-Za-z_][\w.]*\{[\w.,\s]+\}`),
+		Message: "restricted types were replaced by intersection types (e.g. &{FungibleToken.Balance})",
+	},
+}
+
+// accountStorageFunctions are the storage functions that moved under account.storage
+var accountStorageFunctions = []string{"save", "load", "copy", "borrow", "type", "forEachStored"}
+
+// authAccountParamPattern finds identifiers declared with the AuthAccount type
+var authAccountParamPattern = regexp.MustCompile(`\b([A-Za-z_]\w*)\s*:\s*AuthAccount\b`)
+
+// Result holds the findings and rewritten source of a single file
+type Result struct {
+	Diagnostics []lint.Diagnostic
+	Content     []byte
+	Changed     bool
+}
+
+// edit replaces a span of a source line
+type edit struct {
+	start, end int
+	text       string
+}
+
+// MigrateSource detects pre-1.0 syntax in Cadence source code and returns the
+// findings together with the rewritten code for every fixable finding.
+// Comments and string literals are neither reported nor rewritten.
+func MigrateSource(filePath string, content []byte) *Result {
+	result := &Result{}
+	lines := strings.Split(string(content), "\n")
+	codeLines := strings.Split(string(analyzer.StripCommentsAndStrings(content)), "\n")
+
+	// Storage functions called on AuthAccount-typed identifiers move to account.storage
+	accountRules := make([]Rule, 0)
+	for _, match := range authAccountParamPattern.FindAllStringSubmatch(strings.Join(codeLines, "\n"), -1) {
+		name := match[1]
+		accountRules = append(accountRules, Rule{
+			Name:        "account-storage-api",
+			Pattern:     regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\.(` + strings.Join(accountStorageFunctions, "|") + `)\b`),
+			Message:     "account storage functions moved to account.storage in Cadence 1.0",
+			Replacement: name + ".storage.$1",
+		})
+	}
+
+	for i, line := range lines {
+		code := codeLines[i]
+
+		var edits []edit
+		for _, rule := range append(accountRules, rules...) {
+			for _, loc := range rule.Pattern.FindAllStringSubmatchIndex(code, -1) {
+				// A match running into a blanked comment or string, like the
+				// whitespace of `pub /* c */ fun`, is reported but left alone
+				fixable := rule.Replacement != "" && line[loc[0]:loc[1]] == code[loc[0]:loc[1]]
+				severity := lint.SeverityError
+				if rule.Replacement != "" {
+					severity = lint.SeverityWarning
+				}
+				result.Diagnostics = append(result.Diagnostics, lint.Diagnostic{
+					File:     filePath,
+					Line:     i + 1,
+					Column:   loc[0] + 1,
+					Severity: severity,
+					Rule:     rule.Name,
+					Message:  rule.Message,
+					Fixable:  fixable,
+				})
+				if fixable && !overlaps(edits, loc[0], loc[1]) {
+					edits = append(edits, edit{
+						start: loc[0],
+						end:   loc[1],
+						text:  string(rule.Pattern.ExpandString(nil, rule.Replacement, code, loc)),
+					})
+				}
+			}
+		}
+		if len(edits) == 0 {
+			continue
+		}
+
+		sort.Slice(edits, func(a, b int) bool { return edits[a].start > edits[b].start })
+		rewritten := line
+		for _, e := range edits {
+			rewritten = rewritten[:e.start] + e.text + rewritten[e.end:]
+		}
+		lines[i] = rewritten
+		result.Changed = true
+	}
+
+	result.Content = []byte(strings.Join(lines, "\n"))
+	return result
+}
+
+// overlaps reports whether the span from start to end overlaps one of edits
+func overlaps(edits []edit, start, end int) bool {
+	for _, e := range edits {
+		if start < e.end && e.start < end {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrateDirectory runs the migration assistant on all Cadence files in a
+// directory and its subdirectories. When write is true fixable findings are
+// rewritten in place.
+func MigrateDirectory(dirPath string, write bool) ([]lint.Diagnostic, error) {
+	var diagnostics []lint.Diagnostic
+	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || filepath.Ext(path) != ".cdc" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+
+		result := MigrateSource(path, content)
+		diagnostics = append(diagnostics, result.Diagnostics...)
+
+		if write && result.Changed {
+			if err := os.WriteFile(path, result.Content, info.Mode()); err != nil {
+				return fmt.Errorf("failed to write file: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return diagnostics, nil
+}
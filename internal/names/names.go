@@ -0,0 +1,90 @@
+package names
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// DefaultFile is the default path of the names file
+const DefaultFile = "names.json"
+
+// Names maps the file name of every script and transaction to its generated
+// function or case name, per target, e.g. typescript -> get_balance.cdc -> getBalance
+type Names map[string]map[string]string
+
+// Load reads the names file at path. A missing file yields no names.
+func Load(path string) (Names, error) {
+	names := make(Names)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return names, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read names file: %w", err)
+	}
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("failed to parse names file %s: %w", path, err)
+	}
+	return names, nil
+}
+
+// Save writes the names file to path
+func (n Names) Save(path string) error {
+	data, err := json.MarshalIndent(n, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal names: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write names file: %w", err)
+	}
+	return nil
+}
+
+// CheckUnique returns an error naming the files of target that share a
+// generated name, e.g. when a name recorded for one file is derived for another
+func CheckUnique(target string, names map[string]string) error {
+	files := make(map[string][]string)
+	for file, name := range names {
+		files[name] = append(files[name], file)
+	}
+	var duplicates []string
+	for name, shared := range files {
+		if len(shared) > 1 {
+			sort.Strings(shared)
+			duplicates = append(duplicates, fmt.Sprintf("%s (%s)", name, strings.Join(shared, ", ")))
+		}
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+	sort.Strings(duplicates)
+	return fmt.Errorf("duplicate %s names: %s", target, strings.Join(duplicates, "; "))
+}
+
+// Function returns the generated name of the script or transaction filename,
+// as recorded in recorded or else derived from the file name with the casing
+// format of the target
+func Function(recorded map[string]string, filename string, format func(string) string) string {
+	if name, ok := recorded[filename]; ok {
+		return name
+	}
+	return format(filename)
+}
+
+// Functions returns the generated name of every script and transaction of
+// report by file name, see Function
+func Functions(report analyzer.Report, recorded map[string]string, format func(string) string) map[string]string {
+	names := make(map[string]string)
+	for filename := range report.Transactions {
+		names[filename] = Function(recorded, filename, format)
+	}
+	for filename := range report.Scripts {
+		names[filename] = Function(recorded, filename, format)
+	}
+	return names
+}
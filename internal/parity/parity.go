@@ -0,0 +1,277 @@
+package parity
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// Networks are the networks compared, in order
+var Networks = []string{"mainnet", "testnet"}
+
+// Surface maps the members of a contract, e.g. Vault.withdraw, to their
+// signatures
+type Surface map[string]string
+
+// Divergence is a member of an imported contract that the report depends on
+// and that differs between mainnet and testnet
+type Divergence struct {
+	Contract     string   `json:"contract"`
+	Member       string   `json:"member"`
+	Mainnet      string   `json:"mainnet"`
+	Testnet      string   `json:"testnet"`
+	Interactions []string `json:"interactions"`
+}
+
+// String renders the divergence as a single line
+func (d Divergence) String() string {
+	signature := func(s string) string {
+		if s == "" {
+			return "missing"
+		}
+		return s
+	}
+	return fmt.Sprintf("%s.%s: mainnet %s, testnet %s (used by %s)", d.Contract, d.Member,
+		signature(d.Mainnet), signature(d.Testnet), strings.Join(d.Interactions, ", "))
+}
+
+// Result is the outcome of comparing the contracts of a report across networks
+type Result struct {
+	Divergences []Divergence      `json:"divergences"`
+	Skipped     map[string]string `json:"skipped,omitempty"` // Reason per contract that was not compared
+}
+
+// Fetcher returns the code of the contract deployed to address on network
+type Fetcher func(network string, address string, contract string) ([]byte, error)
+
+// ParseSurface returns the surface of the contract or contract interface
+// named contract declared by code
+func ParseSurface(code []byte, contract string) (Surface, error) {
+	_, code = analyzer.ExtractImports(code)
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse contract %s: %w", contract, err)
+	}
+
+	surface := make(Surface)
+	for _, composite := range program.CompositeDeclarations() {
+		if composite.Identifier.Identifier == contract {
+			addMembers(surface, "", composite.Members)
+			return surface, nil
+		}
+	}
+	for _, declaration := range program.InterfaceDeclarations() {
+		if declaration.Identifier.Identifier == contract {
+			addMembers(surface, "", declaration.Members)
+			return surface, nil
+		}
+	}
+	return nil, fmt.Errorf("contract %s not declared", contract)
+}
+
+// addMembers adds the fields, functions and nested types of members to
+// surface, prefixing their names with prefix
+func addMembers(surface Surface, prefix string, members *ast.Members) {
+	if members == nil {
+		return
+	}
+	for _, field := range members.Fields() {
+		surface[prefix+field.Identifier.Identifier] = fmt.Sprintf("%s %s %s: %s",
+			field.Access.Keyword(), field.VariableKind.Keyword(), field.Identifier.Identifier, field.TypeAnnotation.String())
+	}
+	for _, function := range members.Functions() {
+		signature := fmt.Sprintf("%s fun %s(%s)", function.Access.Keyword(), function.Identifier.Identifier, parameters(function.ParameterList))
+		if function.ReturnTypeAnnotation != nil {
+			signature += ": " + function.ReturnTypeAnnotation.String()
+		}
+		surface[prefix+function.Identifier.Identifier] = signature
+	}
+	for _, composite := range members.Composites() {
+		name := prefix + composite.Identifier.Identifier
+		if composite.CompositeKind == common.CompositeKindEvent {
+			var parameterList *ast.ParameterList
+			if initializers := composite.Members.Initializers(); len(initializers) > 0 {
+				parameterList = initializers[0].FunctionDeclaration.ParameterList
+			}
+			surface[name] = fmt.Sprintf("%s event %s(%s)", composite.Access.Keyword(), composite.Identifier.Identifier, parameters(parameterList))
+			continue
+		}
+		surface[name] = fmt.Sprintf("%s %s %s%s", composite.Access.Keyword(), composite.CompositeKind.Keyword(),
+			composite.Identifier.Identifier, conformances(composite.Conformances))
+		addMembers(surface, name+".", composite.Members)
+	}
+	for _, declaration := range members.Interfaces() {
+		name := prefix + declaration.Identifier.Identifier
+		surface[name] = fmt.Sprintf("%s %s interface %s%s", declaration.Access.Keyword(), declaration.CompositeKind.Keyword(),
+			declaration.Identifier.Identifier, conformances(declaration.Conformances))
+		addMembers(surface, name+".", declaration.Members)
+	}
+}
+
+// parameters renders a parameter list without parentheses
+func parameters(list *ast.ParameterList) string {
+	if list == nil {
+		return ""
+	}
+	var rendered []string
+	for _, param := range list.Parameters {
+		parameter := param.Identifier.Identifier + ": " + param.TypeAnnotation.String()
+		if param.Label != "" {
+			parameter = param.Label + " " + parameter
+		}
+		rendered = append(rendered, parameter)
+	}
+	return strings.Join(rendered, ", ")
+}
+
+// conformances renders the conformances of a type declaration, sorted
+func conformances(types []*ast.NominalType) string {
+	if len(types) == 0 {
+		return ""
+	}
+	var names []string
+	for _, t := range types {
+		names = append(names, t.String())
+	}
+	sort.Strings(names)
+	return ": " + strings.Join(names, ", ")
+}
+
+// Dependencies returns the members of each contract referenced by the
+// interactions of report, e.g. FlowToken.Vault, and the interactions
+// referencing them
+func Dependencies(report analyzer.Report) map[string]map[string][]string {
+	dependencies := make(map[string]map[string][]string)
+	add := func(results map[string]analyzer.AnalysisResult) {
+		for name, result := range results {
+			code, err := base64.StdEncoding.DecodeString(result.Base64)
+			if err != nil {
+				continue
+			}
+			stripped := analyzer.StripCommentsAndStrings(code)
+			for _, contract := range analyzer.ImportedContracts(code) {
+				pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(contract) + `\.([A-Za-z_]\w*)`)
+				for _, match := range pattern.FindAllSubmatch(stripped, -1) {
+					member := string(match[1])
+					if dependencies[contract] == nil {
+						dependencies[contract] = make(map[string][]string)
+					}
+					users := dependencies[contract][member]
+					if len(users) == 0 || users[len(users)-1] != name {
+						dependencies[contract][member] = append(users, name)
+					}
+				}
+			}
+		}
+	}
+	add(report.Scripts)
+	add(report.Transactions)
+	for _, members := range dependencies {
+		for member := range members {
+			sort.Strings(members[member])
+		}
+	}
+	return dependencies
+}
+
+// address returns the address of contract on network in the report
+func address(report analyzer.Report, network string, contract string) string {
+	addresses, ok := report.Addresses[network].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if address, ok := addresses["0x"+contract].(string); ok {
+		return address
+	}
+	address, _ := addresses[contract].(string)
+	return address
+}
+
+// Check fetches the contracts imported by the interactions of report from
+// mainnet and testnet and returns the divergences of the members they use.
+// A referenced type covers all of its nested members.
+func Check(report analyzer.Report, fetch Fetcher) *Result {
+	result := &Result{Divergences: make([]Divergence, 0)}
+	skip := func(contract string, reason string) {
+		if result.Skipped == nil {
+			result.Skipped = make(map[string]string)
+		}
+		result.Skipped[contract] = reason
+	}
+
+	dependencies := Dependencies(report)
+	var contracts []string
+	for contract := range dependencies {
+		contracts = append(contracts, contract)
+	}
+	sort.Strings(contracts)
+
+	for _, contract := range contracts {
+		surfaces := make([]Surface, len(Networks))
+		for i, network := range Networks {
+			contractAddress := address(report, network, contract)
+			if contractAddress == "" {
+				skip(contract, fmt.Sprintf("no %s address", network))
+				break
+			}
+			code, err := fetch(network, contractAddress, contract)
+			if err != nil {
+				skip(contract, err.Error())
+				break
+			}
+			surface, err := ParseSurface(code, contract)
+			if err != nil {
+				skip(contract, err.Error())
+				break
+			}
+			surfaces[i] = surface
+		}
+		if _, skipped := result.Skipped[contract]; skipped {
+			continue
+		}
+		result.Divergences = append(result.Divergences, compare(contract, dependencies[contract], surfaces[0], surfaces[1])...)
+	}
+	return result
+}
+
+// compare returns the divergences of the members used of a contract between
+// its mainnet and testnet surfaces
+func compare(contract string, used map[string][]string, mainnet Surface, testnet Surface) []Divergence {
+	var divergences []Divergence
+	keys := make(map[string]bool)
+	for member := range used {
+		for _, surface := range []Surface{mainnet, testnet} {
+			for key := range surface {
+				if key == member || strings.HasPrefix(key, member+".") {
+					keys[key] = true
+				}
+			}
+		}
+	}
+
+	var sorted []string
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		if mainnet[key] == testnet[key] {
+			continue
+		}
+		divergences = append(divergences, Divergence{
+			Contract:     contract,
+			Member:       key,
+			Mainnet:      mainnet[key],
+			Testnet:      testnet[key],
+			Interactions: used[strings.SplitN(key, ".", 2)[0]],
+		})
+	}
+	return divergences
+}
@@ -0,0 +1,44 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// Environment variables set for a plugin, besides the environment of the
+// generate command
+const (
+	EnvOutput  = "CADENCE_CODEGEN_OUTPUT"  // Path the output of the plugin is written to
+	EnvVersion = "CADENCE_CODEGEN_VERSION" // Version of cadence-codegen running the plugin
+)
+
+// Run runs the plugin executable at path, without arguments, with the
+// report JSON on stdin, as written by the analyze command, and returns what
+// it writes to stdout. What the plugin writes to stderr is copied to stderr.
+// The plugin fails if it exits with a non-zero status.
+func Run(path string, report *analyzer.Report, outputPath string, stderr io.Writer) ([]byte, error) {
+	input, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(),
+		EnvOutput+"="+outputPath,
+		EnvVersion+"="+analyzer.Version,
+	)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run plugin %s: %w", path, err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// TokenEnv is the environment variable holding the registry auth token
+const TokenEnv = "CADENCE_CODEGEN_REGISTRY_TOKEN"
+
+// LatestVersion is the version pulled when none is given
+const LatestVersion = "latest"
+
+// Client publishes and pulls reports of an interaction registry. Reports
+// are stored at <URL>/<name>/<version>.
+type Client struct {
+	URL        string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// New creates a Client for the registry at baseURL, authenticating with
+// token if it is not empty
+func New(baseURL string, token string) (*Client, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("no registry URL configured")
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid registry URL: %s", baseURL)
+	}
+	return &Client{
+		URL:        strings.TrimSuffix(baseURL, "/"),
+		Token:      token,
+		HTTPClient: http.DefaultClient,
+	}, nil
+}
+
+// endpoint returns the URL of the report name at version
+func (c *Client) endpoint(name string, version string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("no interaction library name given")
+	}
+	if version == "" {
+		version = LatestVersion
+	}
+	return c.URL + "/" + url.PathEscape(name) + "/" + url.PathEscape(version), nil
+}
+
+// do sends a request with the auth token and returns the response body,
+// or an error for non-2xx responses
+func (c *Client) do(method string, endpoint string, body []byte) ([]byte, error) {
+	request, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach registry: %w", err)
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry response: %w", err)
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		message := strings.TrimSpace(string(data))
+		if len(message) > 200 {
+			message = message[:200]
+		}
+		if message != "" {
+			return nil, fmt.Errorf("registry returned %s: %s", response.Status, message)
+		}
+		return nil, fmt.Errorf("registry returned %s", response.Status)
+	}
+	return data, nil
+}
+
+// Publish uploads report as name at version with an HTTP PUT and returns
+// the URL it was published at
+func (c *Client) Publish(name string, version string, report analyzer.Report) (string, error) {
+	endpoint, err := c.endpoint(name, version)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report: %w", err)
+	}
+	if _, err := c.do(http.MethodPut, endpoint, data); err != nil {
+		return "", err
+	}
+	return endpoint, nil
+}
+
+// Pull downloads the report name at version, the latest one if version is
+// empty
+func (c *Client) Pull(name string, version string) (*analyzer.Report, error) {
+	endpoint, err := c.endpoint(name, version)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.do(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	var report analyzer.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse published report: %w", err)
+	}
+	if report.Scripts == nil && report.Transactions == nil {
+		return nil, fmt.Errorf("published %s is not a cadence-codegen report", name)
+	}
+	return &report, nil
+}
@@ -0,0 +1,279 @@
+package rpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/golang"
+	"github.com/outblock/cadence-codegen/internal/generator/swift"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+	"github.com/outblock/cadence-codegen/internal/lint"
+	"github.com/outblock/cadence-codegen/internal/migrate"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Request is a JSON-RPC request. Requests without an ID are notifications.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC response
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// FileParams identifies a Cadence file. Content holds the unsaved editor
+// buffer and is read from disk if omitted.
+type FileParams struct {
+	Path    string  `json:"path"`
+	Content *string `json:"content,omitempty"`
+}
+
+// SnippetParams requests the generated code of a file for a target
+type SnippetParams struct {
+	FileParams
+	Target string `json:"target"`
+}
+
+// InitializeParams configures the server
+type InitializeParams struct {
+	// Root is the workspace root that tags are derived from
+	Root string `json:"root"`
+}
+
+// AnalyzeResult is the result of the analyzeFile method
+type AnalyzeResult struct {
+	Result  *analyzer.AnalysisResult   `json:"result,omitempty"`
+	Error   string                     `json:"error,omitempty"`
+	Structs map[string]analyzer.Struct `json:"structs"`
+	Events  map[string]analyzer.Event  `json:"events"`
+}
+
+// Server answers newline delimited JSON-RPC requests from an editor
+type Server struct {
+	Root string
+
+	mu  sync.Mutex
+	out *json.Encoder
+}
+
+// New creates a new JSON-RPC server
+func New() *Server {
+	return &Server{}
+}
+
+// Serve reads one request per line from r and writes one response per line
+// to w until r is closed or the shutdown method is called
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.out = json.NewEncoder(w)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var request Request
+		if err := json.Unmarshal(line, &request); err != nil {
+			s.reply(json.RawMessage("null"), nil, &Error{Code: CodeParseError, Message: err.Error()})
+			continue
+		}
+		if request.JSONRPC != "2.0" || request.Method == "" {
+			s.reply(request.ID, nil, &Error{Code: CodeInvalidRequest, Message: "invalid request"})
+			continue
+		}
+
+		result, rpcErr := s.handle(request)
+		if request.ID != nil {
+			s.reply(request.ID, result, rpcErr)
+		}
+		if request.Method == "shutdown" {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+	return nil
+}
+
+// reply writes a response
+func (s *Server) reply(id json.RawMessage, result interface{}, rpcErr *Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.out.Encode(Response{JSONRPC: "2.0", ID: id, Result: result, Error: rpcErr})
+}
+
+// handle dispatches a request to its method
+func (s *Server) handle(request Request) (interface{}, *Error) {
+	switch request.Method {
+	case "initialize":
+		var params InitializeParams
+		if err := decodeParams(request.Params, &params); err != nil {
+			return nil, err
+		}
+		s.Root = params.Root
+		return map[string]interface{}{
+			"methods": []string{"initialize", "analyzeFile", "getDiagnostics", "getSnippet", "shutdown"},
+			"targets": []string{"typescript", "swift", "golang"},
+		}, nil
+	case "analyzeFile":
+		var params FileParams
+		if err := decodeParams(request.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.analyzeFile(params)
+	case "getDiagnostics":
+		var params FileParams
+		if err := decodeParams(request.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.getDiagnostics(params)
+	case "getSnippet":
+		var params SnippetParams
+		if err := decodeParams(request.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.getSnippet(params)
+	case "shutdown":
+		return true, nil
+	default:
+		return nil, &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", request.Method)}
+	}
+}
+
+// decodeParams decodes the parameters of a request
+func decodeParams(raw json.RawMessage, params interface{}) *Error {
+	if len(raw) == 0 {
+		raw = json.RawMessage("{}")
+	}
+	if err := json.Unmarshal(raw, params); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// source returns the workspace relative path and content of a file
+func (s *Server) source(params FileParams) (string, []byte, *Error) {
+	if params.Path == "" {
+		return "", nil, &Error{Code: CodeInvalidParams, Message: "path is required"}
+	}
+
+	var content []byte
+	if params.Content != nil {
+		content = []byte(*params.Content)
+	} else {
+		data, err := os.ReadFile(params.Path)
+		if err != nil {
+			return "", nil, &Error{Code: CodeInternalError, Message: fmt.Sprintf("failed to read file: %v", err)}
+		}
+		content = data
+	}
+
+	// Tags are derived from the directory, so make the path relative to the workspace
+	path := params.Path
+	if s.Root != "" {
+		if rel, err := filepath.Rel(s.Root, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return path, content, nil
+}
+
+// analyze analyzes source code and returns the analyzer holding its result
+func analyze(path string, content []byte) (*analyzer.Analyzer, *analyzer.AnalysisResult, error) {
+	a := analyzer.New()
+	a.SetIncludeBase64(true)
+	result, err := a.AnalyzeSource(path, content)
+	return a, result, err
+}
+
+func (s *Server) analyzeFile(params FileParams) (interface{}, *Error) {
+	path, content, rpcErr := s.source(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	a, result, err := analyze(path, content)
+	response := AnalyzeResult{
+		Result:  result,
+		Structs: a.GetReport().Structs,
+		Events:  a.Events,
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	return response, nil
+}
+
+func (s *Server) getDiagnostics(params FileParams) (interface{}, *Error) {
+	_, content, rpcErr := s.source(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	// Report diagnostics against the path the editor knows the file by
+	linter := lint.New()
+	linter.SetRoot(s.Root)
+	diagnostics := linter.LintSource(params.Path, content)
+	diagnostics = append(diagnostics, migrate.MigrateSource(params.Path, content).Diagnostics...)
+	if diagnostics == nil {
+		diagnostics = []lint.Diagnostic{}
+	}
+	return diagnostics, nil
+}
+
+func (s *Server) getSnippet(params SnippetParams) (interface{}, *Error) {
+	path, content, rpcErr := s.source(params.FileParams)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	a, _, err := analyze(path, content)
+	if err != nil {
+		return nil, &Error{Code: CodeInternalError, Message: err.Error()}
+	}
+
+	report := *a.GetReport()
+	var code string
+	switch params.Target {
+	case "typescript", "":
+		code, err = typescript.New(report).Generate()
+	case "swift":
+		code, err = swift.New(report).Generate()
+	case "golang":
+		code, err = golang.New(report).Generate()
+	default:
+		return nil, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("unsupported target: %s", params.Target)}
+	}
+	if err != nil {
+		return nil, &Error{Code: CodeInternalError, Message: fmt.Sprintf("failed to generate code: %v", err)}
+	}
+	return map[string]string{"code": code}, nil
+}
@@ -0,0 +1,118 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Target is a generate command and the default output path it is set up with
+type Target struct {
+	Command string
+	Output  string // Slash separated path relative to the output directory
+}
+
+// Targets are the generate commands init can set up, by name
+var Targets = map[string]Target{
+	"typescript": {Command: "typescript", Output: "typescript/cadence.generated.ts"},
+	"swift":      {Command: "swift", Output: "swift/CadenceGen.swift"},
+	"golang":     {Command: "golang", Output: "go/cadence_gen.go"},
+	"kotlin":     {Command: "kotlin", Output: "kotlin/CadenceGen.kt"},
+	"trpc":       {Command: "trpc", Output: "typescript/cadence.router.ts"},
+	"nuxt":       {Command: "nuxt", Output: "composables/cadence.ts"},
+	"solid":      {Command: "solid", Output: "typescript/cadence.solid.ts"},
+	"rest":       {Command: "rest", Output: "typescript/cadence.server.ts"},
+	"grpc":       {Command: "grpc", Output: "grpc"},
+}
+
+// TargetNames returns the names of the targets, sorted
+func TargetNames() []string {
+	var names []string
+	for name := range Targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// skippedDirs are directories never searched for Cadence files
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendored":     true,
+	"build":        true,
+	"dist":         true,
+}
+
+// Project is what init found in a directory
+type Project struct {
+	CadenceDirs   []string // Slash separated directories holding .cdc files
+	Input         string   // Deepest directory containing all CadenceDirs
+	FlowJSON      bool     // Whether flow.json exists
+	AddressesJSON string   // Path of the addresses.json found, if any
+}
+
+// Inspect searches dir for Cadence files, flow.json and addresses.json
+func Inspect(dir string) (*Project, error) {
+	project := &Project{}
+	if _, err := os.Stat(filepath.Join(dir, "flow.json")); err == nil {
+		project.FlowJSON = true
+	}
+
+	seen := make(map[string]bool)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && (strings.HasPrefix(info.Name(), ".") || skippedDirs[info.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case filepath.Ext(path) == ".cdc":
+			cadenceDir := filepath.ToSlash(filepath.Dir(rel))
+			if !seen[cadenceDir] {
+				seen[cadenceDir] = true
+				project.CadenceDirs = append(project.CadenceDirs, cadenceDir)
+			}
+		case info.Name() == "addresses.json" && project.AddressesJSON == "":
+			project.AddressesJSON = filepath.ToSlash(rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect directory: %w", err)
+	}
+
+	sort.Strings(project.CadenceDirs)
+	project.Input = commonDir(project.CadenceDirs)
+	return project, nil
+}
+
+// commonDir returns the deepest directory containing all slash separated
+// dirs, "." if they share none
+func commonDir(dirs []string) string {
+	if len(dirs) == 0 {
+		return "."
+	}
+	common := strings.Split(dirs[0], "/")
+	for _, dir := range dirs[1:] {
+		parts := strings.Split(dir, "/")
+		n := 0
+		for n < len(common) && n < len(parts) && common[n] == parts[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	if len(common) == 0 {
+		return "."
+	}
+	return strings.Join(common, "/")
+}
@@ -0,0 +1,244 @@
+package server
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/golang"
+	"github.com/outblock/cadence-codegen/internal/generator/swift"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// Interaction is a script or transaction as shown in the web UI
+type Interaction struct {
+	Name       string               `json:"name"`
+	FileName   string               `json:"fileName"`
+	Type       string               `json:"type"`
+	Tag        string               `json:"tag,omitempty"`
+	Parameters []analyzer.Parameter `json:"parameters"`
+	ReturnType string               `json:"returnType,omitempty"`
+	Imports    []analyzer.Import    `json:"imports"`
+	Code       string               `json:"code"`
+	Snippets   map[string]string    `json:"snippets"`
+	Messages   map[string]string    `json:"messages,omitempty"` // User-facing messages by key, e.g. title
+}
+
+// Catalog is the data served to the web UI
+type Catalog struct {
+	Directory    string                     `json:"directory"`
+	GeneratedAt  time.Time                  `json:"generatedAt"`
+	Interactions []Interaction              `json:"interactions"`
+	Structs      map[string]analyzer.Struct `json:"structs"`
+	Events       map[string]analyzer.Event  `json:"events,omitempty"`
+	Outputs      map[string]string          `json:"outputs,omitempty"`
+}
+
+// Server serves the interaction catalog of a directory of Cadence files
+type Server struct {
+	Dir     string
+	Outputs map[string]string // target (swift/typescript/golang) -> output path
+
+	mu      sync.RWMutex
+	catalog *Catalog
+}
+
+// New creates a new server for a directory of Cadence files
+func New(dir string) *Server {
+	return &Server{
+		Dir:     dir,
+		Outputs: make(map[string]string),
+	}
+}
+
+// SetOutput sets the output path regenerated for a target, an empty path disables it
+func (s *Server) SetOutput(target string, path string) {
+	if path == "" {
+		delete(s.Outputs, target)
+		return
+	}
+	s.Outputs[target] = path
+}
+
+// Regenerate analyzes the directory again and rewrites the configured outputs
+func (s *Server) Regenerate() error {
+	a := analyzer.New()
+	a.SetIncludeBase64(true)
+	if err := a.AnalyzeDirectory(s.Dir); err != nil {
+		return fmt.Errorf("failed to analyze directory: %w", err)
+	}
+	report := a.GetReport()
+
+	for target, path := range s.Outputs {
+		code, err := generate(*report, target)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s code: %w", target, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+			return fmt.Errorf("failed to write %s code: %w", target, err)
+		}
+	}
+
+	catalog := &Catalog{
+		Directory:    s.Dir,
+		GeneratedAt:  time.Now(),
+		Interactions: make([]Interaction, 0),
+		Structs:      report.Structs,
+		Events:       report.Events,
+		Outputs:      s.Outputs,
+	}
+	for _, results := range []map[string]analyzer.AnalysisResult{report.Transactions, report.Scripts} {
+		for _, result := range results {
+			catalog.Interactions = append(catalog.Interactions, newInteraction(result))
+		}
+	}
+	sort.Slice(catalog.Interactions, func(i, j int) bool {
+		return catalog.Interactions[i].Name < catalog.Interactions[j].Name
+	})
+
+	s.mu.Lock()
+	s.catalog = catalog
+	s.mu.Unlock()
+	return nil
+}
+
+// generate generates the code of a target for a report
+func generate(report analyzer.Report, target string) (string, error) {
+	switch target {
+	case "swift":
+		return swift.New(report).Generate()
+	case "typescript":
+		return typescript.New(report).Generate()
+	case "golang":
+		return golang.New(report).Generate()
+	default:
+		return "", fmt.Errorf("unsupported target: %s", target)
+	}
+}
+
+// newInteraction converts an analysis result into an interaction with usage snippets
+func newInteraction(result analyzer.AnalysisResult) Interaction {
+	var code string
+	if decoded, err := base64.StdEncoding.DecodeString(result.Base64); err == nil {
+		code = string(decoded)
+	}
+
+	var names, swiftArgs []string
+	for _, param := range result.Parameters {
+		names = append(names, param.Name)
+		swiftArgs = append(swiftArgs, param.Name+": "+param.Name)
+	}
+
+	tsName := typescript.FunctionName(result.FileName)
+	swiftName := swift.FunctionName(result.FileName)
+	swiftCase := "CadenceGen."
+	if result.Tag != "" {
+		swiftCase += result.Tag + "."
+	}
+	swiftCase += fmt.Sprintf("%s(%s)", swiftName, strings.Join(swiftArgs, ", "))
+	goName := golang.FunctionName(result.FileName)
+
+	snippets := make(map[string]string)
+	if result.Type == "script" {
+		snippets["typescript"] = fmt.Sprintf("const result = await service.%s(%s);", tsName, strings.Join(names, ", "))
+		snippets["swift"] = fmt.Sprintf("let result = try await flow.query(%s)", swiftCase)
+		snippets["golang"] = fmt.Sprintf("args, err := cadencegen.Encode%sArguments(%s)\n// ... execute the script\nresult, err := cadencegen.Decode%sResult(response)", goName, strings.Join(names, ", "), goName)
+	} else {
+		snippets["typescript"] = fmt.Sprintf("const txId = await service.%s(%s);", tsName, strings.Join(names, ", "))
+		snippets["swift"] = fmt.Sprintf("let txId = try await flow.sendTx(%s, singers: [signer]) {}", swiftCase)
+		snippets["golang"] = fmt.Sprintf("args, err := cadencegen.Encode%sArguments(%s)", goName, strings.Join(names, ", "))
+	}
+
+	parameters := result.Parameters
+	if parameters == nil {
+		parameters = make([]analyzer.Parameter, 0)
+	}
+	imports := result.Imports
+	if imports == nil {
+		imports = make([]analyzer.Import, 0)
+	}
+	return Interaction{
+		Name:       tsName,
+		FileName:   result.FileName,
+		Type:       result.Type,
+		Tag:        result.Tag,
+		Parameters: parameters,
+		ReturnType: result.ReturnType,
+		Imports:    imports,
+		Code:       code,
+		Snippets:   snippets,
+		Messages:   result.Messages,
+	}
+}
+
+// Handler returns the HTTP handler of the web UI and its JSON API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/api/catalog", s.handleCatalog)
+	mux.HandleFunc("/api/regenerate", s.handleRegenerate)
+	return mux
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(indexHTML)
+}
+
+func (s *Server) handleCatalog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.mu.RLock()
+	catalog := s.catalog
+	s.mu.RUnlock()
+	writeJSON(w, http.StatusOK, catalog)
+}
+
+func (s *Server) handleRegenerate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := s.Regenerate(); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	s.handleCatalog(w, &http.Request{Method: http.MethodGet})
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// ListenAndServe analyzes the directory and serves the web UI on addr
+func (s *Server) ListenAndServe(addr string) error {
+	if err := s.Regenerate(); err != nil {
+		return err
+	}
+	fmt.Printf("Serving %s on http://%s\n", s.Dir, addr)
+	return http.ListenAndServe(addr, s.Handler())
+}
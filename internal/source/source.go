@@ -0,0 +1,234 @@
+package source
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// IsRemote reports whether an input is an archive or git repository that has
+// to be fetched before it can be analyzed
+func IsRemote(input string) bool {
+	return isArchive(input) || isGitURL(input)
+}
+
+// isArchive reports whether an input is a .zip or .tar.gz archive
+func isArchive(input string) bool {
+	lower := strings.ToLower(input)
+	return strings.HasSuffix(lower, ".zip") || strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz")
+}
+
+// isGitURL reports whether an input is a git repository URL, optionally followed by #ref
+func isGitURL(input string) bool {
+	url, _, _ := strings.Cut(input, "#")
+	return strings.HasPrefix(url, "git@") || strings.HasPrefix(url, "git://") ||
+		strings.HasPrefix(url, "ssh://") || strings.HasPrefix(url, "git+") ||
+		(strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://")) && strings.HasSuffix(url, ".git")
+}
+
+// Fetch extracts an archive or clones a git repository (url#ref) into a
+// temporary directory. It returns the directory holding the sources and a
+// function removing the temporary directory.
+func Fetch(input string) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "cadence-codegen-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	if isGitURL(input) {
+		if err := clone(input, dir); err != nil {
+			cleanup()
+			return "", nil, err
+		}
+		return dir, cleanup, nil
+	}
+
+	path := input
+	if strings.HasPrefix(input, "https://") || strings.HasPrefix(input, "http://") {
+		path = filepath.Join(dir, "archive"+archiveExt(input))
+		if err := download(input, path); err != nil {
+			cleanup()
+			return "", nil, err
+		}
+	}
+
+	root := filepath.Join(dir, "src")
+	if err := extract(path, root); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return singleDirectory(root), cleanup, nil
+}
+
+// archiveExt returns the extension of an archive
+func archiveExt(input string) string {
+	if strings.HasSuffix(strings.ToLower(input), ".zip") {
+		return ".zip"
+	}
+	return ".tar.gz"
+}
+
+// clone shallow clones a git repository at an optional ref given after #
+func clone(input string, dir string) error {
+	url, ref, _ := strings.Cut(input, "#")
+	url = strings.TrimPrefix(url, "git+")
+
+	args := []string{"-c", "advice.detachedHead=false", "clone", "--quiet", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, url, dir)
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to clone %s: %w", url, err)
+	}
+	return nil
+}
+
+// download downloads an archive to path
+func download(url string, path string) error {
+	response, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download archive: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download archive: %s", response.Status)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create archive file: %w", err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, response.Body); err != nil {
+		return fmt.Errorf("failed to download archive: %w", err)
+	}
+	return nil
+}
+
+// extract extracts a .zip or .tar.gz archive into dir
+func extract(path string, dir string) error {
+	if strings.HasSuffix(strings.ToLower(path), ".zip") {
+		return extractZip(path, dir)
+	}
+	return extractTarGz(path, dir)
+}
+
+// target returns the path of an archive entry in dir, rejecting entries
+// that would be written outside of it
+func target(dir string, name string) (string, error) {
+	path := filepath.Join(dir, name)
+	if path != dir && !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid archive entry: %s", name)
+	}
+	return path, nil
+}
+
+// writeFile writes an archive entry to path
+func writeFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, r); err != nil {
+		return fmt.Errorf("failed to extract file: %w", err)
+	}
+	return nil
+}
+
+func extractZip(path string, dir string) error {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer reader.Close()
+
+	for _, entry := range reader.File {
+		path, err := target(dir, entry.Name)
+		if err != nil {
+			return err
+		}
+		if entry.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+			continue
+		}
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		file, err := entry.Open()
+		if err != nil {
+			return fmt.Errorf("failed to read archive entry: %w", err)
+		}
+		err = writeFile(path, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTarGz(path string, dir string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		path, err := target(dir, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+		case tar.TypeReg:
+			if err := writeFile(path, reader); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// singleDirectory descends into the top-level directory that archives such as
+// GitHub release tarballs wrap their content in
+func singleDirectory(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return dir
+	}
+	return filepath.Join(dir, entries[0].Name())
+}
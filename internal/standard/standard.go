@@ -0,0 +1,157 @@
+package standard
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// files are the Cadence files of the presets, in a folder per preset
+//
+//go:embed ft/*.cdc nft/*.cdc
+var files embed.FS
+
+// presetTags are the tags of the interactions of each preset
+var presetTags = map[string]string{
+	"ft":  "FT",
+	"nft": "NFT",
+}
+
+// contractAddresses are the addresses of the standard contracts the presets
+// import, by network and import alias
+var contractAddresses = map[string]map[string]string{
+	"mainnet": {
+		"0xFungibleToken":              "0xf233dcee88fe0abe",
+		"0xFungibleTokenMetadataViews": "0xf233dcee88fe0abe",
+		"0xNonFungibleToken":           "0x1d7e57aa55817448",
+		"0xMetadataViews":              "0x1d7e57aa55817448",
+	},
+	"testnet": {
+		"0xFungibleToken":              "0x9a0766d93b6608b7",
+		"0xFungibleTokenMetadataViews": "0x9a0766d93b6608b7",
+		"0xNonFungibleToken":           "0x631e88ae7f1d7c20",
+		"0xMetadataViews":              "0x631e88ae7f1d7c20",
+	},
+}
+
+// Parse splits a comma separated list of presets, e.g. ft,nft
+func Parse(list string) ([]string, error) {
+	var presets []string
+	for _, preset := range strings.Split(list, ",") {
+		preset = strings.ToLower(strings.TrimSpace(preset))
+		if preset == "" {
+			continue
+		}
+		if _, ok := presetTags[preset]; !ok {
+			return nil, fmt.Errorf("unknown standard preset %q (expected ft or nft)", preset)
+		}
+		presets = append(presets, preset)
+	}
+	return presets, nil
+}
+
+// Analyze analyzes the Cadence files of presets into a report, tagging the
+// interactions of each preset with its name, e.g. FT
+func Analyze(presets []string) (*analyzer.Report, error) {
+	a := analyzer.New()
+	a.SetIncludeBase64(true)
+	for _, preset := range presets {
+		entries, err := fs.ReadDir(files, preset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read standard preset %s: %w", preset, err)
+		}
+		for _, entry := range entries {
+			content, err := files.ReadFile(path.Join(preset, entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read standard preset %s: %w", preset, err)
+			}
+			result, err := a.AnalyzeSource(path.Join("standard", preset, entry.Name()), content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to analyze standard preset %s: %w", entry.Name(), err)
+			}
+			result.Tag = presetTags[preset]
+			if result.Type == "transaction" {
+				a.Transactions[result.FileName] = *result
+			} else {
+				a.Scripts[result.FileName] = *result
+			}
+		}
+	}
+	return a.GetReport(), nil
+}
+
+// Merge adds the scripts, transactions and structs of presets to report,
+// with the mainnet and testnet addresses of the standard contracts they
+// import. Entries and addresses of report with the same name are kept.
+func Merge(report *analyzer.Report, presets []string) error {
+	standard, err := Analyze(presets)
+	if err != nil {
+		return err
+	}
+	if report.Transactions == nil {
+		report.Transactions = make(map[string]analyzer.AnalysisResult)
+	}
+	if report.Scripts == nil {
+		report.Scripts = make(map[string]analyzer.AnalysisResult)
+	}
+	if report.Structs == nil {
+		report.Structs = make(map[string]analyzer.Struct)
+	}
+	for name, transaction := range standard.Transactions {
+		if _, ok := report.Transactions[name]; !ok {
+			report.Transactions[name] = transaction
+		}
+	}
+	for name, script := range standard.Scripts {
+		if _, ok := report.Scripts[name]; !ok {
+			report.Scripts[name] = script
+		}
+	}
+	for name, composite := range standard.Structs {
+		if _, ok := report.Structs[name]; !ok {
+			report.Structs[name] = composite
+		}
+	}
+	mergeAddresses(report, standard)
+	return nil
+}
+
+// mergeAddresses adds the addresses of the standard contracts imported by
+// the interactions of standard to report, unless report has them by alias
+// or contract name
+func mergeAddresses(report *analyzer.Report, standard *analyzer.Report) {
+	aliases := make(map[string]bool)
+	for _, results := range []map[string]analyzer.AnalysisResult{standard.Transactions, standard.Scripts} {
+		for _, result := range results {
+			for _, imp := range result.Imports {
+				aliases[imp.Address] = true
+			}
+		}
+	}
+	for network, contracts := range contractAddresses {
+		if report.Addresses == nil {
+			report.Addresses = make(map[string]interface{})
+		}
+		networkAddresses, ok := report.Addresses[network].(map[string]interface{})
+		if !ok {
+			networkAddresses = make(map[string]interface{})
+			report.Addresses[network] = networkAddresses
+		}
+		for alias, address := range contracts {
+			if !aliases[alias] {
+				continue
+			}
+			if _, ok := networkAddresses[alias]; ok {
+				continue
+			}
+			if _, ok := networkAddresses[strings.TrimPrefix(alias, "0x")]; ok {
+				continue
+			}
+			networkAddresses[alias] = address
+		}
+	}
+}
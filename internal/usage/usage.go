@@ -0,0 +1,125 @@
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+	"github.com/outblock/cadence-codegen/internal/generator/golang"
+	"github.com/outblock/cadence-codegen/internal/generator/swift"
+	"github.com/outblock/cadence-codegen/internal/generator/typescript"
+	"github.com/outblock/cadence-codegen/internal/names"
+)
+
+// Counts maps the name of a called function, e.g. getBalance, or the file name
+// of an interaction to its number of calls, as exported from app analytics
+type Counts map[string]int
+
+// Load reads the usage export at path, a JSON object of call counts
+func Load(path string) (Counts, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage export: %w", err)
+	}
+	counts := make(Counts)
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return nil, fmt.Errorf("failed to parse usage export %s: %w", path, err)
+	}
+	return counts, nil
+}
+
+// Interaction is a script or transaction and the calls counted for it
+type Interaction struct {
+	FileName string `json:"fileName"`
+	Type     string `json:"type"`
+	Calls    int    `json:"calls"`
+}
+
+// Result splits the interactions of a report by whether they are called
+type Result struct {
+	Used    []Interaction `json:"used"`
+	Unused  []Interaction `json:"unused"`
+	Unknown []string      `json:"unknown"` // Names of the export matching no interaction
+}
+
+// Analyze counts the calls of every script and transaction of report. An
+// interaction is called by its file name, with or without .cdc, its
+// TypeScript, Swift or Go function name, or a name recorded for it in
+// recorded, e.g. a names file.
+func Analyze(report analyzer.Report, counts Counts, recorded names.Names) *Result {
+	result := &Result{Used: []Interaction{}, Unused: []Interaction{}, Unknown: []string{}}
+	matched := make(map[string]bool)
+	for _, results := range []map[string]analyzer.AnalysisResult{report.Transactions, report.Scripts} {
+		for fileName, r := range results {
+			interaction := Interaction{FileName: fileName, Type: r.Type}
+			for _, name := range aliases(fileName, recorded) {
+				if calls, ok := counts[name]; ok {
+					interaction.Calls += calls
+					matched[name] = true
+				}
+			}
+			if interaction.Calls > 0 {
+				result.Used = append(result.Used, interaction)
+			} else {
+				result.Unused = append(result.Unused, interaction)
+			}
+		}
+	}
+	for name := range counts {
+		if !matched[name] {
+			result.Unknown = append(result.Unknown, name)
+		}
+	}
+
+	for _, interactions := range [][]Interaction{result.Used, result.Unused} {
+		sort.Slice(interactions, func(i, j int) bool {
+			if interactions[i].Type != interactions[j].Type {
+				return interactions[i].Type > interactions[j].Type
+			}
+			return interactions[i].FileName < interactions[j].FileName
+		})
+	}
+	sort.Strings(result.Unknown)
+	return result
+}
+
+// aliases returns the distinct names an interaction can be called by
+func aliases(fileName string, recorded names.Names) []string {
+	candidates := []string{
+		fileName,
+		strings.TrimSuffix(fileName, ".cdc"),
+		typescript.FunctionName(fileName),
+		swift.FunctionName(fileName),
+		golang.FunctionName(fileName),
+	}
+	for _, target := range recorded {
+		if name, ok := target[fileName]; ok {
+			candidates = append(candidates, name)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var aliases []string
+	for _, name := range candidates {
+		if !seen[name] {
+			seen[name] = true
+			aliases = append(aliases, name)
+		}
+	}
+	return aliases
+}
+
+// Prune removes the unused interactions of result from report, and the
+// structs only they use
+func (r *Result) Prune(report *analyzer.Report) {
+	unused := make(map[string]bool)
+	for _, interaction := range r.Unused {
+		unused[interaction.Type+"/"+interaction.FileName] = true
+	}
+	report.Retain(func(name string, result analyzer.AnalysisResult) bool {
+		return !unused[result.Type+"/"+name]
+	})
+}
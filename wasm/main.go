@@ -0,0 +1,68 @@
+//go:build js && wasm
+
+// Command wasm exposes the analyzer to JavaScript when compiled to WebAssembly:
+//
+//	GOOS=js GOARCH=wasm go build -o cadence-codegen.wasm ./wasm
+//
+// Once the module is running, globalThis.cadenceCodegen.analyzeSource(code, fileName?)
+// returns a Promise of the same report format as the analyze command.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"syscall/js"
+
+	"github.com/outblock/cadence-codegen/internal/analyzer"
+)
+
+// analyzeSource analyzes a single Cadence source string and returns the report as a JSON string
+func analyzeSource(code string, fileName string) (string, error) {
+	a := analyzer.New()
+	a.SetIncludeBase64(true)
+	if _, err := a.AnalyzeSource(fileName, []byte(code)); err != nil {
+		return "", fmt.Errorf("failed to analyze %s: %w", fileName, err)
+	}
+
+	jsonData, err := json.Marshal(a.GetReport())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(jsonData), nil
+}
+
+// jsAnalyzeSource is the JavaScript binding of analyzeSource. It returns a
+// Promise that resolves to the parsed report or rejects with an Error.
+func jsAnalyzeSource(this js.Value, args []js.Value) interface{} {
+	executor := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve, reject := promiseArgs[0], promiseArgs[1]
+		if len(args) < 1 || args[0].Type() != js.TypeString {
+			reject.Invoke(js.Global().Get("Error").New("analyzeSource expects the Cadence code as first argument"))
+			return nil
+		}
+		fileName := "main.cdc"
+		if len(args) > 1 && args[1].Type() == js.TypeString {
+			fileName = args[1].String()
+		}
+
+		report, err := analyzeSource(args[0].String(), fileName)
+		if err != nil {
+			reject.Invoke(js.Global().Get("Error").New(err.Error()))
+			return nil
+		}
+		resolve.Invoke(js.Global().Get("JSON").Call("parse", report))
+		return nil
+	})
+	defer executor.Release()
+
+	return js.Global().Get("Promise").New(executor)
+}
+
+func main() {
+	js.Global().Set("cadenceCodegen", js.ValueOf(map[string]interface{}{
+		"analyzeSource": js.FuncOf(jsAnalyzeSource),
+	}))
+
+	// Keep the module alive so the exported functions stay callable
+	select {}
+}